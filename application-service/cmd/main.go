@@ -8,6 +8,7 @@ import (
 
 	"github.com/compendium-tech/compendium/common/pkg/auth"
 	"github.com/compendium-tech/compendium/common/pkg/pg"
+	"github.com/compendium-tech/compendium/common/pkg/redis"
 	"github.com/compendium-tech/compendium/common/pkg/validate"
 
 	"github.com/compendium-tech/compendium/application-service/internal/app"
@@ -38,17 +39,31 @@ func main() {
 		return
 	}
 
+	redisClient, err := redis.NewRedisClient(ctx, cfg.RedisHost, cfg.RedisPort)
+	if err != nil {
+		fmt.Printf("Failed to connect to Redis, cause: %v\n", err)
+		return
+	}
+
 	llmService, err := interop.NewGrpcLLMServiceClient(cfg.GrpcLLMServiceClientTarget)
 	if err != nil {
 		fmt.Printf("Failed to initialize llm service client, cause: %v\n", err)
 		return
 	}
 
+	subscriptionService, err := interop.NewGrpcSubscriptionServiceClient(cfg.GrpcSubscriptionServiceClientTarget)
+	if err != nil {
+		fmt.Printf("Failed to initialize subscription service client, cause: %v\n", err)
+		return
+	}
+
 	deps := app.Dependencies{
-		Config:       cfg,
-		TokenManager: tokenManager,
-		PgDB:         pgDB,
-		LLMService:   llmService,
+		Config:              cfg,
+		TokenManager:        tokenManager,
+		PgDB:                pgDB,
+		RedisClient:         redisClient,
+		LLMService:          llmService,
+		SubscriptionService: subscriptionService,
 	}
 
 	err = app.NewApp(deps).Run()
@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+	"github.com/compendium-tech/compendium/common/pkg/log"
+)
+
+const defaultMaxIterations = 8
+
+// ToolHandler executes the tool with the parameters chosen by the model and returns the result sent back to it.
+type ToolHandler func(ctx context.Context, parameters map[string]any) map[string]any
+
+// Tool is a Go function the model is allowed to call.
+type Tool struct {
+	Definition domain.LLMToolDefinition
+	Handler    ToolHandler
+}
+
+// Agent runs the tool-calling loop: it sends the chat history to the model, executes the tools
+// the model calls, sends the results back and repeats until the model answers without calling tools.
+type Agent interface {
+	RegisterTool(tool Tool)
+	// Run returns the chat history extended with the generated messages, the last of which is the final answer.
+	Run(ctx context.Context, chatHistory []domain.LLMMessage,
+		structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions) []domain.LLMMessage
+}
+
+type agent struct {
+	llmService interop.LLMService
+	tools      map[string]Tool
+	// toolDefinitions keep the registration order, so that the prompts are stable.
+	toolDefinitions []domain.LLMToolDefinition
+	maxIterations   int
+}
+
+// NewAgent creates an agent which gives up after maxIterations model calls. Non-positive
+// maxIterations means the default limit.
+func NewAgent(llmService interop.LLMService, maxIterations int) Agent {
+	if maxIterations <= 0 {
+		maxIterations = defaultMaxIterations
+	}
+
+	return &agent{
+		llmService:    llmService,
+		tools:         make(map[string]Tool),
+		maxIterations: maxIterations,
+	}
+}
+
+func (a *agent) RegisterTool(tool Tool) {
+	if _, ok := a.tools[tool.Definition.Name]; ok {
+		panic(fmt.Errorf("tool %s is already registered", tool.Definition.Name))
+	}
+
+	a.tools[tool.Definition.Name] = tool
+	a.toolDefinitions = append(a.toolDefinitions, tool.Definition)
+}
+
+func (a *agent) Run(
+	ctx context.Context, chatHistory []domain.LLMMessage,
+	structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions) []domain.LLMMessage {
+	history := append([]domain.LLMMessage(nil), chatHistory...)
+
+	for range a.maxIterations {
+		response := a.llmService.GenerateResponse(ctx, history, a.toolDefinitions, structuredOutputSchema, options)
+		history = append(history, response)
+
+		if len(response.ToolCalls) == 0 {
+			return history
+		}
+
+		for _, toolCall := range response.ToolCalls {
+			history = append(history, domain.LLMMessage{
+				Role:       domain.RoleTool,
+				ToolResult: a.callTool(ctx, toolCall),
+			})
+		}
+	}
+
+	panic(fmt.Errorf("agent didn't produce the final answer in %d iterations", a.maxIterations))
+}
+
+// callTool reports unknown tools to the model instead of failing, so it gets a chance to correct itself.
+func (a *agent) callTool(ctx context.Context, toolCall domain.LLMToolCall) *domain.LLMToolResult {
+	result := &domain.LLMToolResult{
+		ToolCallID: toolCall.ID,
+		Name:       toolCall.Name,
+	}
+
+	tool, ok := a.tools[toolCall.Name]
+	if !ok {
+		log.L(ctx).Warnf("Model called unknown tool %s", toolCall.Name)
+
+		result.Result = map[string]any{"error": fmt.Sprintf("unknown tool %s", toolCall.Name)}
+		return result
+	}
+
+	log.L(ctx).Debugf("Calling tool %s", toolCall.Name)
+
+	result.Result = tool.Handler(ctx, toolCall.Parameters)
+	return result
+}
@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+)
+
+func TestAgentRunsToolsUntilFinalAnswer(t *testing.T) {
+	prompt := []domain.LLMMessage{{Role: domain.RoleUser, Text: "How many hours do I spend on activities?"}}
+	toolCall := domain.LLMMessage{
+		Role: domain.RoleAssistant,
+		ToolCalls: []domain.LLMToolCall{
+			{ID: "call1", Name: "get_activities_hours", Parameters: map[string]any{"perWeek": true}},
+		},
+	}
+	toolResult := domain.LLMMessage{
+		Role: domain.RoleTool,
+		ToolResult: &domain.LLMToolResult{
+			ToolCallID: "call1", Name: "get_activities_hours", Result: map[string]any{"hours": 12},
+		},
+	}
+	finalAnswer := domain.LLMMessage{Role: domain.RoleAssistant, Text: "12 hours per week."}
+
+	llmService := interop.NewFakeLLMService(map[string]domain.LLMMessage{
+		interop.LLMPromptFingerprint(prompt):                               toolCall,
+		interop.LLMPromptFingerprint(append(prompt, toolCall, toolResult)): finalAnswer,
+	})
+
+	var calledWith map[string]any
+	agent := NewAgent(llmService, 0)
+	agent.RegisterTool(Tool{
+		Definition: domain.LLMToolDefinition{Name: "get_activities_hours"},
+		Handler: func(_ context.Context, parameters map[string]any) map[string]any {
+			calledWith = parameters
+			return map[string]any{"hours": 12}
+		},
+	})
+
+	history := agent.Run(context.Background(), prompt, nil, domain.LLMGenerationOptions{})
+
+	assert.Equal(t, map[string]any{"perWeek": true}, calledWith)
+	require.Len(t, history, 4)
+	assert.Equal(t, toolResult, history[2])
+	assert.Equal(t, finalAnswer, history[3])
+}
+
+func TestAgentReportsUnknownToolsToModel(t *testing.T) {
+	prompt := []domain.LLMMessage{{Role: domain.RoleUser, Text: "Hi"}}
+	toolCall := domain.LLMMessage{
+		Role:      domain.RoleAssistant,
+		ToolCalls: []domain.LLMToolCall{{ID: "call1", Name: "missing", Parameters: map[string]any{}}},
+	}
+
+	llmService := interop.NewFakeLLMService(map[string]domain.LLMMessage{
+		interop.LLMPromptFingerprint(prompt): toolCall,
+	})
+
+	history := NewAgent(llmService, 0).Run(context.Background(), prompt, nil, domain.LLMGenerationOptions{})
+
+	require.Len(t, history, 4)
+	assert.Equal(t, map[string]any{"error": "unknown tool missing"}, history[2].ToolResult.Result)
+	assert.Empty(t, history[3].ToolCalls)
+}
+
+func TestAgentGivesUpAfterMaxIterations(t *testing.T) {
+	prompt := []domain.LLMMessage{{Role: domain.RoleUser, Text: "Loop forever"}}
+	toolCall := domain.LLMMessage{
+		Role:      domain.RoleAssistant,
+		ToolCalls: []domain.LLMToolCall{{ID: "call1", Name: "missing", Parameters: map[string]any{}}},
+	}
+
+	llmService := interop.NewFakeLLMService(map[string]domain.LLMMessage{
+		interop.LLMPromptFingerprint(prompt): toolCall,
+	})
+
+	assert.Panics(t, func() {
+		NewAgent(llmService, 1).Run(context.Background(), prompt, nil, domain.LLMGenerationOptions{})
+	})
+}
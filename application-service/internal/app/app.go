@@ -1,9 +1,11 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 
 	"github.com/compendium-tech/compendium/common/pkg/auth"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/compendium-tech/compendium/application-service/internal/config"
 	httpv1 "github.com/compendium-tech/compendium/application-service/internal/delivery/http/v1"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
 	"github.com/compendium-tech/compendium/application-service/internal/interop"
 	"github.com/compendium-tech/compendium/application-service/internal/repository"
 	"github.com/compendium-tech/compendium/application-service/internal/service"
@@ -21,8 +24,12 @@ import (
 type Dependencies struct {
 	Config       *config.AppConfig
 	PgDB         *sql.DB
+	RedisClient  *redis.Client
 	TokenManager auth.TokenManager
 	LLMService   interop.LLMService
+	// SubscriptionService reports the subscription tiers of the users, which set the features they may use
+	// and their quotas.
+	SubscriptionService interop.SubscriptionService
 }
 
 func NewApp(deps Dependencies) netapp.GinApp {
@@ -34,7 +41,39 @@ func NewApp(deps Dependencies) netapp.GinApp {
 
 	applicationRepository := repository.NewPgApplicationRepository(deps.PgDB)
 	applicationService := service.NewApplicationService(applicationRepository)
-	applicationEvaluationService := service.NewApplicationEvaluateService(applicationRepository, deps.LLMService)
+	subscriptionTierService := service.NewSubscriptionTierService(
+		deps.SubscriptionService, repository.NewRedisSubscriptionRepository(deps.RedisClient),
+		service.SubscriptionTierConfig{
+			CacheTTL: deps.Config.SubscriptionTierCacheTTL,
+			FeatureTiers: map[domain.Feature][]domain.SubscriptionTier{
+				domain.FeatureApplicationEvaluation: subscriptionTiers(deps.Config.ApplicationEvaluationTiers),
+				domain.FeatureEssayCoach:            subscriptionTiers(deps.Config.EssayCoachTiers),
+				domain.FeatureCounselorSharing:      subscriptionTiers(deps.Config.CounselorSharingTiers),
+			},
+		})
+	usageService := service.NewUsageService(
+		repository.NewPgUsageRepository(deps.PgDB), subscriptionTierService, service.UsageConfig{
+			MonthlyQuotas: map[domain.Feature]map[domain.SubscriptionTier]int{
+				domain.FeatureApplicationEvaluation: subscriptionTierQuotas(
+					deps.Config.ApplicationEvaluationMonthlyQuotas),
+				domain.FeatureEssayCoach:   subscriptionTierQuotas(deps.Config.EssayCoachMonthlyQuotas),
+				domain.FeatureEssayRewrite: subscriptionTierQuotas(deps.Config.EssayRewriteMonthlyQuotas),
+			},
+		})
+	applicationEvaluationService := service.NewApplicationEvaluateService(
+		applicationRepository, repository.NewPgApplicationEvaluationRepository(deps.PgDB), deps.LLMService,
+		usageService, deps.Config.ApplicationEvaluationPromptVersion)
+	applicationEvaluationJobService := service.NewApplicationEvaluationJobService(
+		applicationRepository, repository.NewPgApplicationEvaluationJobRepository(deps.PgDB),
+		applicationEvaluationService, service.ApplicationEvaluationJobConfig{
+			Workers: deps.Config.ApplicationEvaluationWorkers,
+			Lease:   deps.Config.ApplicationEvaluationJobLease,
+		})
+	essayCoachService := service.NewEssayCoachService(
+		applicationRepository, repository.NewPgEssayChatRepository(deps.PgDB), deps.LLMService, usageService,
+		service.EssayCoachConfig{PromptVersion: deps.Config.EssayCoachPromptVersion})
+
+	go applicationEvaluationJobService.Run(context.Background())
 
 	r := gin.Default()
 	r.Use(middleware.RequestIDMiddleware{AllowToSet: false}.Handle)
@@ -42,7 +81,30 @@ func NewApp(deps Dependencies) netapp.GinApp {
 	r.Use(middleware.LoggerMiddleware{LogProcessedRequests: true, LogFinishedRequests: true}.Handle)
 
 	httpv1.NewApplicationController(applicationService).MakeRoutes(r)
-	httpv1.NewApplicationEvaluationController(applicationService, applicationEvaluationService).MakeRoutes(r)
+	httpv1.NewApplicationEvaluationController(
+		applicationService, applicationEvaluationService, applicationEvaluationJobService,
+		subscriptionTierService, usageService).MakeRoutes(r)
+	httpv1.NewEssayCoachController(
+		applicationService, essayCoachService, subscriptionTierService).MakeRoutes(r)
+	httpv1.NewUsageController(usageService).MakeRoutes(r)
 
 	return netapp.NewGinApp(r)
 }
+
+func subscriptionTiers(tiers []string) []domain.SubscriptionTier {
+	subscriptionTiers := make([]domain.SubscriptionTier, len(tiers))
+	for i, tier := range tiers {
+		subscriptionTiers[i] = domain.SubscriptionTier(tier)
+	}
+
+	return subscriptionTiers
+}
+
+func subscriptionTierQuotas(quotas map[string]int) map[domain.SubscriptionTier]int {
+	subscriptionTierQuotas := make(map[domain.SubscriptionTier]int, len(quotas))
+	for tier, quota := range quotas {
+		subscriptionTierQuotas[domain.SubscriptionTier(tier)] = quota
+	}
+
+	return subscriptionTierQuotas
+}
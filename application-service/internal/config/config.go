@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 )
 
 const (
@@ -11,6 +13,27 @@ const (
 	EnvironmentProd string = "prod"
 )
 
+const (
+	defaultApplicationEvaluationWorkers  = 4
+	defaultApplicationEvaluationJobLease = 5 * time.Minute
+	defaultSubscriptionTierCacheTTL      = time.Minute
+)
+
+// The tiers which may use the features by default. The users without a subscription may try the essay coach
+// within their monthly quota.
+var (
+	defaultApplicationEvaluationTiers = []string{"student", "team", "community"}
+	defaultEssayCoachTiers            = []string{"none", "student", "team", "community"}
+	defaultCounselorSharingTiers      = []string{"team", "community"}
+)
+
+// The monthly quotas of the features by default.
+var (
+	defaultApplicationEvaluationMonthlyQuotas = map[string]int{"none": 0, "student": 20, "team": 40, "community": 40}
+	defaultEssayCoachMonthlyQuotas            = map[string]int{"none": 50, "student": 1000, "team": 2000, "community": 2000}
+	defaultEssayRewriteMonthlyQuotas          = map[string]int{"none": 0, "student": 100, "team": 200, "community": 200}
+)
+
 type AppConfig struct {
 	Environment                string
 	PgHost                     string
@@ -18,9 +41,34 @@ type AppConfig struct {
 	PgUsername                 string
 	PgPassword                 string
 	PgDatabaseName             string
+	RedisHost                  string
+	RedisPort                  uint16
 	JwtSingingKey              string
 	GrpcLLMServiceClientTarget string
 	CsrfTokenHashSalt          string
+	// ApplicationEvaluationPromptVersion pins the version of the prompt template used to evaluate
+	// applications. Zero means the latest version.
+	ApplicationEvaluationPromptVersion  int32
+	GrpcSubscriptionServiceClientTarget string
+	// EssayCoachPromptVersion pins the version of the system prompt template of the essay coach. Zero means
+	// the latest version.
+	EssayCoachPromptVersion int32
+	// Every replica runs ApplicationEvaluationWorkers evaluation jobs at a time. A job running for longer than
+	// ApplicationEvaluationJobLease is taken over by another worker.
+	ApplicationEvaluationWorkers  int
+	ApplicationEvaluationJobLease time.Duration
+	// SubscriptionTierCacheTTL is how long the tiers reported by subscription-service are cached.
+	SubscriptionTierCacheTTL time.Duration
+	// *Tiers list the subscription tiers which may use each feature, "none" is for the users without
+	// a subscription.
+	ApplicationEvaluationTiers []string
+	EssayCoachTiers            []string
+	CounselorSharingTiers      []string
+	// *MonthlyQuotas are how many times the users of each subscription tier may use each feature per billing
+	// period. Tiers missing from the quotas can't use the feature.
+	ApplicationEvaluationMonthlyQuotas map[string]int
+	EssayCoachMonthlyQuotas            map[string]int
+	EssayRewriteMonthlyQuotas          map[string]int
 }
 
 func LoadAppConfig() *AppConfig {
@@ -30,9 +78,21 @@ func LoadAppConfig() *AppConfig {
 		PgUsername:                 os.Getenv("POSTGRES_USERNAME"),
 		PgPassword:                 os.Getenv("POSTGRES_PASSWORD"),
 		PgDatabaseName:             os.Getenv("POSTGRES_DATABASE_NAME"),
+		RedisHost:                  os.Getenv("REDIS_HOST"),
 		JwtSingingKey:              os.Getenv("JWT_SIGNING_KEY"),
 		CsrfTokenHashSalt:          os.Getenv("CSRF_TOKEN_HASH_SALT"),
 		GrpcLLMServiceClientTarget: os.Getenv("GRPC_LLM_SERVICE_CLIENT_TARGET"),
+
+		GrpcSubscriptionServiceClientTarget: os.Getenv("GRPC_SUBSCRIPTION_SERVICE_CLIENT_TARGET"),
+		ApplicationEvaluationWorkers:        defaultApplicationEvaluationWorkers,
+		ApplicationEvaluationJobLease:       defaultApplicationEvaluationJobLease,
+		SubscriptionTierCacheTTL:            defaultSubscriptionTierCacheTTL,
+		ApplicationEvaluationTiers:          defaultApplicationEvaluationTiers,
+		EssayCoachTiers:                     defaultEssayCoachTiers,
+		CounselorSharingTiers:               defaultCounselorSharingTiers,
+		ApplicationEvaluationMonthlyQuotas:  defaultApplicationEvaluationMonthlyQuotas,
+		EssayCoachMonthlyQuotas:             defaultEssayCoachMonthlyQuotas,
+		EssayRewriteMonthlyQuotas:           defaultEssayRewriteMonthlyQuotas,
 	}
 
 	env := os.Getenv("ENVIRONMENT")
@@ -52,5 +112,133 @@ func LoadAppConfig() *AppConfig {
 		}
 	}
 
+	if port := os.Getenv("REDIS_PORT"); port != "" {
+		var redisPort uint16
+		_, err := fmt.Sscan(port, &redisPort)
+
+		if err == nil {
+			appConfig.RedisPort = redisPort
+		} else {
+			log.Printf("Failed to parse redis port: %s", port)
+		}
+	}
+
+	if version := os.Getenv("APPLICATION_EVALUATION_PROMPT_VERSION"); version != "" {
+		var promptVersion int32
+		_, err := fmt.Sscan(version, &promptVersion)
+
+		if err == nil {
+			appConfig.ApplicationEvaluationPromptVersion = promptVersion
+		} else {
+			log.Printf("Failed to parse application evaluation prompt version: %s", version)
+		}
+	}
+
+	if version := os.Getenv("ESSAY_COACH_PROMPT_VERSION"); version != "" {
+		var promptVersion int32
+		_, err := fmt.Sscan(version, &promptVersion)
+
+		if err == nil {
+			appConfig.EssayCoachPromptVersion = promptVersion
+		} else {
+			log.Printf("Failed to parse essay coach prompt version: %s", version)
+		}
+	}
+
+	loadPositiveInt("APPLICATION_EVALUATION_WORKERS", "application evaluation workers",
+		&appConfig.ApplicationEvaluationWorkers)
+	loadDuration("APPLICATION_EVALUATION_JOB_LEASE", "application evaluation job lease",
+		&appConfig.ApplicationEvaluationJobLease)
+	loadDuration("SUBSCRIPTION_TIER_CACHE_TTL", "subscription tier cache TTL", &appConfig.SubscriptionTierCacheTTL)
+
+	loadList("APPLICATION_EVALUATION_TIERS", &appConfig.ApplicationEvaluationTiers)
+	loadList("ESSAY_COACH_TIERS", &appConfig.EssayCoachTiers)
+	loadList("COUNSELOR_SHARING_TIERS", &appConfig.CounselorSharingTiers)
+
+	loadQuotas("APPLICATION_EVALUATION_MONTHLY_QUOTAS", "application evaluation monthly quotas",
+		&appConfig.ApplicationEvaluationMonthlyQuotas)
+	loadQuotas("ESSAY_COACH_MONTHLY_QUOTAS", "essay coach monthly quotas", &appConfig.EssayCoachMonthlyQuotas)
+	loadQuotas("ESSAY_REWRITE_MONTHLY_QUOTAS", "essay rewrite monthly quotas", &appConfig.EssayRewriteMonthlyQuotas)
+
 	return appConfig
 }
+
+// loadPositiveInt overrides the number with the environment variable if it is set and positive.
+func loadPositiveInt(key string, name string, number *int) {
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+
+	var parsed int
+	_, err := fmt.Sscan(value, &parsed)
+
+	if err == nil && parsed > 0 {
+		*number = parsed
+	} else {
+		log.Printf("Failed to parse %s: %s", name, value)
+	}
+}
+
+// loadDuration overrides the duration with the environment variable if it is set and positive.
+func loadDuration(key string, name string, duration *time.Duration) {
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err == nil && parsed > 0 {
+		*duration = parsed
+	} else {
+		log.Printf("Failed to parse %s: %s", name, value)
+	}
+}
+
+// loadList overrides the list with the comma-separated environment variable if it is set. A variable
+// with no items empties the list.
+func loadList(key string, list *[]string) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return
+	}
+
+	*list = nil
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*list = append(*list, item)
+		}
+	}
+}
+
+// loadQuotas overrides the quotas with the environment variable if it is set and valid. The variable is
+// a comma-separated list of tier:quota pairs, e.g. "none:0,student:20".
+func loadQuotas(key string, name string, quotas *map[string]int) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return
+	}
+
+	parsed := make(map[string]int)
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		tier, quota, found := strings.Cut(item, ":")
+
+		var parsedQuota int
+		_, err := fmt.Sscan(quota, &parsedQuota)
+
+		if !found || err != nil || parsedQuota < 0 {
+			log.Printf("Failed to parse %s: %s", name, value)
+			return
+		}
+
+		parsed[strings.TrimSpace(tier)] = parsedQuota
+	}
+
+	*quotas = parsed
+}
@@ -0,0 +1,31 @@
+package localcontext
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+)
+
+type _subscriptionKey struct{}
+
+var subscriptionKey = _subscriptionKey{}
+
+func SetSubscription(ctx *context.Context, subscription domain.Subscription) {
+	*ctx = context.WithValue(*ctx, subscriptionKey, subscription)
+}
+
+func GetSubscription(ctx context.Context) domain.Subscription {
+	if subscription, ok := ctx.Value(subscriptionKey).(domain.Subscription); ok {
+		return subscription
+	} else {
+		panic(fmt.Errorf("middleware didn't set current subscription value, perhaps it wasn't enabled?"))
+	}
+}
+
+// GetSubscriptionTierOrEmpty returns an empty tier if the subscription wasn't resolved, e.g. on the routes
+// which don't require any feature.
+func GetSubscriptionTierOrEmpty(ctx context.Context) domain.SubscriptionTier {
+	subscription, _ := ctx.Value(subscriptionKey).(domain.Subscription)
+	return subscription.Tier
+}
@@ -44,6 +44,9 @@ func (a ApplicationController) MakeRoutes(e *gin.Engine) {
 				application.GET("/activities", eh.Handle(a.getActivities))
 				application.PUT("/activities", auth.RequireCsrf, eh.Handle(a.putActivities))
 
+				application.GET("/academics", eh.Handle(a.getAcademics))
+				application.PUT("/academics", auth.RequireCsrf, eh.Handle(a.putAcademics))
+
 				application.GET("/honors", eh.Handle(a.getHonors))
 				application.PUT("/honors", auth.RequireCsrf, eh.Handle(a.putHonors))
 
@@ -92,6 +95,17 @@ func (a ApplicationController) putActivities(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+func (a ApplicationController) getAcademics(c *gin.Context) {
+	c.JSON(http.StatusOK, a.applicationService.GetAcademics(c.Request.Context()))
+}
+
+func (a ApplicationController) putAcademics(c *gin.Context) {
+	a.applicationService.PutAcademics(
+		c.Request.Context(),
+		httputils.MustBindWith[domain.UpdateAcademicsRequest](c, binding.JSON).Validated())
+	c.Status(http.StatusOK)
+}
+
 func (a ApplicationController) getHonors(c *gin.Context) {
 	c.JSON(http.StatusOK, a.applicationService.GetHonors(c.Request.Context()))
 }
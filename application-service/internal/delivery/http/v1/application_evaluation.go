@@ -8,21 +8,31 @@ import (
 	"github.com/compendium-tech/compendium/common/pkg/auth"
 	httputils "github.com/compendium-tech/compendium/common/pkg/http"
 
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
 	"github.com/compendium-tech/compendium/application-service/internal/middleware"
 	"github.com/compendium-tech/compendium/application-service/internal/service"
 )
 
 type ApplicationEvaluationController struct {
-	applicationService           service.ApplicationService
-	applicationEvaluationService service.ApplicationEvaluationService
+	applicationService              service.ApplicationService
+	applicationEvaluationService    service.ApplicationEvaluationService
+	applicationEvaluationJobService service.ApplicationEvaluationJobService
+	subscriptionTierService         service.SubscriptionTierService
+	usageService                    service.UsageService
 }
 
 func NewApplicationEvaluationController(
 	applicationService service.ApplicationService,
-	applicationEvaluationService service.ApplicationEvaluationService) ApplicationEvaluationController {
+	applicationEvaluationService service.ApplicationEvaluationService,
+	applicationEvaluationJobService service.ApplicationEvaluationJobService,
+	subscriptionTierService service.SubscriptionTierService,
+	usageService service.UsageService) ApplicationEvaluationController {
 	return ApplicationEvaluationController{
-		applicationService:           applicationService,
-		applicationEvaluationService: applicationEvaluationService,
+		applicationService:              applicationService,
+		applicationEvaluationService:    applicationEvaluationService,
+		applicationEvaluationJobService: applicationEvaluationJobService,
+		subscriptionTierService:         subscriptionTierService,
+		usageService:                    usageService,
 	}
 }
 
@@ -37,12 +47,76 @@ func (a ApplicationEvaluationController) MakeRoutes(e *gin.Engine) {
 			application := authenticated.Group("/applications/:applicationId")
 			application.Use(middleware.NewSetApplicationFromRequest(a.applicationService).Handle)
 			{
-				application.POST("/evaluations", eh.Handle(a.evaluateApplication))
+				// The evaluations made before the subscription ended stay available.
+				requireEvaluation := middleware.NewRequireFeature(
+					a.subscriptionTierService, domain.FeatureApplicationEvaluation)
+				requireEvaluationQuota := middleware.NewRequireQuota(
+					a.usageService, domain.FeatureApplicationEvaluation)
+
+				application.GET("/evaluations", eh.Handle(a.getApplicationEvaluations))
+				application.GET("/evaluations/:evaluationId", eh.Handle(a.getApplicationEvaluation))
+				application.POST("/evaluations", requireEvaluation.Handle, requireEvaluationQuota.Handle,
+					eh.Handle(a.enqueueApplicationEvaluation))
+				application.POST("/evaluations/stream", requireEvaluation.Handle, requireEvaluationQuota.Handle,
+					eh.Handle(a.streamApplicationEvaluation))
+				application.GET("/evaluations/jobs/:jobId", eh.Handle(a.getApplicationEvaluationJob))
+				application.GET("/evaluations/jobs/:jobId/events", eh.Handle(a.watchApplicationEvaluationJob))
 			}
 		}
 	}
 }
 
-func (a ApplicationEvaluationController) evaluateApplication(c *gin.Context) {
-	c.JSON(http.StatusOK, a.applicationEvaluationService.EvaluateCurrentApplication(c.Request.Context()))
+func (a ApplicationEvaluationController) getApplicationEvaluations(c *gin.Context) {
+	c.JSON(http.StatusOK, a.applicationEvaluationService.GetCurrentApplicationEvaluations(c.Request.Context()))
+}
+
+func (a ApplicationEvaluationController) getApplicationEvaluation(c *gin.Context) {
+	c.JSON(http.StatusOK, a.applicationEvaluationService.GetCurrentApplicationEvaluation(
+		c.Request.Context(), uuidFromPath(c, "evaluationId")))
+}
+
+// enqueueApplicationEvaluation evaluates the application in the background, as the evaluation may outlast
+// the request. The client polls the returned job or watches its events.
+func (a ApplicationEvaluationController) enqueueApplicationEvaluation(c *gin.Context) {
+	c.JSON(http.StatusAccepted,
+		a.applicationEvaluationJobService.EnqueueCurrentApplicationEvaluation(c.Request.Context()))
+}
+
+func (a ApplicationEvaluationController) getApplicationEvaluationJob(c *gin.Context) {
+	c.JSON(http.StatusOK, a.applicationEvaluationJobService.GetCurrentApplicationEvaluationJob(
+		c.Request.Context(), uuidFromPath(c, "jobId")))
+}
+
+// watchApplicationEvaluationJob sends the job as a "status" server-sent event every time its status changes,
+// until it succeeds or fails. If watching fails, the final event is "error".
+func (a ApplicationEvaluationController) watchApplicationEvaluationJob(c *gin.Context) {
+	jobID := uuidFromPath(c, "jobId")
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	defer recoverStream(c)
+
+	a.applicationEvaluationJobService.WatchCurrentApplicationEvaluationJob(
+		c.Request.Context(), jobID, func(job domain.ApplicationEvaluationJobResponse) {
+			c.SSEvent("status", job)
+			c.Writer.Flush()
+		})
+}
+
+// streamApplicationEvaluation relays the model output to the client using server-sent events:
+// "delta" events carry raw text as it is generated, the final "evaluation" event carries the parsed result.
+// If the evaluation fails midway, the final event is "error" instead.
+func (a ApplicationEvaluationController) streamApplicationEvaluation(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	defer recoverStream(c)
+
+	evaluation := a.applicationEvaluationService.EvaluateCurrentApplicationStream(
+		c.Request.Context(), func(textDelta string) {
+			c.SSEvent("delta", textDelta)
+			c.Writer.Flush()
+		})
+
+	c.SSEvent("evaluation", evaluation)
+	c.Writer.Flush()
 }
@@ -0,0 +1,92 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+	httputils "github.com/compendium-tech/compendium/common/pkg/http"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	"github.com/compendium-tech/compendium/application-service/internal/middleware"
+	"github.com/compendium-tech/compendium/application-service/internal/service"
+)
+
+type EssayCoachController struct {
+	applicationService      service.ApplicationService
+	essayCoachService       service.EssayCoachService
+	subscriptionTierService service.SubscriptionTierService
+}
+
+func NewEssayCoachController(
+	applicationService service.ApplicationService,
+	essayCoachService service.EssayCoachService,
+	subscriptionTierService service.SubscriptionTierService) EssayCoachController {
+	return EssayCoachController{
+		applicationService:      applicationService,
+		essayCoachService:       essayCoachService,
+		subscriptionTierService: subscriptionTierService,
+	}
+}
+
+func (a EssayCoachController) MakeRoutes(e *gin.Engine) {
+	var eh httputils.ErrorHandler
+
+	v1 := e.Group("/v1")
+	{
+		authenticated := v1.Group("/")
+		authenticated.Use(auth.RequireAuth)
+		{
+			application := authenticated.Group("/applications/:applicationId")
+			application.Use(middleware.NewSetApplicationFromRequest(a.applicationService).Handle)
+			{
+				requireEssayCoach := middleware.NewRequireFeature(a.subscriptionTierService, domain.FeatureEssayCoach)
+
+				application.GET("/essays/:essayId/chat", eh.Handle(a.getChatMessages))
+				application.POST("/essays/:essayId/chat", auth.RequireCsrf, requireEssayCoach.Handle,
+					eh.Handle(a.sendChatMessage))
+				application.POST("/essays/:essayId/chat/stream", auth.RequireCsrf, requireEssayCoach.Handle,
+					eh.Handle(a.streamChatMessage))
+				application.DELETE("/essays/:essayId/chat", auth.RequireCsrf, eh.Handle(a.removeChatMessages))
+			}
+		}
+	}
+}
+
+func (a EssayCoachController) getChatMessages(c *gin.Context) {
+	c.JSON(http.StatusOK, a.essayCoachService.GetChatMessages(c.Request.Context(), uuidFromPath(c, "essayId")))
+}
+
+func (a EssayCoachController) sendChatMessage(c *gin.Context) {
+	c.JSON(http.StatusOK, a.essayCoachService.SendChatMessage(
+		c.Request.Context(),
+		uuidFromPath(c, "essayId"),
+		httputils.MustBindWith[domain.SendEssayChatMessageRequest](c, binding.JSON).Validated()))
+}
+
+// streamChatMessage relays the reply of the essay coach to the client using server-sent events: "delta" events
+// carry raw text as it is generated, the final "message" event carries the saved reply, or "error" if it fails.
+func (a EssayCoachController) streamChatMessage(c *gin.Context) {
+	essayID := uuidFromPath(c, "essayId")
+	request := httputils.MustBindWith[domain.SendEssayChatMessageRequest](c, binding.JSON).Validated()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	defer recoverStream(c)
+
+	message := a.essayCoachService.SendChatMessageStream(
+		c.Request.Context(), essayID, request, func(textDelta string) {
+			c.SSEvent("delta", textDelta)
+			c.Writer.Flush()
+		})
+
+	c.SSEvent("message", message)
+	c.Writer.Flush()
+}
+
+func (a EssayCoachController) removeChatMessages(c *gin.Context) {
+	a.essayCoachService.RemoveChatMessages(c.Request.Context(), uuidFromPath(c, "essayId"))
+	c.Status(http.StatusNoContent)
+}
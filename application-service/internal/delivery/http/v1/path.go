@@ -0,0 +1,20 @@
+package httpv1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+)
+
+// uuidFromPath parses the path parameter, e.g. essayId, throwing RequestValidationError if it isn't a UUID.
+func uuidFromPath(c *gin.Context, param string) uuid.UUID {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		myerror.NewWithReason(myerror.RequestValidationError, fmt.Sprintf("%s is not a valid UUID: %v", param, err)).Throw()
+	}
+
+	return id
+}
@@ -0,0 +1,36 @@
+package httpv1
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+)
+
+// recoverStream must be deferred by the handlers sending server-sent events. Once the first event is sent,
+// the status and the content type can't be changed anymore, so instead of leaving the panic to the
+// ErrorHandler, which would write JSON into the stream, the error is sent as the final "error" event.
+func recoverStream(c *gin.Context) {
+	if !c.Writer.Written() {
+		return
+	}
+
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	err, ok := r.(myerror.MyError)
+	if !ok {
+		log.L(c.Request.Context()).Errorf("Stream failed: %v", r)
+		err = myerror.New(0)
+	}
+
+	c.SSEvent("error", gin.H{
+		"errorDetails": err.ErrorDetails(),
+		"errorType":    err.ErrorType(),
+	})
+	c.Writer.Flush()
+	c.Abort()
+}
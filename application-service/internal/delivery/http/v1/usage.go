@@ -0,0 +1,39 @@
+package httpv1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+	httputils "github.com/compendium-tech/compendium/common/pkg/http"
+
+	"github.com/compendium-tech/compendium/application-service/internal/service"
+)
+
+type UsageController struct {
+	usageService service.UsageService
+}
+
+func NewUsageController(usageService service.UsageService) UsageController {
+	return UsageController{
+		usageService: usageService,
+	}
+}
+
+func (u UsageController) MakeRoutes(e *gin.Engine) {
+	var eh httputils.ErrorHandler
+
+	v1 := e.Group("/v1")
+	{
+		authenticated := v1.Group("/")
+		authenticated.Use(auth.RequireAuth)
+		{
+			authenticated.GET("/usage", eh.Handle(u.getUsage))
+		}
+	}
+}
+
+func (u UsageController) getUsage(c *gin.Context) {
+	c.JSON(http.StatusOK, u.usageService.GetCurrentUsage(c.Request.Context()))
+}
@@ -31,6 +31,36 @@ type HonorResponse struct {
 	Grade       model.Grade      `json:"grade"`
 }
 
+type AcademicsResponse struct {
+	GPA        *GPAResponse        `json:"gpa"`
+	ClassRank  *ClassRankResponse  `json:"classRank"`
+	Courses    []CourseResponse    `json:"courses"`
+	TestScores []TestScoreResponse `json:"testScores"`
+}
+
+type GPAResponse struct {
+	Value    float64 `json:"value"`
+	Scale    float64 `json:"scale"`
+	Weighted bool    `json:"weighted"`
+}
+
+type ClassRankResponse struct {
+	Rank      int `json:"rank"`
+	ClassSize int `json:"classSize"`
+}
+
+type CourseResponse struct {
+	Name   string            `json:"name"`
+	Rigor  model.CourseRigor `json:"rigor"`
+	Grade  model.Grade       `json:"grade"`
+	Result *string           `json:"result"`
+}
+
+type TestScoreResponse struct {
+	Type  model.TestType `json:"type"`
+	Score float64        `json:"score"`
+}
+
 type CreateApplicationRequest struct {
 	Name string `json:"name"`
 }
@@ -52,12 +82,48 @@ type UpdateHonorRequest struct {
 	Grade       model.Grade      `json:"grade"`
 }
 
+// UpdateAcademicsRequest replaces the academics of the application. The GPA and the class rank may be
+// omitted, e.g. if the school doesn't report them.
+type UpdateAcademicsRequest struct {
+	GPA        *UpdateGPARequest        `json:"gpa"`
+	ClassRank  *UpdateClassRankRequest  `json:"classRank"`
+	Courses    []UpdateCourseRequest    `json:"courses" validate:"max=50,dive"`
+	TestScores []UpdateTestScoreRequest `json:"testScores" validate:"max=20,dive"`
+}
+
+type UpdateGPARequest struct {
+	Value    float64 `json:"value" validate:"gte=0,ltefield=Scale"`
+	Scale    float64 `json:"scale" validate:"gt=0,lte=100"`
+	Weighted bool    `json:"weighted"`
+}
+
+type UpdateClassRankRequest struct {
+	Rank      int `json:"rank" validate:"gte=1,ltefield=ClassSize"`
+	ClassSize int `json:"classSize" validate:"gte=1,lte=100000"`
+}
+
+type UpdateCourseRequest struct {
+	Name   string            `json:"name" validate:"required,min=1,max=100"`
+	Rigor  model.CourseRigor `json:"rigor" validate:"required"`
+	Grade  model.Grade       `json:"grade" validate:"required"`
+	Result *string           `json:"result" validate:"omitempty,min=1,max=10"`
+}
+
+// UpdateTestScoreRequest is the total score of the test, which is validated against the scale of its type.
+type UpdateTestScoreRequest struct {
+	Type  model.TestType `json:"type" validate:"required"`
+	Score float64        `json:"score"`
+}
+
 type CreateEssayRequest struct {
 	Kind    model.EssayType `json:"type"`
 	Content string          `json:"content"`
 }
 
+// UpdateEssayRequest updates the essay with the ID, keeping its chat with the essay coach. Essays without
+// an ID, or with an ID of none of the essays of the application, are created.
 type UpdateEssayRequest struct {
+	ID      *uuid.UUID      `json:"id"`
 	Kind    model.EssayType `json:"type"`
 	Content string          `json:"content"`
 }
@@ -1,6 +1,16 @@
 package domain
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
 type ApplicationEvaluationResponse struct {
+	AcademicsEvaluationResponse          `json:"academicsEvaluation"`
 	ActivitiesEvaluationResponse         `json:"activitiesEvaluation"`
 	HonorsEvaluationResponse             `json:"honorsEvaluation"`
 	EssaysEvaluationResponse             `json:"essaysEvaluation"`
@@ -9,6 +19,31 @@ type ApplicationEvaluationResponse struct {
 	Strengths                            []string `json:"strengths"`
 	Weaknesses                           []string `json:"weaknesses"`
 	Summary                              string   `json:"summary"`
+	// PromptTemplate and PromptTemplateVersion identify the prompt the application was evaluated with.
+	PromptTemplate        string `json:"promptTemplate"`
+	PromptTemplateVersion int32  `json:"promptTemplateVersion"`
+	// ID and CreatedAt identify the stored evaluation. SnapshotHash identifies the evaluated state
+	// of the application, which changes with every edit.
+	ID           uuid.UUID `json:"id"`
+	SnapshotHash string    `json:"snapshotHash"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// ApplicationEvaluationSummaryResponse lists a stored evaluation without the evaluations of the sections.
+type ApplicationEvaluationSummaryResponse struct {
+	ID                    uuid.UUID `json:"id"`
+	Summary               string    `json:"summary"`
+	PromptTemplate        string    `json:"promptTemplate"`
+	PromptTemplateVersion int32     `json:"promptTemplateVersion"`
+	SnapshotHash          string    `json:"snapshotHash"`
+	CreatedAt             time.Time `json:"createdAt"`
+}
+
+type AcademicsEvaluationResponse struct {
+	Suggestions []string `json:"suggestions"`
+	Strengths   []string `json:"strengths"`
+	Weaknesses  []string `json:"weaknesses"`
+	Summary     string   `json:"summary"`
 }
 
 type ActivitiesEvaluationResponse struct {
@@ -46,3 +81,21 @@ type SupplementalEssayEvaluationResponse struct {
 	Strengths   []string `json:"strengths"`
 	Weaknesses  []string `json:"weaknesses"`
 }
+
+// ApplicationEvaluationJobResponse reports the progress of an evaluation running in the background.
+// EvaluationID is set once the job succeeds, Error once it fails.
+type ApplicationEvaluationJobResponse struct {
+	ID           uuid.UUID                            `json:"id"`
+	Status       model.ApplicationEvaluationJobStatus `json:"status"`
+	EvaluationID *uuid.UUID                           `json:"evaluationId"`
+	Error        *ApplicationEvaluationJobError       `json:"error"`
+	CreatedAt    time.Time                            `json:"createdAt"`
+	StartedAt    *time.Time                           `json:"startedAt"`
+	FinishedAt   *time.Time                           `json:"finishedAt"`
+}
+
+// ApplicationEvaluationJobError is the error the job failed with, in the format of the API errors.
+type ApplicationEvaluationJobError struct {
+	ErrorType    int             `json:"errorType"`
+	ErrorDetails json.RawMessage `json:"errorDetails"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
+type SendEssayChatMessageRequest struct {
+	Message string `json:"message" validate:"required,min=1,max=4000"`
+}
+
+type EssayChatMessageResponse struct {
+	ID        uuid.UUID           `json:"id"`
+	Role      model.EssayChatRole `json:"role"`
+	Content   string              `json:"content"`
+	CreatedAt time.Time           `json:"createdAt"`
+}
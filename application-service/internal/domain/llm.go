@@ -4,6 +4,7 @@ const (
 	RoleSystem    LLMRole = "system"
 	RoleUser      LLMRole = "user"
 	RoleAssistant LLMRole = "assistant"
+	RoleTool      LLMRole = "tool"
 
 	TypeString  LLMType = "STRING"
 	TypeNumber  LLMType = "NUMBER"
@@ -11,15 +12,55 @@ const (
 	TypeBoolean LLMType = "BOOLEAN"
 	TypeArray   LLMType = "ARRAY"
 	TypeObject  LLMType = "OBJECT"
+
+	ContextOverflowReject    LLMContextOverflowPolicy = "reject"
+	ContextOverflowTruncate  LLMContextOverflowPolicy = "truncate"
+	ContextOverflowSummarize LLMContextOverflowPolicy = "summarize"
 )
 
 type LLMRole string
 type LLMType string
 
+// LLMContextOverflowPolicy tells llm-service what to do when the chat history doesn't fit into
+// the context window of the model.
+type LLMContextOverflowPolicy string
+
 type LLMMessage struct {
 	Role      LLMRole
 	Text      string
 	ToolCalls []LLMToolCall
+	// ToolResult is only set in messages with RoleTool.
+	ToolResult *LLMToolResult
+	// Attachments are only supported in messages with RoleUser.
+	Attachments []LLMAttachment
+}
+
+// LLMAttachment is a document or an image, e.g. a transcript or a score report, sent to the model.
+// Either Data or URI of the file uploaded to the provider is set.
+type LLMAttachment struct {
+	MIMEType string
+	Data     []byte
+	URI      string
+}
+
+// LLMMessageDelta is a part of an assistant message received while the response is still being generated.
+type LLMMessageDelta struct {
+	Text      string
+	ToolCalls []LLMToolCall
+}
+
+// LLMEmbeddings are the vectors of the embedded texts, in the same order as the texts.
+type LLMEmbeddings struct {
+	Vectors [][]float32
+	Model   string
+}
+
+// LLMRenderedPrompt is the prompt template of llm-service rendered with the variables. Version is
+// the version of the template that was rendered, which is the latest one unless a version was requested.
+type LLMRenderedPrompt struct {
+	Text    string
+	Name    string
+	Version int32
 }
 
 type LLMToolCall struct {
@@ -28,12 +69,35 @@ type LLMToolCall struct {
 	Parameters map[string]any
 }
 
+// LLMToolResult is the output of the tool called by the assistant, sent back to the model.
+type LLMToolResult struct {
+	ToolCallID string
+	Name       string
+	Result     map[string]any
+}
+
 type LLMToolDefinition struct {
 	Name             string
 	Description      string
 	ParametersSchema *LLMSchema
 }
 
+// LLMGenerationOptions tune the generation for a single request. Nil and empty fields
+// mean that the defaults of llm-service should be used, e.g. zero temperature.
+type LLMGenerationOptions struct {
+	Model           string
+	Temperature     *float32
+	TopP            *float32
+	MaxOutputTokens *int32
+	StopSequences   []string
+	Seed            *int32
+	// NoCache makes llm-service generate a new response even if the identical request was cached.
+	NoCache bool
+	// Empty ContextOverflowPolicy means ContextOverflowReject.
+	ContextOverflowPolicy LLMContextOverflowPolicy
+}
+
+// LLMSchema describes structured output and tool parameters. Type is empty when the value is described by AnyOf.
 type LLMSchema struct {
 	Type        LLMType
 	Description string
@@ -42,4 +106,15 @@ type LLMSchema struct {
 	MaxItems    *int64
 	MinItems    *int64
 	Required    []string
+	// Enum lists the allowed values of a string.
+	Enum     []string
+	Nullable bool
+	// Minimum and Maximum are inclusive bounds of a number or an integer.
+	Minimum *float64
+	Maximum *float64
+	// Format of a string, for example "date-time".
+	Format string
+	// PropertyOrdering is the order in which the model generates the properties.
+	PropertyOrdering []string
+	AnyOf            []LLMSchema
 }
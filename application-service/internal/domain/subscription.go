@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// SubscriptionTier is the tier of the subscription the user is a member of, as reported by subscription-service.
+type SubscriptionTier string
+
+const (
+	// TierNone means that the user isn't a member of any subscription.
+	TierNone      SubscriptionTier = "none"
+	TierStudent   SubscriptionTier = "student"
+	TierTeam      SubscriptionTier = "team"
+	TierCommunity SubscriptionTier = "community"
+)
+
+// Subscription is the subscription the user is a member of. The current billing period is unset for TierNone.
+type Subscription struct {
+	Tier        SubscriptionTier `json:"tier"`
+	PeriodStart *time.Time       `json:"periodStart"`
+	PeriodEnd   *time.Time       `json:"periodEnd"`
+}
+
+// Feature is a part of application-service which only some subscription tiers may use.
+type Feature string
+
+const (
+	FeatureApplicationEvaluation Feature = "applicationEvaluation"
+	FeatureEssayCoach            Feature = "essayCoach"
+	FeatureEssayRewrite          Feature = "essayRewrite"
+	// FeatureCounselorSharing is sharing the applications with the counselors of the team or the community.
+	FeatureCounselorSharing Feature = "counselorSharing"
+)
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+type UsageResponse struct {
+	Tier        SubscriptionTier `json:"tier"`
+	PeriodStart time.Time        `json:"periodStart"`
+	// PeriodEnd is when the usage is reset.
+	PeriodEnd time.Time              `json:"periodEnd"`
+	Features  []FeatureUsageResponse `json:"features"`
+}
+
+type FeatureUsageResponse struct {
+	Feature Feature `json:"feature"`
+	Used    int     `json:"used"`
+	Quota   int     `json:"quota"`
+}
@@ -8,6 +8,23 @@ import (
 const (
 	RequestValidationError   = 1
 	ApplicationNotFoundError = 300
+	// InvalidLLMResponseError means that the model failed to generate a response conforming to the schema.
+	InvalidLLMResponseError = 301
+	// LLMUnavailableError means that the model provider is temporarily unavailable, so the request may be repeated later.
+	LLMUnavailableError = 302
+	// TooManyLLMRequestsError means that llm-service is overloaded, possibly with the requests of the same user.
+	TooManyLLMRequestsError = 303
+	// LLMContextWindowExceededError means that the application is too long for the model to evaluate.
+	LLMContextWindowExceededError = 304
+	EssayNotFoundError            = 305
+	// 306 was the error of the daily quota of the essay coach, which is now limited by UsageQuotaExceededError.
+	ApplicationEvaluationNotFoundError    = 307
+	ApplicationEvaluationJobNotFoundError = 308
+	// FeatureNotAvailableError means that the subscription tier of the user doesn't include the feature.
+	FeatureNotAvailableError = 309
+	// UsageQuotaExceededError means that the user used the feature as many times as their tier allows for the
+	// billing period.
+	UsageQuotaExceededError = 310
 )
 
 type MyError struct {
@@ -48,8 +65,19 @@ func (e MyError) ErrorDetails() any {
 
 func (e MyError) HttpStatus() int {
 	switch e.ty {
-	case ApplicationNotFoundError:
+	case ApplicationNotFoundError, EssayNotFoundError, ApplicationEvaluationNotFoundError,
+		ApplicationEvaluationJobNotFoundError:
 		return http.StatusNotFound
+	case FeatureNotAvailableError:
+		return http.StatusForbidden
+	case InvalidLLMResponseError:
+		return http.StatusBadGateway
+	case LLMUnavailableError:
+		return http.StatusServiceUnavailable
+	case TooManyLLMRequestsError, UsageQuotaExceededError:
+		return http.StatusTooManyRequests
+	case LLMContextWindowExceededError:
+		return http.StatusRequestEntityTooLarge
 	default:
 		return http.StatusBadRequest
 	}
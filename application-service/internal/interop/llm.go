@@ -2,17 +2,47 @@ package interop
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
 
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
 
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
 	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
 	pb "github.com/compendium-tech/compendium/application-service/internal/proto/v1"
+	"github.com/compendium-tech/compendium/common/pkg/auth"
 	pbhelp "github.com/compendium-tech/compendium/common/pkg/pb"
 )
 
+// llmServiceCaller is reported to llm-service, which keeps the usage ledger per caller and user.
+const llmServiceCaller = "application-service"
+
+// invalidStructuredOutputReason is the ErrorInfo reason llm-service reports when the model failed
+// to generate structured output conforming to the schema.
+const invalidStructuredOutputReason = "INVALID_STRUCTURED_OUTPUT"
+
+// providerUnavailableReason is the ErrorInfo reason llm-service reports when the model provider is temporarily
+// unavailable or rate limiting, so that the request may succeed later.
+const providerUnavailableReason = "PROVIDER_UNAVAILABLE"
+
+// admissionRejectedReason is the ErrorInfo reason llm-service reports when it has too many queued requests,
+// in total or of the user.
+const admissionRejectedReason = "ADMISSION_REJECTED"
+
+// contextWindowExceededReason is the ErrorInfo reason llm-service reports when the chat history doesn't fit
+// into the context window of the model and couldn't be shortened.
+const contextWindowExceededReason = "CONTEXT_WINDOW_EXCEEDED"
+
 type llmServiceGrpcClient struct {
 	client pb.LLMServiceClient
 }
@@ -20,7 +50,18 @@ type llmServiceGrpcClient struct {
 type LLMService interface {
 	GenerateResponse(
 		ctx context.Context, chatHistory []domain.LLMMessage,
-		tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema) domain.LLMMessage
+		tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema,
+		options domain.LLMGenerationOptions) domain.LLMMessage
+	GenerateResponseStream(
+		ctx context.Context, chatHistory []domain.LLMMessage,
+		tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema,
+		options domain.LLMGenerationOptions) iter.Seq[domain.LLMMessageDelta]
+	// Embed computes the embeddings of the texts. Empty model means the default embedding model of llm-service.
+	Embed(ctx context.Context, texts []string, model string) domain.LLMEmbeddings
+	// RenderPromptTemplate renders the prompt template kept by llm-service. Zero version means the latest version.
+	// Variables must be convertible to google.protobuf.Struct, e.g. numbers, strings, []any and map[string]any.
+	RenderPromptTemplate(
+		ctx context.Context, name string, version int32, variables map[string]any) domain.LLMRenderedPrompt
 }
 
 func NewGrpcLLMServiceClient(target string) (LLMService, error) {
@@ -41,7 +82,154 @@ func (c *llmServiceGrpcClient) GenerateResponse(
 	chatHistory []domain.LLMMessage,
 	tools []domain.LLMToolDefinition,
 	structuredOutputSchema *domain.LLMSchema,
+	options domain.LLMGenerationOptions,
 ) domain.LLMMessage {
+	resp, err := c.client.GenerateResponse(
+		withLLMCallerMetadata(ctx), buildGenerateResponseRequest(chatHistory, tools, structuredOutputSchema, options))
+	if err != nil {
+		panic(llmServiceError(err))
+	}
+
+	return domain.LLMMessage{
+		Role:      rolePBToRole(resp.Message.Role),
+		Text:      resp.Message.Text,
+		ToolCalls: toolCallsPBToToolCalls(resp.Message.ToolCalls),
+	}
+}
+
+func (c *llmServiceGrpcClient) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.LLMMessage,
+	tools []domain.LLMToolDefinition,
+	structuredOutputSchema *domain.LLMSchema,
+	options domain.LLMGenerationOptions,
+) iter.Seq[domain.LLMMessageDelta] {
+	return func(yield func(domain.LLMMessageDelta) bool) {
+		stream, err := c.client.GenerateResponseStream(
+			withLLMCallerMetadata(ctx), buildGenerateResponseRequest(chatHistory, tools, structuredOutputSchema, options))
+		if err != nil {
+			panic(llmServiceError(err))
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				panic(llmServiceError(err))
+			}
+
+			delta := domain.LLMMessageDelta{
+				Text:      resp.TextDelta,
+				ToolCalls: toolCallsPBToToolCalls(resp.ToolCalls),
+			}
+
+			if !yield(delta) {
+				return
+			}
+		}
+	}
+}
+
+func (c *llmServiceGrpcClient) Embed(ctx context.Context, texts []string, model string) domain.LLMEmbeddings {
+	resp, err := c.client.Embed(withLLMCallerMetadata(ctx), &pb.EmbedRequest{Texts: texts, Model: model})
+	if err != nil {
+		panic(llmServiceError(err))
+	}
+
+	vectors := make([][]float32, len(resp.Embeddings))
+	for i, embedding := range resp.Embeddings {
+		vectors[i] = embedding.Values
+	}
+
+	return domain.LLMEmbeddings{Vectors: vectors, Model: resp.Model}
+}
+
+func (c *llmServiceGrpcClient) RenderPromptTemplate(
+	ctx context.Context, name string, version int32, variables map[string]any) domain.LLMRenderedPrompt {
+	variablesPB, err := structpb.NewStruct(variables)
+	if err != nil {
+		panic(fmt.Errorf("failed to convert prompt template variables: %w", err))
+	}
+
+	resp, err := c.client.RenderPromptTemplate(withLLMCallerMetadata(ctx), &pb.RenderPromptTemplateRequest{
+		Name:      name,
+		Version:   version,
+		Variables: variablesPB,
+	})
+	if err != nil {
+		panic(llmServiceError(err))
+	}
+
+	return domain.LLMRenderedPrompt{Text: resp.Text, Name: name, Version: resp.Version}
+}
+
+// llmServiceError converts the errors llm-service reports with ErrorInfo into MyError, so that they are
+// reported to the user, and returns other errors as is.
+func llmServiceError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var errorInfo *errdetails.ErrorInfo
+	var violations []map[string]string
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			errorInfo = detail
+		case *errdetails.BadRequest:
+			for _, fieldViolation := range detail.FieldViolations {
+				violations = append(violations, map[string]string{
+					"path":        fieldViolation.Field,
+					"description": fieldViolation.Description,
+				})
+			}
+		}
+	}
+
+	if errorInfo == nil {
+		return err
+	}
+
+	switch errorInfo.Reason {
+	case invalidStructuredOutputReason:
+		return myerror.NewWithDetails(myerror.InvalidLLMResponseError, map[string]any{"violations": violations})
+	case providerUnavailableReason:
+		return myerror.New(myerror.LLMUnavailableError)
+	case admissionRejectedReason:
+		return myerror.New(myerror.TooManyLLMRequestsError)
+	case contextWindowExceededReason:
+		return myerror.New(myerror.LLMContextWindowExceededError)
+	default:
+		return err
+	}
+}
+
+func withLLMCallerMetadata(ctx context.Context) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-caller", llmServiceCaller)
+
+	if userID := auth.GetUserIDOrNil(ctx); userID != uuid.Nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", userID.String())
+	}
+
+	// llm-service prioritizes the requests of the higher tiers.
+	if tier := localcontext.GetSubscriptionTierOrEmpty(ctx); tier != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-subscription-tier", string(tier))
+	}
+
+	return ctx
+}
+
+func buildGenerateResponseRequest(
+	chatHistory []domain.LLMMessage,
+	tools []domain.LLMToolDefinition,
+	structuredOutputSchema *domain.LLMSchema,
+	options domain.LLMGenerationOptions,
+) *pb.GenerateResponseRequest {
 	protoChatHistory := make([]*pb.Message, len(chatHistory))
 
 	for i, msg := range chatHistory {
@@ -64,10 +252,31 @@ func (c *llmServiceGrpcClient) GenerateResponse(
 			}
 		}
 
+		var toolResult *pb.ToolResult
+		if msg.ToolResult != nil {
+			result := make(map[string]*anypb.Any)
+			for k, v := range msg.ToolResult.Result {
+				var err error
+
+				result[k], err = pbhelp.AnyToAnyPB(v)
+				if err != nil {
+					panic(err)
+				}
+			}
+
+			toolResult = &pb.ToolResult{
+				ToolCallId: msg.ToolResult.ToolCallID,
+				Name:       msg.ToolResult.Name,
+				Result:     result,
+			}
+		}
+
 		protoChatHistory[i] = &pb.Message{
-			Role:      roleToRolePB(msg.Role),
-			Text:      msg.Text,
-			ToolCalls: toolCalls,
+			Role:        roleToRolePB(msg.Role),
+			Text:        msg.Text,
+			ToolCalls:   toolCalls,
+			ToolResult:  toolResult,
+			Attachments: attachmentsToAttachmentsPB(msg.Attachments),
 		}
 	}
 
@@ -85,17 +294,55 @@ func (c *llmServiceGrpcClient) GenerateResponse(
 		protoSchema = schemaToSchemaPB(structuredOutputSchema)
 	}
 
-	resp, err := c.client.GenerateResponse(ctx, &pb.GenerateResponseRequest{
+	return &pb.GenerateResponseRequest{
 		ChatHistory:            protoChatHistory,
 		Tools:                  protoTools,
 		StructuredOutputSchema: protoSchema,
-	})
-	if err != nil {
-		panic(err)
+		GenerationOptions: &pb.GenerationOptions{
+			Model:           options.Model,
+			Temperature:     options.Temperature,
+			TopP:            options.TopP,
+			MaxOutputTokens: options.MaxOutputTokens,
+			StopSequences:   options.StopSequences,
+			Seed:            options.Seed,
+			NoCache:         options.NoCache,
+
+			ContextOverflowPolicy: contextOverflowPolicyToContextOverflowPolicyPB(options.ContextOverflowPolicy),
+		},
 	}
+}
+
+func contextOverflowPolicyToContextOverflowPolicyPB(policy domain.LLMContextOverflowPolicy) pb.ContextOverflowPolicy {
+	switch policy {
+	case domain.ContextOverflowTruncate:
+		return pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_TRUNCATE
+	case domain.ContextOverflowSummarize:
+		return pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_SUMMARIZE
+	default:
+		return pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_REJECT
+	}
+}
+
+func attachmentsToAttachmentsPB(attachments []domain.LLMAttachment) []*pb.Attachment {
+	protoAttachments := make([]*pb.Attachment, len(attachments))
+	for i, attachment := range attachments {
+		protoAttachments[i] = &pb.Attachment{MimeType: attachment.MIMEType}
+
+		if len(attachment.Data) > 0 {
+			protoAttachments[i].Source = &pb.Attachment_Data{Data: attachment.Data}
+		} else {
+			protoAttachments[i].Source = &pb.Attachment_Uri{Uri: attachment.URI}
+		}
+	}
+
+	return protoAttachments
+}
+
+func toolCallsPBToToolCalls(protoToolCalls []*pb.ToolCall) []domain.LLMToolCall {
+	toolCalls := make([]domain.LLMToolCall, len(protoToolCalls))
+	for i, tc := range protoToolCalls {
+		var err error
 
-	toolCalls := make([]domain.LLMToolCall, len(resp.Message.ToolCalls))
-	for i, tc := range resp.Message.ToolCalls {
 		params := make(map[string]any)
 		for k, v := range tc.Parameters {
 			params[k], err = pbhelp.AnyPBToAny(v)
@@ -111,11 +358,7 @@ func (c *llmServiceGrpcClient) GenerateResponse(
 		}
 	}
 
-	return domain.LLMMessage{
-		Role:      rolePBToRole(resp.Message.Role),
-		Text:      resp.Message.Text,
-		ToolCalls: toolCalls,
-	}
+	return toolCalls
 }
 
 func roleToRolePB(role domain.LLMRole) pb.Role {
@@ -126,6 +369,8 @@ func roleToRolePB(role domain.LLMRole) pb.Role {
 		return pb.Role_USER
 	case domain.RoleAssistant:
 		return pb.Role_ASSISTANT
+	case domain.RoleTool:
+		return pb.Role_TOOL
 	default:
 		return pb.Role_SYSTEM
 	}
@@ -139,6 +384,8 @@ func rolePBToRole(role pb.Role) domain.LLMRole {
 		return domain.RoleUser
 	case pb.Role_ASSISTANT:
 		return domain.RoleAssistant
+	case pb.Role_TOOL:
+		return domain.RoleTool
 	default:
 		return domain.RoleSystem
 	}
@@ -159,13 +406,25 @@ func schemaToSchemaPB(domainSchema *domain.LLMSchema) *pb.Schema {
 		items = schemaToSchemaPB(domainSchema.Items)
 	}
 
+	var anyOf []*pb.Schema
+	for _, v := range domainSchema.AnyOf {
+		anyOf = append(anyOf, schemaToSchemaPB(&v))
+	}
+
 	return &pb.Schema{
-		Type:        &pb.Type{Value: string(domainSchema.Type)},
-		Description: domainSchema.Description,
-		Properties:  properties,
-		Items:       items,
-		MaxItems:    *domainSchema.MaxItems,
-		MinItems:    *domainSchema.MinItems,
-		Required:    domainSchema.Required,
+		Type:             &pb.Type{Value: string(domainSchema.Type)},
+		Description:      domainSchema.Description,
+		Properties:       properties,
+		Items:            items,
+		MaxItems:         domainSchema.MaxItems,
+		MinItems:         domainSchema.MinItems,
+		Required:         domainSchema.Required,
+		Enum:             domainSchema.Enum,
+		Nullable:         domainSchema.Nullable,
+		Minimum:          domainSchema.Minimum,
+		Maximum:          domainSchema.Maximum,
+		Format:           domainSchema.Format,
+		PropertyOrdering: domainSchema.PropertyOrdering,
+		AnyOf:            anyOf,
 	}
 }
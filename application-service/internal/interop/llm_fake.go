@@ -0,0 +1,246 @@
+package interop
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math"
+	"strings"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+)
+
+// fakeLLMService is a deterministic LLMService for tests, mirroring the fake provider of llm-service.
+// It replays canned responses matched on the prompt fingerprint (see LLMPromptFingerprint) and,
+// when no canned response exists, synthesizes a response conforming to the structured output schema.
+type fakeLLMService struct {
+	responses map[string]domain.LLMMessage
+}
+
+// NewFakeLLMService creates a fake LLMService replaying responses keyed by prompt fingerprints.
+func NewFakeLLMService(responses map[string]domain.LLMMessage) LLMService {
+	if responses == nil {
+		responses = make(map[string]domain.LLMMessage)
+	}
+
+	return &fakeLLMService{responses: responses}
+}
+
+func (f *fakeLLMService) GenerateResponse(
+	_ context.Context,
+	chatHistory []domain.LLMMessage,
+	_ []domain.LLMToolDefinition,
+	structuredOutputSchema *domain.LLMSchema,
+	_ domain.LLMGenerationOptions,
+) domain.LLMMessage {
+	fingerprint := LLMPromptFingerprint(chatHistory)
+
+	if response, ok := f.responses[fingerprint]; ok {
+		return response
+	}
+
+	if structuredOutputSchema != nil {
+		text, err := json.Marshal(SynthesizeLLMSchemaValue(structuredOutputSchema))
+		if err != nil {
+			panic(err)
+		}
+
+		return domain.LLMMessage{Role: domain.RoleAssistant, Text: string(text)}
+	}
+
+	return domain.LLMMessage{
+		Role: domain.RoleAssistant,
+		Text: fmt.Sprintf("Fake response to prompt %s.", fingerprint),
+	}
+}
+
+func (f *fakeLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.LLMMessage,
+	tools []domain.LLMToolDefinition,
+	structuredOutputSchema *domain.LLMSchema,
+	options domain.LLMGenerationOptions,
+) iter.Seq[domain.LLMMessageDelta] {
+	return func(yield func(domain.LLMMessageDelta) bool) {
+		response := f.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+
+		// Split the response into words to imitate token streaming.
+		words := strings.SplitAfter(response.Text, " ")
+		for i, word := range words {
+			delta := domain.LLMMessageDelta{Text: word}
+			if i == len(words)-1 {
+				delta.ToolCalls = response.ToolCalls
+			}
+
+			if !yield(delta) {
+				return
+			}
+		}
+	}
+}
+
+// Embed derives the vectors from the hashes of the texts, like the fake provider of llm-service.
+func (f *fakeLLMService) Embed(_ context.Context, texts []string, _ string) domain.LLMEmbeddings {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		hash := sha256.Sum256([]byte(text))
+
+		vectors[i] = make([]float32, len(hash))
+		for j, b := range hash {
+			vectors[i][j] = float32(b)/127.5 - 1
+		}
+	}
+
+	return domain.LLMEmbeddings{Vectors: vectors, Model: "fake"}
+}
+
+// RenderPromptTemplate doesn't render the templates of llm-service, but serializes the variables, so that
+// the rendered prompt and its fingerprint are still deterministic.
+func (f *fakeLLMService) RenderPromptTemplate(
+	_ context.Context, name string, version int32, variables map[string]any) domain.LLMRenderedPrompt {
+	if version == 0 {
+		version = 1
+	}
+
+	text, err := json.Marshal(variables)
+	if err != nil {
+		panic(err)
+	}
+
+	return domain.LLMRenderedPrompt{
+		Text:    fmt.Sprintf("Prompt template %s@%d rendered with %s.", name, version, text),
+		Name:    name,
+		Version: version,
+	}
+}
+
+// LLMPromptFingerprint returns a stable hash of the chat history which identifies the prompt
+// regardless of the generated tool call IDs. It matches the fingerprints used by llm-service.
+func LLMPromptFingerprint(chatHistory []domain.LLMMessage) string {
+	type fingerprintToolCall struct {
+		Name       string         `json:"name"`
+		Parameters map[string]any `json:"parameters"`
+	}
+
+	type fingerprintToolResult struct {
+		Name   string         `json:"name"`
+		Result map[string]any `json:"result"`
+	}
+
+	// Attachments are identified by the hash of their data, so that the fingerprint stays short.
+	type fingerprintAttachment struct {
+		MIMEType   string `json:"mimeType"`
+		DataSHA256 string `json:"dataSha256,omitempty"`
+		URI        string `json:"uri,omitempty"`
+	}
+
+	type fingerprintMessage struct {
+		Role       domain.LLMRole         `json:"role"`
+		Text       string                 `json:"text"`
+		ToolCalls  []fingerprintToolCall  `json:"toolCalls"`
+		ToolResult *fingerprintToolResult `json:"toolResult,omitempty"`
+		// Attachments are omitted when empty, so that the fingerprints of text prompts stay the same.
+		Attachments []fingerprintAttachment `json:"attachments,omitempty"`
+	}
+
+	messages := make([]fingerprintMessage, len(chatHistory))
+	for i, msg := range chatHistory {
+		toolCalls := make([]fingerprintToolCall, len(msg.ToolCalls))
+		for j, toolCall := range msg.ToolCalls {
+			toolCalls[j] = fingerprintToolCall{
+				Name:       toolCall.Name,
+				Parameters: toolCall.Parameters,
+			}
+		}
+
+		messages[i] = fingerprintMessage{
+			Role:      msg.Role,
+			Text:      strings.TrimSpace(msg.Text),
+			ToolCalls: toolCalls,
+		}
+
+		if msg.ToolResult != nil {
+			messages[i].ToolResult = &fingerprintToolResult{
+				Name:   msg.ToolResult.Name,
+				Result: msg.ToolResult.Result,
+			}
+		}
+
+		for _, attachment := range msg.Attachments {
+			fingerprint := fingerprintAttachment{MIMEType: attachment.MIMEType, URI: attachment.URI}
+			if len(attachment.Data) > 0 {
+				dataHash := sha256.Sum256(attachment.Data)
+				fingerprint.DataSHA256 = hex.EncodeToString(dataHash[:])
+			}
+
+			messages[i].Attachments = append(messages[i].Attachments, fingerprint)
+		}
+	}
+
+	encoded, _ := json.Marshal(messages)
+	hash := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// SynthesizeLLMSchemaValue builds the smallest deterministic value conforming to the schema.
+func SynthesizeLLMSchemaValue(schema *domain.LLMSchema) any {
+	if len(schema.AnyOf) > 0 {
+		return SynthesizeLLMSchemaValue(&schema.AnyOf[0])
+	}
+
+	switch schema.Type {
+	case domain.TypeObject:
+		object := make(map[string]any)
+		for name, prop := range schema.Properties {
+			object[name] = SynthesizeLLMSchemaValue(&prop)
+		}
+
+		return object
+	case domain.TypeArray:
+		itemsCount := int64(1)
+		if schema.MinItems != nil {
+			itemsCount = *schema.MinItems
+		}
+
+		if schema.MaxItems != nil && *schema.MaxItems < itemsCount {
+			itemsCount = *schema.MaxItems
+		}
+
+		array := make([]any, 0, itemsCount)
+		if schema.Items != nil {
+			for range itemsCount {
+				array = append(array, SynthesizeLLMSchemaValue(schema.Items))
+			}
+		}
+
+		return array
+	case domain.TypeNumber, domain.TypeInteger:
+		switch {
+		case schema.Minimum != nil:
+			return math.Ceil(*schema.Minimum)
+		case schema.Maximum != nil && *schema.Maximum < 0:
+			return math.Floor(*schema.Maximum)
+		default:
+			return 0
+		}
+	case domain.TypeBoolean:
+		return false
+	default:
+		if len(schema.Enum) > 0 {
+			return schema.Enum[0]
+		}
+
+		switch schema.Format {
+		case "date-time":
+			return "1970-01-01T00:00:00Z"
+		case "date":
+			return "1970-01-01"
+		default:
+			return "string"
+		}
+	}
+}
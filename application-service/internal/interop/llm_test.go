@@ -0,0 +1,44 @@
+package interop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+)
+
+func TestSchemaToSchemaPB(t *testing.T) {
+	minimum, maximum := float64(1), float64(10)
+	schema := &domain.LLMSchema{
+		Type: domain.TypeObject,
+		Properties: map[string]domain.LLMSchema{
+			"rating":  {Type: domain.TypeInteger, Minimum: &minimum, Maximum: &maximum},
+			"verdict": {Type: domain.TypeString, Enum: []string{"reach", "target", "safety"}},
+			"notes":   {Type: domain.TypeArray, Items: &domain.LLMSchema{Type: domain.TypeString}},
+			"deadline": {
+				AnyOf:    []domain.LLMSchema{{Type: domain.TypeString, Format: "date"}, {Type: domain.TypeInteger}},
+				Nullable: true,
+			},
+		},
+		PropertyOrdering: []string{"verdict", "rating", "notes", "deadline"},
+	}
+
+	protoSchema := schemaToSchemaPB(schema)
+
+	assert.Equal(t, []string{"verdict", "rating", "notes", "deadline"}, protoSchema.PropertyOrdering)
+	assert.Equal(t, float64(1), protoSchema.Properties["rating"].GetMinimum())
+	assert.Equal(t, float64(10), protoSchema.Properties["rating"].GetMaximum())
+	assert.Equal(t, []string{"reach", "target", "safety"}, protoSchema.Properties["verdict"].Enum)
+
+	// Unset limits stay unset instead of being sent as zeros.
+	assert.Nil(t, protoSchema.Properties["notes"].MinItems)
+	assert.Nil(t, protoSchema.Properties["notes"].MaxItems)
+
+	deadline := protoSchema.Properties["deadline"]
+	assert.True(t, deadline.Nullable)
+	require.Len(t, deadline.AnyOf, 2)
+	assert.Equal(t, "date", deadline.AnyOf[0].Format)
+	assert.Equal(t, string(domain.TypeInteger), deadline.AnyOf[1].Type.Value)
+}
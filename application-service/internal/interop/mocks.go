@@ -6,8 +6,10 @@ package interop
 
 import (
 	"context"
+	"iter"
 
 	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,17 +40,80 @@ func (_m *MockLLMService) EXPECT() *MockLLMService_Expecter {
 	return &MockLLMService_Expecter{mock: &_m.Mock}
 }
 
+// Embed provides a mock function for the type MockLLMService
+func (_mock *MockLLMService) Embed(ctx context.Context, texts []string, model string) domain.LLMEmbeddings {
+	ret := _mock.Called(ctx, texts, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Embed")
+	}
+
+	var r0 domain.LLMEmbeddings
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) domain.LLMEmbeddings); ok {
+		r0 = returnFunc(ctx, texts, model)
+	} else {
+		r0 = ret.Get(0).(domain.LLMEmbeddings)
+	}
+	return r0
+}
+
+// MockLLMService_Embed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Embed'
+type MockLLMService_Embed_Call struct {
+	*mock.Call
+}
+
+// Embed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - texts []string
+//   - model string
+func (_e *MockLLMService_Expecter) Embed(ctx interface{}, texts interface{}, model interface{}) *MockLLMService_Embed_Call {
+	return &MockLLMService_Embed_Call{Call: _e.mock.On("Embed", ctx, texts, model)}
+}
+
+func (_c *MockLLMService_Embed_Call) Run(run func(ctx context.Context, texts []string, model string)) *MockLLMService_Embed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLLMService_Embed_Call) Return(lLMEmbeddings domain.LLMEmbeddings) *MockLLMService_Embed_Call {
+	_c.Call.Return(lLMEmbeddings)
+	return _c
+}
+
+func (_c *MockLLMService_Embed_Call) RunAndReturn(run func(ctx context.Context, texts []string, model string) domain.LLMEmbeddings) *MockLLMService_Embed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateResponse provides a mock function for the type MockLLMService
-func (_mock *MockLLMService) GenerateResponse(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema) domain.LLMMessage {
-	ret := _mock.Called(ctx, chatHistory, tools, structuredOutputSchema)
+func (_mock *MockLLMService) GenerateResponse(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions) domain.LLMMessage {
+	ret := _mock.Called(ctx, chatHistory, tools, structuredOutputSchema, options)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateResponse")
 	}
 
 	var r0 domain.LLMMessage
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.LLMMessage, []domain.LLMToolDefinition, *domain.LLMSchema) domain.LLMMessage); ok {
-		r0 = returnFunc(ctx, chatHistory, tools, structuredOutputSchema)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.LLMMessage, []domain.LLMToolDefinition, *domain.LLMSchema, domain.LLMGenerationOptions) domain.LLMMessage); ok {
+		r0 = returnFunc(ctx, chatHistory, tools, structuredOutputSchema, options)
 	} else {
 		r0 = ret.Get(0).(domain.LLMMessage)
 	}
@@ -65,11 +130,12 @@ type MockLLMService_GenerateResponse_Call struct {
 //   - chatHistory []domain.LLMMessage
 //   - tools []domain.LLMToolDefinition
 //   - structuredOutputSchema *domain.LLMSchema
-func (_e *MockLLMService_Expecter) GenerateResponse(ctx interface{}, chatHistory interface{}, tools interface{}, structuredOutputSchema interface{}) *MockLLMService_GenerateResponse_Call {
-	return &MockLLMService_GenerateResponse_Call{Call: _e.mock.On("GenerateResponse", ctx, chatHistory, tools, structuredOutputSchema)}
+//   - options domain.LLMGenerationOptions
+func (_e *MockLLMService_Expecter) GenerateResponse(ctx interface{}, chatHistory interface{}, tools interface{}, structuredOutputSchema interface{}, options interface{}) *MockLLMService_GenerateResponse_Call {
+	return &MockLLMService_GenerateResponse_Call{Call: _e.mock.On("GenerateResponse", ctx, chatHistory, tools, structuredOutputSchema, options)}
 }
 
-func (_c *MockLLMService_GenerateResponse_Call) Run(run func(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema)) *MockLLMService_GenerateResponse_Call {
+func (_c *MockLLMService_GenerateResponse_Call) Run(run func(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions)) *MockLLMService_GenerateResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -87,11 +153,16 @@ func (_c *MockLLMService_GenerateResponse_Call) Run(run func(ctx context.Context
 		if args[3] != nil {
 			arg3 = args[3].(*domain.LLMSchema)
 		}
+		var arg4 domain.LLMGenerationOptions
+		if args[4] != nil {
+			arg4 = args[4].(domain.LLMGenerationOptions)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -102,7 +173,237 @@ func (_c *MockLLMService_GenerateResponse_Call) Return(lLMMessage domain.LLMMess
 	return _c
 }
 
-func (_c *MockLLMService_GenerateResponse_Call) RunAndReturn(run func(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema) domain.LLMMessage) *MockLLMService_GenerateResponse_Call {
+func (_c *MockLLMService_GenerateResponse_Call) RunAndReturn(run func(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions) domain.LLMMessage) *MockLLMService_GenerateResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateResponseStream provides a mock function for the type MockLLMService
+func (_mock *MockLLMService) GenerateResponseStream(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions) iter.Seq[domain.LLMMessageDelta] {
+	ret := _mock.Called(ctx, chatHistory, tools, structuredOutputSchema, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateResponseStream")
+	}
+
+	var r0 iter.Seq[domain.LLMMessageDelta]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.LLMMessage, []domain.LLMToolDefinition, *domain.LLMSchema, domain.LLMGenerationOptions) iter.Seq[domain.LLMMessageDelta]); ok {
+		r0 = returnFunc(ctx, chatHistory, tools, structuredOutputSchema, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq[domain.LLMMessageDelta])
+		}
+	}
+	return r0
+}
+
+// MockLLMService_GenerateResponseStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateResponseStream'
+type MockLLMService_GenerateResponseStream_Call struct {
+	*mock.Call
+}
+
+// GenerateResponseStream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatHistory []domain.LLMMessage
+//   - tools []domain.LLMToolDefinition
+//   - structuredOutputSchema *domain.LLMSchema
+//   - options domain.LLMGenerationOptions
+func (_e *MockLLMService_Expecter) GenerateResponseStream(ctx interface{}, chatHistory interface{}, tools interface{}, structuredOutputSchema interface{}, options interface{}) *MockLLMService_GenerateResponseStream_Call {
+	return &MockLLMService_GenerateResponseStream_Call{Call: _e.mock.On("GenerateResponseStream", ctx, chatHistory, tools, structuredOutputSchema, options)}
+}
+
+func (_c *MockLLMService_GenerateResponseStream_Call) Run(run func(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions)) *MockLLMService_GenerateResponseStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.LLMMessage
+		if args[1] != nil {
+			arg1 = args[1].([]domain.LLMMessage)
+		}
+		var arg2 []domain.LLMToolDefinition
+		if args[2] != nil {
+			arg2 = args[2].([]domain.LLMToolDefinition)
+		}
+		var arg3 *domain.LLMSchema
+		if args[3] != nil {
+			arg3 = args[3].(*domain.LLMSchema)
+		}
+		var arg4 domain.LLMGenerationOptions
+		if args[4] != nil {
+			arg4 = args[4].(domain.LLMGenerationOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLLMService_GenerateResponseStream_Call) Return(seq iter.Seq[domain.LLMMessageDelta]) *MockLLMService_GenerateResponseStream_Call {
+	_c.Call.Return(seq)
+	return _c
+}
+
+func (_c *MockLLMService_GenerateResponseStream_Call) RunAndReturn(run func(ctx context.Context, chatHistory []domain.LLMMessage, tools []domain.LLMToolDefinition, structuredOutputSchema *domain.LLMSchema, options domain.LLMGenerationOptions) iter.Seq[domain.LLMMessageDelta]) *MockLLMService_GenerateResponseStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenderPromptTemplate provides a mock function for the type MockLLMService
+func (_mock *MockLLMService) RenderPromptTemplate(ctx context.Context, name string, version int32, variables map[string]any) domain.LLMRenderedPrompt {
+	ret := _mock.Called(ctx, name, version, variables)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderPromptTemplate")
+	}
+
+	var r0 domain.LLMRenderedPrompt
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int32, map[string]any) domain.LLMRenderedPrompt); ok {
+		r0 = returnFunc(ctx, name, version, variables)
+	} else {
+		r0 = ret.Get(0).(domain.LLMRenderedPrompt)
+	}
+	return r0
+}
+
+// MockLLMService_RenderPromptTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderPromptTemplate'
+type MockLLMService_RenderPromptTemplate_Call struct {
+	*mock.Call
+}
+
+// RenderPromptTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - version int32
+//   - variables map[string]any
+func (_e *MockLLMService_Expecter) RenderPromptTemplate(ctx interface{}, name interface{}, version interface{}, variables interface{}) *MockLLMService_RenderPromptTemplate_Call {
+	return &MockLLMService_RenderPromptTemplate_Call{Call: _e.mock.On("RenderPromptTemplate", ctx, name, version, variables)}
+}
+
+func (_c *MockLLMService_RenderPromptTemplate_Call) Run(run func(ctx context.Context, name string, version int32, variables map[string]any)) *MockLLMService_RenderPromptTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		var arg3 map[string]any
+		if args[3] != nil {
+			arg3 = args[3].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLLMService_RenderPromptTemplate_Call) Return(lLMRenderedPrompt domain.LLMRenderedPrompt) *MockLLMService_RenderPromptTemplate_Call {
+	_c.Call.Return(lLMRenderedPrompt)
+	return _c
+}
+
+func (_c *MockLLMService_RenderPromptTemplate_Call) RunAndReturn(run func(ctx context.Context, name string, version int32, variables map[string]any) domain.LLMRenderedPrompt) *MockLLMService_RenderPromptTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionService creates a new instance of MockSubscriptionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionService {
+	mock := &MockSubscriptionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSubscriptionService is an autogenerated mock type for the SubscriptionService type
+type MockSubscriptionService struct {
+	mock.Mock
+}
+
+type MockSubscriptionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionService) EXPECT() *MockSubscriptionService_Expecter {
+	return &MockSubscriptionService_Expecter{mock: &_m.Mock}
+}
+
+// GetSubscription provides a mock function for the type MockSubscriptionService
+func (_mock *MockSubscriptionService) GetSubscription(ctx context.Context, userID uuid.UUID) domain.Subscription {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscription")
+	}
+
+	var r0 domain.Subscription
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Subscription); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Subscription)
+	}
+	return r0
+}
+
+// MockSubscriptionService_GetSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscription'
+type MockSubscriptionService_GetSubscription_Call struct {
+	*mock.Call
+}
+
+// GetSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockSubscriptionService_Expecter) GetSubscription(ctx interface{}, userID interface{}) *MockSubscriptionService_GetSubscription_Call {
+	return &MockSubscriptionService_GetSubscription_Call{Call: _e.mock.On("GetSubscription", ctx, userID)}
+}
+
+func (_c *MockSubscriptionService_GetSubscription_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSubscriptionService_GetSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSubscription_Call) Return(subscription domain.Subscription) *MockSubscriptionService_GetSubscription_Call {
+	_c.Call.Return(subscription)
+	return _c
+}
+
+func (_c *MockSubscriptionService_GetSubscription_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) domain.Subscription) *MockSubscriptionService_GetSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
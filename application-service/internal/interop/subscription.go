@@ -0,0 +1,60 @@
+package interop
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	pb "github.com/compendium-tech/compendium/application-service/internal/proto/v1"
+)
+
+type SubscriptionService interface {
+	// GetSubscription returns the subscription the user is a member of, or a subscription of TierNone.
+	GetSubscription(ctx context.Context, userID uuid.UUID) domain.Subscription
+}
+
+type subscriptionServiceGrpcClient struct {
+	client pb.SubscriptionServiceClient
+}
+
+func NewGrpcSubscriptionServiceClient(target string) (SubscriptionService, error) {
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+
+	return &subscriptionServiceGrpcClient{
+		client: pb.NewSubscriptionServiceClient(conn),
+	}, nil
+}
+
+func (c *subscriptionServiceGrpcClient) GetSubscription(ctx context.Context, userID uuid.UUID) domain.Subscription {
+	resp, err := c.client.GetSubscriptionTier(ctx, &pb.GetSubscriptionTierRequest{UserId: userID.String()})
+	if err != nil {
+		panic(fmt.Errorf("failed to get subscription tier: %w", err))
+	}
+
+	var subscription domain.Subscription
+
+	switch resp.Tier {
+	case pb.SubscriptionTier_STUDENT:
+		subscription.Tier = domain.TierStudent
+	case pb.SubscriptionTier_TEAM:
+		subscription.Tier = domain.TierTeam
+	case pb.SubscriptionTier_COMMUNITY:
+		subscription.Tier = domain.TierCommunity
+	default:
+		return domain.Subscription{Tier: domain.TierNone}
+	}
+
+	if resp.PeriodStart != nil && resp.PeriodEnd != nil {
+		periodStart, periodEnd := resp.PeriodStart.AsTime(), resp.PeriodEnd.AsTime()
+		subscription.PeriodStart, subscription.PeriodEnd = &periodStart, &periodEnd
+	}
+
+	return subscription
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	"github.com/compendium-tech/compendium/application-service/internal/service"
+	httputils "github.com/compendium-tech/compendium/common/pkg/http"
+	"github.com/compendium-tech/compendium/common/pkg/log"
+)
+
+// RequireFeature rejects the requests of the users whose subscription tier doesn't include the feature.
+// The subscription is set to the request context, so that the handlers can apply the limits of its tier.
+type RequireFeature struct {
+	subscriptionTierService service.SubscriptionTierService
+	feature                 domain.Feature
+}
+
+func NewRequireFeature(subscriptionTierService service.SubscriptionTierService, feature domain.Feature) *RequireFeature {
+	return &RequireFeature{
+		subscriptionTierService: subscriptionTierService,
+		feature:                 feature,
+	}
+}
+
+func (r *RequireFeature) Handle(c *gin.Context) {
+	var eh httputils.ErrorHandler
+
+	eh.Handle(r.handle)(c)
+}
+
+func (r *RequireFeature) handle(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	subscription := r.subscriptionTierService.GetCurrentSubscription(ctx)
+	r.subscriptionTierService.RequireFeature(ctx, subscription.Tier, r.feature)
+
+	log.SetLogger(&ctx, log.L(ctx).WithField("tier", subscription.Tier))
+	localcontext.SetSubscription(&ctx, subscription)
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// RequireQuota rejects the requests of the users who used up the quota of the feature for the billing period.
+// It must follow RequireFeature, which sets the subscription to the request context. The usage is reserved
+// by the feature itself, so this only rejects the requests early, e.g. before a job is enqueued or
+// a stream is started.
+type RequireQuota struct {
+	usageService service.UsageService
+	feature      domain.Feature
+}
+
+func NewRequireQuota(usageService service.UsageService, feature domain.Feature) *RequireQuota {
+	return &RequireQuota{
+		usageService: usageService,
+		feature:      feature,
+	}
+}
+
+func (r *RequireQuota) Handle(c *gin.Context) {
+	var eh httputils.ErrorHandler
+
+	eh.Handle(r.handle)(c)
+}
+
+func (r *RequireQuota) handle(c *gin.Context) {
+	r.usageService.RequireQuota(c.Request.Context(), r.feature)
+}
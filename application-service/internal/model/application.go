@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -175,3 +176,113 @@ func (e *EssayType) UnmarshalJSON(data []byte) error {
 	}
 	return fmt.Errorf("invalid essay type: %s", s)
 }
+
+// Academics is the academic record of the application. Every field is optional, the record of an application
+// whose academics were never put is empty.
+type Academics struct {
+	GPA        *GPA
+	ClassRank  *ClassRank
+	Courses    []Course
+	TestScores []TestScore
+}
+
+// GPA is the grade point average on the scale of the school, e.g. 3.8 out of 4.0. Weighted GPAs may be
+// reported on a higher scale, e.g. 4.6 out of 5.0.
+type GPA struct {
+	Value    float64
+	Scale    float64
+	Weighted bool
+}
+
+type ClassRank struct {
+	Rank      int
+	ClassSize int
+}
+
+type Course struct {
+	Name  string
+	Rigor CourseRigor
+	Grade Grade
+	// Result is the final grade or the exam score as the school or the exam board reports it, e.g. "A*" or "5".
+	Result *string
+}
+
+type TestScore struct {
+	Type  TestType
+	Score float64
+}
+
+type CourseRigor string
+
+const (
+	CourseRigorRegular        CourseRigor = "regular"
+	CourseRigorHonors         CourseRigor = "honors"
+	CourseRigorAP             CourseRigor = "ap"
+	CourseRigorIBStandard     CourseRigor = "ib_sl"
+	CourseRigorIBHigher       CourseRigor = "ib_hl"
+	CourseRigorASLevel        CourseRigor = "as_level"
+	CourseRigorALevel         CourseRigor = "a_level"
+	CourseRigorDualEnrollment CourseRigor = "dual_enrollment"
+)
+
+func (c *CourseRigor) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case string(CourseRigorRegular),
+		string(CourseRigorHonors),
+		string(CourseRigorAP),
+		string(CourseRigorIBStandard),
+		string(CourseRigorIBHigher),
+		string(CourseRigorASLevel),
+		string(CourseRigorALevel),
+		string(CourseRigorDualEnrollment):
+		*c = CourseRigor(s)
+		return nil
+	}
+	return fmt.Errorf("invalid course rigor: %s", s)
+}
+
+type TestType string
+
+const (
+	TestTypeSAT   TestType = "sat"
+	TestTypeACT   TestType = "act"
+	TestTypeIELTS TestType = "ielts"
+	TestTypeTOEFL TestType = "toefl"
+)
+
+func (t *TestType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case string(TestTypeSAT),
+		string(TestTypeACT),
+		string(TestTypeIELTS),
+		string(TestTypeTOEFL):
+		*t = TestType(s)
+		return nil
+	}
+	return fmt.Errorf("invalid test type: %s", s)
+}
+
+// ValidScore reports whether the test can be scored with the score: SAT total scores go from 400 to 1600
+// in steps of 10, ACT composite scores from 1 to 36, IELTS overall bands from 0 to 9 in steps of 0.5 and
+// TOEFL iBT total scores from 0 to 120.
+func (t TestType) ValidScore(score float64) bool {
+	switch t {
+	case TestTypeSAT:
+		return score >= 400 && score <= 1600 && math.Mod(score, 10) == 0
+	case TestTypeACT:
+		return score >= 1 && score <= 36 && score == math.Trunc(score)
+	case TestTypeIELTS:
+		return score >= 0 && score <= 9 && math.Mod(score, 0.5) == 0
+	case TestTypeTOEFL:
+		return score >= 0 && score <= 120 && score == math.Trunc(score)
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationEvaluation is an evaluation of the application as it was when SnapshotHash was computed.
+// Evaluation is the JSON encoded evaluation as generated by the model.
+type ApplicationEvaluation struct {
+	ID                    uuid.UUID
+	ApplicationID         uuid.UUID
+	SnapshotHash          string
+	PromptTemplate        string
+	PromptTemplateVersion int32
+	Evaluation            json.RawMessage
+	CreatedAt             time.Time
+}
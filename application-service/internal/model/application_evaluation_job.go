@@ -0,0 +1,44 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApplicationEvaluationJobStatus string
+
+const (
+	ApplicationEvaluationJobQueued    ApplicationEvaluationJobStatus = "queued"
+	ApplicationEvaluationJobRunning   ApplicationEvaluationJobStatus = "running"
+	ApplicationEvaluationJobSucceeded ApplicationEvaluationJobStatus = "succeeded"
+	ApplicationEvaluationJobFailed    ApplicationEvaluationJobStatus = "failed"
+)
+
+// ApplicationEvaluationJob is a request of UserID to evaluate the application in the background.
+// EvaluationID is set once the job succeeds, ErrorType and ErrorDetails once it fails. Attempts counts
+// the times the job was claimed by the workers. The job is run with the subscription of the user it was
+// enqueued with, and isn't claimed before NotBefore if it is set.
+type ApplicationEvaluationJob struct {
+	ID                      uuid.UUID
+	ApplicationID           uuid.UUID
+	UserID                  uuid.UUID
+	SubscriptionTier        string
+	SubscriptionPeriodStart *time.Time
+	SubscriptionPeriodEnd   *time.Time
+	Status                  ApplicationEvaluationJobStatus
+	Attempts                int
+	EvaluationID            *uuid.UUID
+	ErrorType               *int
+	ErrorDetails            json.RawMessage
+	CreatedAt               time.Time
+	StartedAt               *time.Time
+	FinishedAt              *time.Time
+	NotBefore               *time.Time
+}
+
+// Finished reports whether the job succeeded or failed.
+func (j ApplicationEvaluationJob) Finished() bool {
+	return j.Status == ApplicationEvaluationJobSucceeded || j.Status == ApplicationEvaluationJobFailed
+}
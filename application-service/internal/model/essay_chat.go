@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EssayChatRole string
+
+const (
+	EssayChatRoleUser      EssayChatRole = "user"
+	EssayChatRoleAssistant EssayChatRole = "assistant"
+)
+
+// EssayChatMessage is a message of the conversation of the student with the essay coach about a single essay.
+// UserID is the user who sent the message or whose question the assistant answered.
+type EssayChatMessage struct {
+	ID            uuid.UUID
+	ApplicationID uuid.UUID
+	EssayID       uuid.UUID
+	UserID        uuid.UUID
+	Role          EssayChatRole
+	Content       string
+	CreatedAt     time.Time
+}
@@ -19,7 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LLMService_GenerateResponse_FullMethodName = "/llm_service.v1.LLMService/GenerateResponse"
+	LLMService_GenerateResponse_FullMethodName                  = "/llm_service.v1.LLMService/GenerateResponse"
+	LLMService_GenerateResponseStream_FullMethodName            = "/llm_service.v1.LLMService/GenerateResponseStream"
+	LLMService_GetUsage_FullMethodName                          = "/llm_service.v1.LLMService/GetUsage"
+	LLMService_Embed_FullMethodName                             = "/llm_service.v1.LLMService/Embed"
+	LLMService_GetAdmissionStatus_FullMethodName                = "/llm_service.v1.LLMService/GetAdmissionStatus"
+	LLMService_RenderPromptTemplate_FullMethodName              = "/llm_service.v1.LLMService/RenderPromptTemplate"
+	LLMService_CreatePromptTemplate_FullMethodName              = "/llm_service.v1.LLMService/CreatePromptTemplate"
+	LLMService_SubmitBatch_FullMethodName                       = "/llm_service.v1.LLMService/SubmitBatch"
+	LLMService_GetBatch_FullMethodName                          = "/llm_service.v1.LLMService/GetBatch"
+	LLMService_WatchBatch_FullMethodName                        = "/llm_service.v1.LLMService/WatchBatch"
+	LLMService_CreateConversation_FullMethodName                = "/llm_service.v1.LLMService/CreateConversation"
+	LLMService_AppendConversationMessages_FullMethodName        = "/llm_service.v1.LLMService/AppendConversationMessages"
+	LLMService_GenerateConversationMessage_FullMethodName       = "/llm_service.v1.LLMService/GenerateConversationMessage"
+	LLMService_GenerateConversationMessageStream_FullMethodName = "/llm_service.v1.LLMService/GenerateConversationMessageStream"
+	LLMService_ListConversationMessages_FullMethodName          = "/llm_service.v1.LLMService/ListConversationMessages"
+	LLMService_DeleteConversation_FullMethodName                = "/llm_service.v1.LLMService/DeleteConversation"
 )
 
 // LLMServiceClient is the client API for LLMService service.
@@ -27,6 +42,23 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LLMServiceClient interface {
 	GenerateResponse(ctx context.Context, in *GenerateResponseRequest, opts ...grpc.CallOption) (*GenerateResponseResponse, error)
+	GenerateResponseStream(ctx context.Context, in *GenerateResponseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateResponseStreamResponse], error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
+	GetAdmissionStatus(ctx context.Context, in *GetAdmissionStatusRequest, opts ...grpc.CallOption) (*GetAdmissionStatusResponse, error)
+	RenderPromptTemplate(ctx context.Context, in *RenderPromptTemplateRequest, opts ...grpc.CallOption) (*RenderPromptTemplateResponse, error)
+	CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*CreatePromptTemplateResponse, error)
+	SubmitBatch(ctx context.Context, in *SubmitBatchRequest, opts ...grpc.CallOption) (*SubmitBatchResponse, error)
+	GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*GetBatchResponse, error)
+	// WatchBatch streams the items of the batch as they succeed or fail, including the items
+	// which are already finished. The stream ends once the batch is completed.
+	WatchBatch(ctx context.Context, in *WatchBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchItem], error)
+	CreateConversation(ctx context.Context, in *CreateConversationRequest, opts ...grpc.CallOption) (*CreateConversationResponse, error)
+	AppendConversationMessages(ctx context.Context, in *AppendConversationMessagesRequest, opts ...grpc.CallOption) (*AppendConversationMessagesResponse, error)
+	GenerateConversationMessage(ctx context.Context, in *GenerateConversationMessageRequest, opts ...grpc.CallOption) (*GenerateResponseResponse, error)
+	GenerateConversationMessageStream(ctx context.Context, in *GenerateConversationMessageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateResponseStreamResponse], error)
+	ListConversationMessages(ctx context.Context, in *ListConversationMessagesRequest, opts ...grpc.CallOption) (*ListConversationMessagesResponse, error)
+	DeleteConversation(ctx context.Context, in *DeleteConversationRequest, opts ...grpc.CallOption) (*DeleteConversationResponse, error)
 }
 
 type lLMServiceClient struct {
@@ -47,11 +79,205 @@ func (c *lLMServiceClient) GenerateResponse(ctx context.Context, in *GenerateRes
 	return out, nil
 }
 
+func (c *lLMServiceClient) GenerateResponseStream(ctx context.Context, in *GenerateResponseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateResponseStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LLMService_ServiceDesc.Streams[0], LLMService_GenerateResponseStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateResponseRequest, GenerateResponseStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_GenerateResponseStreamClient = grpc.ServerStreamingClient[GenerateResponseStreamResponse]
+
+func (c *lLMServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, LLMService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbedResponse)
+	err := c.cc.Invoke(ctx, LLMService_Embed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) GetAdmissionStatus(ctx context.Context, in *GetAdmissionStatusRequest, opts ...grpc.CallOption) (*GetAdmissionStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAdmissionStatusResponse)
+	err := c.cc.Invoke(ctx, LLMService_GetAdmissionStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) RenderPromptTemplate(ctx context.Context, in *RenderPromptTemplateRequest, opts ...grpc.CallOption) (*RenderPromptTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderPromptTemplateResponse)
+	err := c.cc.Invoke(ctx, LLMService_RenderPromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*CreatePromptTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePromptTemplateResponse)
+	err := c.cc.Invoke(ctx, LLMService_CreatePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) SubmitBatch(ctx context.Context, in *SubmitBatchRequest, opts ...grpc.CallOption) (*SubmitBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitBatchResponse)
+	err := c.cc.Invoke(ctx, LLMService_SubmitBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*GetBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBatchResponse)
+	err := c.cc.Invoke(ctx, LLMService_GetBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) WatchBatch(ctx context.Context, in *WatchBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LLMService_ServiceDesc.Streams[1], LLMService_WatchBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBatchRequest, BatchItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_WatchBatchClient = grpc.ServerStreamingClient[BatchItem]
+
+func (c *lLMServiceClient) CreateConversation(ctx context.Context, in *CreateConversationRequest, opts ...grpc.CallOption) (*CreateConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateConversationResponse)
+	err := c.cc.Invoke(ctx, LLMService_CreateConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) AppendConversationMessages(ctx context.Context, in *AppendConversationMessagesRequest, opts ...grpc.CallOption) (*AppendConversationMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AppendConversationMessagesResponse)
+	err := c.cc.Invoke(ctx, LLMService_AppendConversationMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) GenerateConversationMessage(ctx context.Context, in *GenerateConversationMessageRequest, opts ...grpc.CallOption) (*GenerateResponseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponseResponse)
+	err := c.cc.Invoke(ctx, LLMService_GenerateConversationMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) GenerateConversationMessageStream(ctx context.Context, in *GenerateConversationMessageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateResponseStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LLMService_ServiceDesc.Streams[2], LLMService_GenerateConversationMessageStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateConversationMessageRequest, GenerateResponseStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_GenerateConversationMessageStreamClient = grpc.ServerStreamingClient[GenerateResponseStreamResponse]
+
+func (c *lLMServiceClient) ListConversationMessages(ctx context.Context, in *ListConversationMessagesRequest, opts ...grpc.CallOption) (*ListConversationMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConversationMessagesResponse)
+	err := c.cc.Invoke(ctx, LLMService_ListConversationMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) DeleteConversation(ctx context.Context, in *DeleteConversationRequest, opts ...grpc.CallOption) (*DeleteConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConversationResponse)
+	err := c.cc.Invoke(ctx, LLMService_DeleteConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LLMServiceServer is the server API for LLMService service.
 // All implementations must embed UnimplementedLLMServiceServer
 // for forward compatibility.
 type LLMServiceServer interface {
 	GenerateResponse(context.Context, *GenerateResponseRequest) (*GenerateResponseResponse, error)
+	GenerateResponseStream(*GenerateResponseRequest, grpc.ServerStreamingServer[GenerateResponseStreamResponse]) error
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	Embed(context.Context, *EmbedRequest) (*EmbedResponse, error)
+	GetAdmissionStatus(context.Context, *GetAdmissionStatusRequest) (*GetAdmissionStatusResponse, error)
+	RenderPromptTemplate(context.Context, *RenderPromptTemplateRequest) (*RenderPromptTemplateResponse, error)
+	CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*CreatePromptTemplateResponse, error)
+	SubmitBatch(context.Context, *SubmitBatchRequest) (*SubmitBatchResponse, error)
+	GetBatch(context.Context, *GetBatchRequest) (*GetBatchResponse, error)
+	// WatchBatch streams the items of the batch as they succeed or fail, including the items
+	// which are already finished. The stream ends once the batch is completed.
+	WatchBatch(*WatchBatchRequest, grpc.ServerStreamingServer[BatchItem]) error
+	CreateConversation(context.Context, *CreateConversationRequest) (*CreateConversationResponse, error)
+	AppendConversationMessages(context.Context, *AppendConversationMessagesRequest) (*AppendConversationMessagesResponse, error)
+	GenerateConversationMessage(context.Context, *GenerateConversationMessageRequest) (*GenerateResponseResponse, error)
+	GenerateConversationMessageStream(*GenerateConversationMessageRequest, grpc.ServerStreamingServer[GenerateResponseStreamResponse]) error
+	ListConversationMessages(context.Context, *ListConversationMessagesRequest) (*ListConversationMessagesResponse, error)
+	DeleteConversation(context.Context, *DeleteConversationRequest) (*DeleteConversationResponse, error)
 	mustEmbedUnimplementedLLMServiceServer()
 }
 
@@ -65,6 +291,51 @@ type UnimplementedLLMServiceServer struct{}
 func (UnimplementedLLMServiceServer) GenerateResponse(context.Context, *GenerateResponseRequest) (*GenerateResponseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateResponse not implemented")
 }
+func (UnimplementedLLMServiceServer) GenerateResponseStream(*GenerateResponseRequest, grpc.ServerStreamingServer[GenerateResponseStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateResponseStream not implemented")
+}
+func (UnimplementedLLMServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedLLMServiceServer) Embed(context.Context, *EmbedRequest) (*EmbedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Embed not implemented")
+}
+func (UnimplementedLLMServiceServer) GetAdmissionStatus(context.Context, *GetAdmissionStatusRequest) (*GetAdmissionStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAdmissionStatus not implemented")
+}
+func (UnimplementedLLMServiceServer) RenderPromptTemplate(context.Context, *RenderPromptTemplateRequest) (*RenderPromptTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderPromptTemplate not implemented")
+}
+func (UnimplementedLLMServiceServer) CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*CreatePromptTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromptTemplate not implemented")
+}
+func (UnimplementedLLMServiceServer) SubmitBatch(context.Context, *SubmitBatchRequest) (*SubmitBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitBatch not implemented")
+}
+func (UnimplementedLLMServiceServer) GetBatch(context.Context, *GetBatchRequest) (*GetBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatch not implemented")
+}
+func (UnimplementedLLMServiceServer) WatchBatch(*WatchBatchRequest, grpc.ServerStreamingServer[BatchItem]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBatch not implemented")
+}
+func (UnimplementedLLMServiceServer) CreateConversation(context.Context, *CreateConversationRequest) (*CreateConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConversation not implemented")
+}
+func (UnimplementedLLMServiceServer) AppendConversationMessages(context.Context, *AppendConversationMessagesRequest) (*AppendConversationMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendConversationMessages not implemented")
+}
+func (UnimplementedLLMServiceServer) GenerateConversationMessage(context.Context, *GenerateConversationMessageRequest) (*GenerateResponseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateConversationMessage not implemented")
+}
+func (UnimplementedLLMServiceServer) GenerateConversationMessageStream(*GenerateConversationMessageRequest, grpc.ServerStreamingServer[GenerateResponseStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateConversationMessageStream not implemented")
+}
+func (UnimplementedLLMServiceServer) ListConversationMessages(context.Context, *ListConversationMessagesRequest) (*ListConversationMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversationMessages not implemented")
+}
+func (UnimplementedLLMServiceServer) DeleteConversation(context.Context, *DeleteConversationRequest) (*DeleteConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConversation not implemented")
+}
 func (UnimplementedLLMServiceServer) mustEmbedUnimplementedLLMServiceServer() {}
 func (UnimplementedLLMServiceServer) testEmbeddedByValue()                    {}
 
@@ -104,6 +375,255 @@ func _LLMService_GenerateResponse_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LLMService_GenerateResponseStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateResponseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LLMServiceServer).GenerateResponseStream(m, &grpc.GenericServerStream[GenerateResponseRequest, GenerateResponseStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_GenerateResponseStreamServer = grpc.ServerStreamingServer[GenerateResponseStreamResponse]
+
+func _LLMService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_Embed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).Embed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_Embed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).Embed(ctx, req.(*EmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_GetAdmissionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdmissionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).GetAdmissionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_GetAdmissionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).GetAdmissionStatus(ctx, req.(*GetAdmissionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_RenderPromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderPromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).RenderPromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_RenderPromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).RenderPromptTemplate(ctx, req.(*RenderPromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_CreatePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).CreatePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_CreatePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).CreatePromptTemplate(ctx, req.(*CreatePromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_SubmitBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).SubmitBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_SubmitBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).SubmitBatch(ctx, req.(*SubmitBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_GetBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).GetBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_GetBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).GetBatch(ctx, req.(*GetBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_WatchBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LLMServiceServer).WatchBatch(m, &grpc.GenericServerStream[WatchBatchRequest, BatchItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_WatchBatchServer = grpc.ServerStreamingServer[BatchItem]
+
+func _LLMService_CreateConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).CreateConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_CreateConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).CreateConversation(ctx, req.(*CreateConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_AppendConversationMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendConversationMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).AppendConversationMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_AppendConversationMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).AppendConversationMessages(ctx, req.(*AppendConversationMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_GenerateConversationMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateConversationMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).GenerateConversationMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_GenerateConversationMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).GenerateConversationMessage(ctx, req.(*GenerateConversationMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_GenerateConversationMessageStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateConversationMessageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LLMServiceServer).GenerateConversationMessageStream(m, &grpc.GenericServerStream[GenerateConversationMessageRequest, GenerateResponseStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_GenerateConversationMessageStreamServer = grpc.ServerStreamingServer[GenerateResponseStreamResponse]
+
+func _LLMService_ListConversationMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).ListConversationMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_ListConversationMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).ListConversationMessages(ctx, req.(*ListConversationMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_DeleteConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).DeleteConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_DeleteConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).DeleteConversation(ctx, req.(*DeleteConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LLMService_ServiceDesc is the grpc.ServiceDesc for LLMService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +635,71 @@ var LLMService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateResponse",
 			Handler:    _LLMService_GenerateResponse_Handler,
 		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _LLMService_GetUsage_Handler,
+		},
+		{
+			MethodName: "Embed",
+			Handler:    _LLMService_Embed_Handler,
+		},
+		{
+			MethodName: "GetAdmissionStatus",
+			Handler:    _LLMService_GetAdmissionStatus_Handler,
+		},
+		{
+			MethodName: "RenderPromptTemplate",
+			Handler:    _LLMService_RenderPromptTemplate_Handler,
+		},
+		{
+			MethodName: "CreatePromptTemplate",
+			Handler:    _LLMService_CreatePromptTemplate_Handler,
+		},
+		{
+			MethodName: "SubmitBatch",
+			Handler:    _LLMService_SubmitBatch_Handler,
+		},
+		{
+			MethodName: "GetBatch",
+			Handler:    _LLMService_GetBatch_Handler,
+		},
+		{
+			MethodName: "CreateConversation",
+			Handler:    _LLMService_CreateConversation_Handler,
+		},
+		{
+			MethodName: "AppendConversationMessages",
+			Handler:    _LLMService_AppendConversationMessages_Handler,
+		},
+		{
+			MethodName: "GenerateConversationMessage",
+			Handler:    _LLMService_GenerateConversationMessage_Handler,
+		},
+		{
+			MethodName: "ListConversationMessages",
+			Handler:    _LLMService_ListConversationMessages_Handler,
+		},
+		{
+			MethodName: "DeleteConversation",
+			Handler:    _LLMService_DeleteConversation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateResponseStream",
+			Handler:       _LLMService_GenerateResponseStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBatch",
+			Handler:       _LLMService_WatchBatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GenerateConversationMessageStream",
+			Handler:       _LLMService_GenerateConversationMessageStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "application-service/proto/llm_service.proto",
 }
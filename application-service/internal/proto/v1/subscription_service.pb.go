@@ -9,6 +9,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -118,8 +119,11 @@ func (x *GetSubscriptionTierRequest) GetUserId() string {
 }
 
 type GetSubscriptionTierResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tier          SubscriptionTier       `protobuf:"varint,1,opt,name=tier,proto3,enum=subscription_service.v1.SubscriptionTier" json:"tier,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tier  SubscriptionTier       `protobuf:"varint,1,opt,name=tier,proto3,enum=subscription_service.v1.SubscriptionTier" json:"tier,omitempty"`
+	// The current billing period of the subscription, unset if the user isn't a member of any subscription.
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=periodStart,proto3" json:"periodStart,omitempty"`
+	PeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=periodEnd,proto3" json:"periodEnd,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -161,15 +165,31 @@ func (x *GetSubscriptionTierResponse) GetTier() SubscriptionTier {
 	return SubscriptionTier_NONE
 }
 
+func (x *GetSubscriptionTierResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *GetSubscriptionTierResponse) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
 var File_application_service_proto_subscription_service_proto protoreflect.FileDescriptor
 
 const file_application_service_proto_subscription_service_proto_rawDesc = "" +
 	"\n" +
-	"4application-service/proto/subscription_service.proto\x12\x17subscription_service.v1\"4\n" +
+	"4application-service/proto/subscription_service.proto\x12\x17subscription_service.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"4\n" +
 	"\x1aGetSubscriptionTierRequest\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\tR\x06userId\"\\\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\"\xd4\x01\n" +
 	"\x1bGetSubscriptionTierResponse\x12=\n" +
-	"\x04tier\x18\x01 \x01(\x0e2).subscription_service.v1.SubscriptionTierR\x04tier*B\n" +
+	"\x04tier\x18\x01 \x01(\x0e2).subscription_service.v1.SubscriptionTierR\x04tier\x12<\n" +
+	"\vperiodStart\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x128\n" +
+	"\tperiodEnd\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd*B\n" +
 	"\x10SubscriptionTier\x12\b\n" +
 	"\x04NONE\x10\x00\x12\v\n" +
 	"\aSTUDENT\x10\x01\x12\b\n" +
@@ -196,16 +216,19 @@ var file_application_service_proto_subscription_service_proto_goTypes = []any{
 	(SubscriptionTier)(0),               // 0: subscription_service.v1.SubscriptionTier
 	(*GetSubscriptionTierRequest)(nil),  // 1: subscription_service.v1.GetSubscriptionTierRequest
 	(*GetSubscriptionTierResponse)(nil), // 2: subscription_service.v1.GetSubscriptionTierResponse
+	(*timestamppb.Timestamp)(nil),       // 3: google.protobuf.Timestamp
 }
 var file_application_service_proto_subscription_service_proto_depIdxs = []int32{
 	0, // 0: subscription_service.v1.GetSubscriptionTierResponse.tier:type_name -> subscription_service.v1.SubscriptionTier
-	1, // 1: subscription_service.v1.SubscriptionService.GetSubscriptionTier:input_type -> subscription_service.v1.GetSubscriptionTierRequest
-	2, // 2: subscription_service.v1.SubscriptionService.GetSubscriptionTier:output_type -> subscription_service.v1.GetSubscriptionTierResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 1: subscription_service.v1.GetSubscriptionTierResponse.periodStart:type_name -> google.protobuf.Timestamp
+	3, // 2: subscription_service.v1.GetSubscriptionTierResponse.periodEnd:type_name -> google.protobuf.Timestamp
+	1, // 3: subscription_service.v1.SubscriptionService.GetSubscriptionTier:input_type -> subscription_service.v1.GetSubscriptionTierRequest
+	2, // 4: subscription_service.v1.SubscriptionService.GetSubscriptionTier:output_type -> subscription_service.v1.GetSubscriptionTierResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_application_service_proto_subscription_service_proto_init() }
@@ -18,6 +18,10 @@ type ApplicationRepository interface {
 	GetActivities(ctx context.Context, applicationID uuid.UUID) []model.Activity
 	PutActivities(ctx context.Context, applicationID uuid.UUID, activities []model.Activity)
 
+	// GetAcademics returns empty academics if they were never put.
+	GetAcademics(ctx context.Context, applicationID uuid.UUID) model.Academics
+	PutAcademics(ctx context.Context, applicationID uuid.UUID, academics model.Academics)
+
 	GetHonors(ctx context.Context, applicationID uuid.UUID) []model.Honor
 	PutHonors(ctx context.Context, applicationID uuid.UUID, honors []model.Honor)
 
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
+type ApplicationEvaluationRepository interface {
+	CreateApplicationEvaluation(ctx context.Context, evaluation model.ApplicationEvaluation)
+	// GetApplicationEvaluation returns nil if the application has no evaluation with the ID.
+	GetApplicationEvaluation(ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluation
+	// GetLatestApplicationEvaluation returns nil if the application was never evaluated.
+	GetLatestApplicationEvaluation(ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluation
+	// FindApplicationEvaluations returns the evaluations of the application, newest first.
+	FindApplicationEvaluations(ctx context.Context, applicationID uuid.UUID) []model.ApplicationEvaluation
+}
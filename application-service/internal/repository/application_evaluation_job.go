@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
+type ApplicationEvaluationJobRepository interface {
+	// CreateApplicationEvaluationJob returns false, without creating the job, if the application already has
+	// a queued or running job.
+	CreateApplicationEvaluationJob(ctx context.Context, job model.ApplicationEvaluationJob) bool
+	// GetApplicationEvaluationJob returns nil if the application has no job with the ID.
+	GetApplicationEvaluationJob(ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluationJob
+	// GetUnfinishedApplicationEvaluationJob returns the latest queued or running job of the application, or nil.
+	GetUnfinishedApplicationEvaluationJob(ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluationJob
+	// ClaimApplicationEvaluationJobs marks up to limit of the oldest queued jobs which may run now, and of the
+	// running jobs claimed before leaseExpiredBefore, as running and returns them. The jobs claimed maxAttempts
+	// times aren't claimed again.
+	ClaimApplicationEvaluationJobs(
+		ctx context.Context, limit int, leaseExpiredBefore time.Time, maxAttempts int) []model.ApplicationEvaluationJob
+	// FailAbandonedApplicationEvaluationJobs marks the running jobs claimed maxAttempts times, the last time
+	// before leaseExpiredBefore, as failed with an internal error, and returns how many jobs were failed.
+	FailAbandonedApplicationEvaluationJobs(ctx context.Context, leaseExpiredBefore time.Time, maxAttempts int) int
+	// RetryApplicationEvaluationJob puts the running job back to the queue, so that it isn't claimed before
+	// notBefore, unless the job was taken over by another worker since it was claimed with job.Attempts.
+	RetryApplicationEvaluationJob(ctx context.Context, job model.ApplicationEvaluationJob, notBefore time.Time)
+	// FinishApplicationEvaluationJob stores the status, the evaluation or the error of the finished job, unless
+	// the job was taken over by another worker since it was claimed with job.Attempts.
+	FinishApplicationEvaluationJob(ctx context.Context, job model.ApplicationEvaluationJob)
+}
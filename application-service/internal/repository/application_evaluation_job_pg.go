@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
+type pgApplicationEvaluationJobRepository struct {
+	db *sql.DB
+}
+
+func NewPgApplicationEvaluationJobRepository(db *sql.DB) ApplicationEvaluationJobRepository {
+	return &pgApplicationEvaluationJobRepository{
+		db: db,
+	}
+}
+
+func (r *pgApplicationEvaluationJobRepository) CreateApplicationEvaluationJob(
+	ctx context.Context, job model.ApplicationEvaluationJob) bool {
+	// The unique index on the unfinished jobs of the application makes the concurrent requests create
+	// a single job.
+	query := `
+		INSERT INTO application_evaluation_jobs (id, application_id, user_id, subscription_tier,
+		                                         subscription_period_start, subscription_period_end, status,
+		                                         created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (application_id) WHERE status IN ('queued', 'running') DO NOTHING
+	`
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		job.ID,
+		job.ApplicationID,
+		job.UserID,
+		job.SubscriptionTier,
+		job.SubscriptionPeriodStart,
+		job.SubscriptionPeriodEnd,
+		job.Status,
+		job.CreatedAt,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		panic(err)
+	}
+
+	return rowsAffected == 1
+}
+
+func (r *pgApplicationEvaluationJobRepository) GetApplicationEvaluationJob(
+	ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluationJob {
+	query := `
+		SELECT id, application_id, user_id, subscription_tier, subscription_period_start, subscription_period_end,
+		       status, attempts, evaluation_id, error_type, error_details, created_at, started_at, finished_at,
+		       not_before
+		FROM application_evaluation_jobs
+		WHERE application_id = $1 AND id = $2
+	`
+
+	return scanApplicationEvaluationJob(r.db.QueryRowContext(ctx, query, applicationID, id))
+}
+
+func (r *pgApplicationEvaluationJobRepository) GetUnfinishedApplicationEvaluationJob(
+	ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluationJob {
+	query := `
+		SELECT id, application_id, user_id, subscription_tier, subscription_period_start, subscription_period_end,
+		       status, attempts, evaluation_id, error_type, error_details, created_at, started_at, finished_at,
+		       not_before
+		FROM application_evaluation_jobs
+		WHERE application_id = $1 AND status IN ('queued', 'running')
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return scanApplicationEvaluationJob(r.db.QueryRowContext(ctx, query, applicationID))
+}
+
+func (r *pgApplicationEvaluationJobRepository) ClaimApplicationEvaluationJobs(
+	ctx context.Context, limit int, leaseExpiredBefore time.Time, maxAttempts int) []model.ApplicationEvaluationJob {
+	var jobs []model.ApplicationEvaluationJob
+	// SKIP LOCKED lets the workers of other replicas claim different jobs at the same time.
+	// The oldest jobs go first.
+	query := `
+		UPDATE application_evaluation_jobs
+		SET status = 'running', started_at = NOW(), attempts = attempts + 1, not_before = NULL
+		WHERE id IN (
+			SELECT id
+			FROM application_evaluation_jobs
+			WHERE (status = 'queued' AND (not_before IS NULL OR not_before <= NOW())
+			       OR status = 'running' AND started_at < $2)
+			  AND attempts < $3
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, application_id, user_id, subscription_tier, subscription_period_start, subscription_period_end,
+		          status, attempts, evaluation_id, error_type, error_details, created_at, started_at, finished_at,
+		          not_before
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, leaseExpiredBefore, maxAttempts)
+	if err != nil {
+		panic(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		job := model.ApplicationEvaluationJob{}
+		err := rows.Scan(
+			&job.ID,
+			&job.ApplicationID,
+			&job.UserID,
+			&job.SubscriptionTier,
+			&job.SubscriptionPeriodStart,
+			&job.SubscriptionPeriodEnd,
+			&job.Status,
+			&job.Attempts,
+			&job.EvaluationID,
+			&job.ErrorType,
+			(*[]byte)(&job.ErrorDetails),
+			&job.CreatedAt,
+			&job.StartedAt,
+			&job.FinishedAt,
+			&job.NotBefore,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return jobs
+}
+
+func (r *pgApplicationEvaluationJobRepository) FailAbandonedApplicationEvaluationJobs(
+	ctx context.Context, leaseExpiredBefore time.Time, maxAttempts int) int {
+	// The error type 0 is the type of internal server errors.
+	query := `
+		UPDATE application_evaluation_jobs
+		SET status = 'failed', error_type = 0, finished_at = NOW()
+		WHERE status = 'running' AND started_at < $1 AND attempts >= $2
+	`
+	result, err := r.db.ExecContext(ctx, query, leaseExpiredBefore, maxAttempts)
+	if err != nil {
+		panic(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		panic(err)
+	}
+
+	return int(rowsAffected)
+}
+
+func (r *pgApplicationEvaluationJobRepository) RetryApplicationEvaluationJob(
+	ctx context.Context, job model.ApplicationEvaluationJob, notBefore time.Time) {
+	query := `
+		UPDATE application_evaluation_jobs
+		SET status = 'queued', not_before = $2
+		WHERE id = $1 AND attempts = $3 AND status = 'running'
+	`
+	_, err := r.db.ExecContext(ctx, query, job.ID, notBefore, job.Attempts)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (r *pgApplicationEvaluationJobRepository) FinishApplicationEvaluationJob(
+	ctx context.Context, job model.ApplicationEvaluationJob) {
+	query := `
+		UPDATE application_evaluation_jobs
+		SET status = $2, evaluation_id = $3, error_type = $4, error_details = $5, finished_at = $6
+		WHERE id = $1 AND attempts = $7
+	`
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		job.ID,
+		job.Status,
+		job.EvaluationID,
+		job.ErrorType,
+		[]byte(job.ErrorDetails),
+		job.FinishedAt,
+		job.Attempts,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func scanApplicationEvaluationJob(row *sql.Row) *model.ApplicationEvaluationJob {
+	job := &model.ApplicationEvaluationJob{}
+	err := row.Scan(
+		&job.ID,
+		&job.ApplicationID,
+		&job.UserID,
+		&job.SubscriptionTier,
+		&job.SubscriptionPeriodStart,
+		&job.SubscriptionPeriodEnd,
+		&job.Status,
+		&job.Attempts,
+		&job.EvaluationID,
+		&job.ErrorType,
+		(*[]byte)(&job.ErrorDetails),
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.NotBefore,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		panic(err)
+	}
+
+	return job
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
+type pgApplicationEvaluationRepository struct {
+	db *sql.DB
+}
+
+func NewPgApplicationEvaluationRepository(db *sql.DB) ApplicationEvaluationRepository {
+	return &pgApplicationEvaluationRepository{
+		db: db,
+	}
+}
+
+func (r *pgApplicationEvaluationRepository) CreateApplicationEvaluation(
+	ctx context.Context, evaluation model.ApplicationEvaluation) {
+	query := `
+		INSERT INTO application_evaluations (id, application_id, snapshot_hash, prompt_template,
+		                                     prompt_template_version, evaluation, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		evaluation.ID,
+		evaluation.ApplicationID,
+		evaluation.SnapshotHash,
+		evaluation.PromptTemplate,
+		evaluation.PromptTemplateVersion,
+		[]byte(evaluation.Evaluation),
+		evaluation.CreatedAt,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (r *pgApplicationEvaluationRepository) GetApplicationEvaluation(
+	ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluation {
+	query := `
+		SELECT id, application_id, snapshot_hash, prompt_template, prompt_template_version, evaluation, created_at
+		FROM application_evaluations
+		WHERE application_id = $1 AND id = $2
+	`
+
+	return scanApplicationEvaluation(r.db.QueryRowContext(ctx, query, applicationID, id))
+}
+
+func (r *pgApplicationEvaluationRepository) GetLatestApplicationEvaluation(
+	ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluation {
+	query := `
+		SELECT id, application_id, snapshot_hash, prompt_template, prompt_template_version, evaluation, created_at
+		FROM application_evaluations
+		WHERE application_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return scanApplicationEvaluation(r.db.QueryRowContext(ctx, query, applicationID))
+}
+
+func (r *pgApplicationEvaluationRepository) FindApplicationEvaluations(
+	ctx context.Context, applicationID uuid.UUID) []model.ApplicationEvaluation {
+	var evaluations []model.ApplicationEvaluation
+	query := `
+		SELECT id, application_id, snapshot_hash, prompt_template, prompt_template_version, evaluation, created_at
+		FROM application_evaluations
+		WHERE application_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		panic(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		evaluation := model.ApplicationEvaluation{}
+		err := rows.Scan(
+			&evaluation.ID,
+			&evaluation.ApplicationID,
+			&evaluation.SnapshotHash,
+			&evaluation.PromptTemplate,
+			&evaluation.PromptTemplateVersion,
+			(*[]byte)(&evaluation.Evaluation),
+			&evaluation.CreatedAt,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		evaluations = append(evaluations, evaluation)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return evaluations
+}
+
+func scanApplicationEvaluation(row *sql.Row) *model.ApplicationEvaluation {
+	evaluation := &model.ApplicationEvaluation{}
+	err := row.Scan(
+		&evaluation.ID,
+		&evaluation.ApplicationID,
+		&evaluation.SnapshotHash,
+		&evaluation.PromptTemplate,
+		&evaluation.PromptTemplateVersion,
+		(*[]byte)(&evaluation.Evaluation),
+		&evaluation.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		panic(err)
+	}
+
+	return evaluation
+}
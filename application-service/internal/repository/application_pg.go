@@ -225,6 +225,220 @@ func (r *pgApplicationRepository) PutActivities(ctx context.Context, application
 	}
 }
 
+func (r *pgApplicationRepository) GetAcademics(ctx context.Context, applicationID uuid.UUID) model.Academics {
+	academics := model.Academics{}
+
+	var gpa, gpaScale sql.NullFloat64
+	var gpaWeighted sql.NullBool
+	var classRank, classSize sql.NullInt64
+
+	query := `
+		SELECT gpa, gpa_scale, gpa_weighted, class_rank, class_size
+		FROM academics
+		WHERE application_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, applicationID).Scan(
+		&gpa,
+		&gpaScale,
+		&gpaWeighted,
+		&classRank,
+		&classSize,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		panic(err)
+	}
+
+	if gpa.Valid {
+		academics.GPA = &model.GPA{Value: gpa.Float64, Scale: gpaScale.Float64, Weighted: gpaWeighted.Bool}
+	}
+
+	if classRank.Valid {
+		academics.ClassRank = &model.ClassRank{Rank: int(classRank.Int64), ClassSize: int(classSize.Int64)}
+	}
+
+	academics.Courses = r.getCourses(ctx, applicationID)
+	academics.TestScores = r.getTestScores(ctx, applicationID)
+
+	return academics
+}
+
+func (r *pgApplicationRepository) getCourses(ctx context.Context, applicationID uuid.UUID) []model.Course {
+	var courses []model.Course
+	query := `
+		SELECT name, rigor, grade, result
+		FROM courses
+		WHERE application_id = $1
+		ORDER BY index
+	`
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		panic(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		course := model.Course{}
+		var result sql.NullString
+		err := rows.Scan(
+			&course.Name,
+			&course.Rigor,
+			&course.Grade,
+			&result,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		if result.Valid {
+			course.Result = &result.String
+		}
+
+		courses = append(courses, course)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return courses
+}
+
+func (r *pgApplicationRepository) getTestScores(ctx context.Context, applicationID uuid.UUID) []model.TestScore {
+	var testScores []model.TestScore
+	query := `
+		SELECT type, score
+		FROM test_scores
+		WHERE application_id = $1
+		ORDER BY index
+	`
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		panic(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		testScore := model.TestScore{}
+		err := rows.Scan(
+			&testScore.Type,
+			&testScore.Score,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		testScores = append(testScores, testScore)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return testScores
+}
+
+func (r *pgApplicationRepository) PutAcademics(ctx context.Context, applicationID uuid.UUID, academics model.Academics) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	defer tx.Rollback()
+
+	var gpa, gpaScale sql.NullFloat64
+	var gpaWeighted sql.NullBool
+	if academics.GPA != nil {
+		gpa = sql.NullFloat64{Float64: academics.GPA.Value, Valid: true}
+		gpaScale = sql.NullFloat64{Float64: academics.GPA.Scale, Valid: true}
+		gpaWeighted = sql.NullBool{Bool: academics.GPA.Weighted, Valid: true}
+	}
+
+	var classRank, classSize sql.NullInt64
+	if academics.ClassRank != nil {
+		classRank = sql.NullInt64{Int64: int64(academics.ClassRank.Rank), Valid: true}
+		classSize = sql.NullInt64{Int64: int64(academics.ClassRank.ClassSize), Valid: true}
+	}
+
+	upsertQuery := `
+		INSERT INTO academics (application_id, gpa, gpa_scale, gpa_weighted, class_rank, class_size)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (application_id) DO UPDATE
+		SET gpa = $2, gpa_scale = $3, gpa_weighted = $4, class_rank = $5, class_size = $6
+	`
+	_, err = tx.ExecContext(ctx, upsertQuery, applicationID, gpa, gpaScale, gpaWeighted, classRank, classSize)
+	if err != nil {
+		panic(err)
+	}
+
+	deleteCoursesQuery := `
+		DELETE FROM courses
+		WHERE application_id = $1
+	`
+	_, err = tx.ExecContext(ctx, deleteCoursesQuery, applicationID)
+	if err != nil {
+		panic(err)
+	}
+
+	insertCourseQuery := `
+		INSERT INTO courses (index, application_id, name, rigor, grade, result)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for i, course := range academics.Courses {
+		var result sql.NullString
+		if course.Result != nil {
+			result = sql.NullString{String: *course.Result, Valid: true}
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			insertCourseQuery,
+			i,
+			applicationID,
+			course.Name,
+			course.Rigor,
+			course.Grade,
+			result,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	deleteTestScoresQuery := `
+		DELETE FROM test_scores
+		WHERE application_id = $1
+	`
+	_, err = tx.ExecContext(ctx, deleteTestScoresQuery, applicationID)
+	if err != nil {
+		panic(err)
+	}
+
+	insertTestScoreQuery := `
+		INSERT INTO test_scores (index, application_id, type, score)
+		VALUES ($1, $2, $3, $4)
+	`
+	for i, testScore := range academics.TestScores {
+		_, err = tx.ExecContext(
+			ctx,
+			insertTestScoreQuery,
+			i,
+			applicationID,
+			testScore.Type,
+			testScore.Score,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (r *pgApplicationRepository) GetHonors(ctx context.Context, applicationID uuid.UUID) []model.Honor {
 	var honors []model.Honor
 	query := `
@@ -322,7 +536,7 @@ func (r *pgApplicationRepository) PutHonors(ctx context.Context, applicationID u
 func (r *pgApplicationRepository) GetEssays(ctx context.Context, applicationID uuid.UUID) []model.Essay {
 	var essays []model.Essay
 	query := `
-		SELECT index, application_id, type, content
+		SELECT id, application_id, type, content
 		FROM essays
 		WHERE application_id = $1
 		ORDER BY index
@@ -374,13 +588,17 @@ func (r *pgApplicationRepository) PutEssays(ctx context.Context, applicationID u
 	}
 
 	insertQuery := `
-		INSERT INTO essays (index, application_id, type, content)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO essays (id, index, application_id, type, content)
+		VALUES ($1, $2, $3, $4, $5)
 	`
+	essayIDs := make([]uuid.UUID, len(essays))
 	for i, essay := range essays {
+		essayIDs[i] = essay.ID
+
 		_, err = tx.ExecContext(
 			ctx,
 			insertQuery,
+			essay.ID,
 			i,
 			applicationID,
 			essay.Type,
@@ -391,6 +609,16 @@ func (r *pgApplicationRepository) PutEssays(ctx context.Context, applicationID u
 		}
 	}
 
+	// The chats with the essay coach are kept as long as their essays are.
+	deleteChatsQuery := `
+		DELETE FROM essay_chat_messages
+		WHERE application_id = $1 AND NOT (essay_id = ANY($2))
+	`
+	_, err = tx.ExecContext(ctx, deleteChatsQuery, applicationID, pq.Array(essayIDs))
+	if err != nil {
+		panic(err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		panic(err)
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
+type EssayChatRepository interface {
+	// GetEssayChatMessages returns the messages of the chat about the essay, oldest first.
+	GetEssayChatMessages(ctx context.Context, essayID uuid.UUID) []model.EssayChatMessage
+	// CreateEssayChatMessages stores the question and the answer together, so that the chat never ends with
+	// an unanswered question.
+	CreateEssayChatMessages(ctx context.Context, messages []model.EssayChatMessage)
+	RemoveEssayChatMessages(ctx context.Context, essayID uuid.UUID)
+}
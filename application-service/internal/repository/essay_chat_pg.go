@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+)
+
+type pgEssayChatRepository struct {
+	db *sql.DB
+}
+
+func NewPgEssayChatRepository(db *sql.DB) EssayChatRepository {
+	return &pgEssayChatRepository{
+		db: db,
+	}
+}
+
+func (r *pgEssayChatRepository) GetEssayChatMessages(ctx context.Context, essayID uuid.UUID) []model.EssayChatMessage {
+	var messages []model.EssayChatMessage
+	query := `
+		SELECT id, application_id, essay_id, user_id, role, content, created_at
+		FROM essay_chat_messages
+		WHERE essay_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, essayID)
+	if err != nil {
+		panic(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		message := model.EssayChatMessage{}
+		err := rows.Scan(
+			&message.ID,
+			&message.ApplicationID,
+			&message.EssayID,
+			&message.UserID,
+			&message.Role,
+			&message.Content,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return messages
+}
+
+func (r *pgEssayChatRepository) CreateEssayChatMessages(ctx context.Context, messages []model.EssayChatMessage) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO essay_chat_messages (id, application_id, essay_id, user_id, role, content, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	for _, message := range messages {
+		_, err = tx.ExecContext(
+			ctx,
+			query,
+			message.ID,
+			message.ApplicationID,
+			message.EssayID,
+			message.UserID,
+			message.Role,
+			message.Content,
+			message.CreatedAt,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (r *pgEssayChatRepository) RemoveEssayChatMessages(ctx context.Context, essayID uuid.UUID) {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM essay_chat_messages WHERE essay_id = $1`, essayID)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,2096 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockApplicationRepository creates a new instance of MockApplicationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockApplicationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockApplicationRepository {
+	mock := &MockApplicationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockApplicationRepository is an autogenerated mock type for the ApplicationRepository type
+type MockApplicationRepository struct {
+	mock.Mock
+}
+
+type MockApplicationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockApplicationRepository) EXPECT() *MockApplicationRepository_Expecter {
+	return &MockApplicationRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateApplication provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) CreateApplication(ctx context.Context, app model.Application) {
+	_mock.Called(ctx, app)
+	return
+}
+
+// MockApplicationRepository_CreateApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApplication'
+type MockApplicationRepository_CreateApplication_Call struct {
+	*mock.Call
+}
+
+// CreateApplication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - app model.Application
+func (_e *MockApplicationRepository_Expecter) CreateApplication(ctx interface{}, app interface{}) *MockApplicationRepository_CreateApplication_Call {
+	return &MockApplicationRepository_CreateApplication_Call{Call: _e.mock.On("CreateApplication", ctx, app)}
+}
+
+func (_c *MockApplicationRepository_CreateApplication_Call) Run(run func(ctx context.Context, app model.Application)) *MockApplicationRepository_CreateApplication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Application
+		if args[1] != nil {
+			arg1 = args[1].(model.Application)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_CreateApplication_Call) Return() *MockApplicationRepository_CreateApplication_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_CreateApplication_Call) RunAndReturn(run func(ctx context.Context, app model.Application)) *MockApplicationRepository_CreateApplication_Call {
+	_c.Run(run)
+	return _c
+}
+
+// FindApplicationsByUserID provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) FindApplicationsByUserID(ctx context.Context, userID uuid.UUID) []model.Application {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindApplicationsByUserID")
+	}
+
+	var r0 []model.Application
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.Application); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Application)
+		}
+	}
+	return r0
+}
+
+// MockApplicationRepository_FindApplicationsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindApplicationsByUserID'
+type MockApplicationRepository_FindApplicationsByUserID_Call struct {
+	*mock.Call
+}
+
+// FindApplicationsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockApplicationRepository_Expecter) FindApplicationsByUserID(ctx interface{}, userID interface{}) *MockApplicationRepository_FindApplicationsByUserID_Call {
+	return &MockApplicationRepository_FindApplicationsByUserID_Call{Call: _e.mock.On("FindApplicationsByUserID", ctx, userID)}
+}
+
+func (_c *MockApplicationRepository_FindApplicationsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockApplicationRepository_FindApplicationsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_FindApplicationsByUserID_Call) Return(applications []model.Application) *MockApplicationRepository_FindApplicationsByUserID_Call {
+	_c.Call.Return(applications)
+	return _c
+}
+
+func (_c *MockApplicationRepository_FindApplicationsByUserID_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) []model.Application) *MockApplicationRepository_FindApplicationsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAcademics provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) GetAcademics(ctx context.Context, applicationID uuid.UUID) model.Academics {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAcademics")
+	}
+
+	var r0 model.Academics
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) model.Academics); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		r0 = ret.Get(0).(model.Academics)
+	}
+	return r0
+}
+
+// MockApplicationRepository_GetAcademics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAcademics'
+type MockApplicationRepository_GetAcademics_Call struct {
+	*mock.Call
+}
+
+// GetAcademics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationRepository_Expecter) GetAcademics(ctx interface{}, applicationID interface{}) *MockApplicationRepository_GetAcademics_Call {
+	return &MockApplicationRepository_GetAcademics_Call{Call: _e.mock.On("GetAcademics", ctx, applicationID)}
+}
+
+func (_c *MockApplicationRepository_GetAcademics_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationRepository_GetAcademics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetAcademics_Call) Return(academics model.Academics) *MockApplicationRepository_GetAcademics_Call {
+	_c.Call.Return(academics)
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetAcademics_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) model.Academics) *MockApplicationRepository_GetAcademics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActivities provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) GetActivities(ctx context.Context, applicationID uuid.UUID) []model.Activity {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivities")
+	}
+
+	var r0 []model.Activity
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.Activity); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Activity)
+		}
+	}
+	return r0
+}
+
+// MockApplicationRepository_GetActivities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivities'
+type MockApplicationRepository_GetActivities_Call struct {
+	*mock.Call
+}
+
+// GetActivities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationRepository_Expecter) GetActivities(ctx interface{}, applicationID interface{}) *MockApplicationRepository_GetActivities_Call {
+	return &MockApplicationRepository_GetActivities_Call{Call: _e.mock.On("GetActivities", ctx, applicationID)}
+}
+
+func (_c *MockApplicationRepository_GetActivities_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationRepository_GetActivities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetActivities_Call) Return(activitys []model.Activity) *MockApplicationRepository_GetActivities_Call {
+	_c.Call.Return(activitys)
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetActivities_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) []model.Activity) *MockApplicationRepository_GetActivities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApplication provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) GetApplication(ctx context.Context, id uuid.UUID) *model.Application {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApplication")
+	}
+
+	var r0 *model.Application
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Application); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Application)
+		}
+	}
+	return r0
+}
+
+// MockApplicationRepository_GetApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApplication'
+type MockApplicationRepository_GetApplication_Call struct {
+	*mock.Call
+}
+
+// GetApplication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockApplicationRepository_Expecter) GetApplication(ctx interface{}, id interface{}) *MockApplicationRepository_GetApplication_Call {
+	return &MockApplicationRepository_GetApplication_Call{Call: _e.mock.On("GetApplication", ctx, id)}
+}
+
+func (_c *MockApplicationRepository_GetApplication_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockApplicationRepository_GetApplication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetApplication_Call) Return(application *model.Application) *MockApplicationRepository_GetApplication_Call {
+	_c.Call.Return(application)
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetApplication_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) *model.Application) *MockApplicationRepository_GetApplication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEssays provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) GetEssays(ctx context.Context, applicationID uuid.UUID) []model.Essay {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEssays")
+	}
+
+	var r0 []model.Essay
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.Essay); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Essay)
+		}
+	}
+	return r0
+}
+
+// MockApplicationRepository_GetEssays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEssays'
+type MockApplicationRepository_GetEssays_Call struct {
+	*mock.Call
+}
+
+// GetEssays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationRepository_Expecter) GetEssays(ctx interface{}, applicationID interface{}) *MockApplicationRepository_GetEssays_Call {
+	return &MockApplicationRepository_GetEssays_Call{Call: _e.mock.On("GetEssays", ctx, applicationID)}
+}
+
+func (_c *MockApplicationRepository_GetEssays_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationRepository_GetEssays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetEssays_Call) Return(essays []model.Essay) *MockApplicationRepository_GetEssays_Call {
+	_c.Call.Return(essays)
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetEssays_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) []model.Essay) *MockApplicationRepository_GetEssays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHonors provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) GetHonors(ctx context.Context, applicationID uuid.UUID) []model.Honor {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHonors")
+	}
+
+	var r0 []model.Honor
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.Honor); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Honor)
+		}
+	}
+	return r0
+}
+
+// MockApplicationRepository_GetHonors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHonors'
+type MockApplicationRepository_GetHonors_Call struct {
+	*mock.Call
+}
+
+// GetHonors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationRepository_Expecter) GetHonors(ctx interface{}, applicationID interface{}) *MockApplicationRepository_GetHonors_Call {
+	return &MockApplicationRepository_GetHonors_Call{Call: _e.mock.On("GetHonors", ctx, applicationID)}
+}
+
+func (_c *MockApplicationRepository_GetHonors_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationRepository_GetHonors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetHonors_Call) Return(honors []model.Honor) *MockApplicationRepository_GetHonors_Call {
+	_c.Call.Return(honors)
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetHonors_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) []model.Honor) *MockApplicationRepository_GetHonors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSupplementalEssays provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) GetSupplementalEssays(ctx context.Context, applicationID uuid.UUID) []model.SupplementalEssay {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSupplementalEssays")
+	}
+
+	var r0 []model.SupplementalEssay
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.SupplementalEssay); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SupplementalEssay)
+		}
+	}
+	return r0
+}
+
+// MockApplicationRepository_GetSupplementalEssays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSupplementalEssays'
+type MockApplicationRepository_GetSupplementalEssays_Call struct {
+	*mock.Call
+}
+
+// GetSupplementalEssays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationRepository_Expecter) GetSupplementalEssays(ctx interface{}, applicationID interface{}) *MockApplicationRepository_GetSupplementalEssays_Call {
+	return &MockApplicationRepository_GetSupplementalEssays_Call{Call: _e.mock.On("GetSupplementalEssays", ctx, applicationID)}
+}
+
+func (_c *MockApplicationRepository_GetSupplementalEssays_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationRepository_GetSupplementalEssays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetSupplementalEssays_Call) Return(supplementalEssays []model.SupplementalEssay) *MockApplicationRepository_GetSupplementalEssays_Call {
+	_c.Call.Return(supplementalEssays)
+	return _c
+}
+
+func (_c *MockApplicationRepository_GetSupplementalEssays_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) []model.SupplementalEssay) *MockApplicationRepository_GetSupplementalEssays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutAcademics provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) PutAcademics(ctx context.Context, applicationID uuid.UUID, academics model.Academics) {
+	_mock.Called(ctx, applicationID, academics)
+	return
+}
+
+// MockApplicationRepository_PutAcademics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutAcademics'
+type MockApplicationRepository_PutAcademics_Call struct {
+	*mock.Call
+}
+
+// PutAcademics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - academics model.Academics
+func (_e *MockApplicationRepository_Expecter) PutAcademics(ctx interface{}, applicationID interface{}, academics interface{}) *MockApplicationRepository_PutAcademics_Call {
+	return &MockApplicationRepository_PutAcademics_Call{Call: _e.mock.On("PutAcademics", ctx, applicationID, academics)}
+}
+
+func (_c *MockApplicationRepository_PutAcademics_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, academics model.Academics)) *MockApplicationRepository_PutAcademics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 model.Academics
+		if args[2] != nil {
+			arg2 = args[2].(model.Academics)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutAcademics_Call) Return() *MockApplicationRepository_PutAcademics_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutAcademics_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, academics model.Academics)) *MockApplicationRepository_PutAcademics_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PutActivities provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) PutActivities(ctx context.Context, applicationID uuid.UUID, activities []model.Activity) {
+	_mock.Called(ctx, applicationID, activities)
+	return
+}
+
+// MockApplicationRepository_PutActivities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutActivities'
+type MockApplicationRepository_PutActivities_Call struct {
+	*mock.Call
+}
+
+// PutActivities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - activities []model.Activity
+func (_e *MockApplicationRepository_Expecter) PutActivities(ctx interface{}, applicationID interface{}, activities interface{}) *MockApplicationRepository_PutActivities_Call {
+	return &MockApplicationRepository_PutActivities_Call{Call: _e.mock.On("PutActivities", ctx, applicationID, activities)}
+}
+
+func (_c *MockApplicationRepository_PutActivities_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, activities []model.Activity)) *MockApplicationRepository_PutActivities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []model.Activity
+		if args[2] != nil {
+			arg2 = args[2].([]model.Activity)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutActivities_Call) Return() *MockApplicationRepository_PutActivities_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutActivities_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, activities []model.Activity)) *MockApplicationRepository_PutActivities_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PutEssays provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) PutEssays(ctx context.Context, applicationID uuid.UUID, essays []model.Essay) {
+	_mock.Called(ctx, applicationID, essays)
+	return
+}
+
+// MockApplicationRepository_PutEssays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutEssays'
+type MockApplicationRepository_PutEssays_Call struct {
+	*mock.Call
+}
+
+// PutEssays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - essays []model.Essay
+func (_e *MockApplicationRepository_Expecter) PutEssays(ctx interface{}, applicationID interface{}, essays interface{}) *MockApplicationRepository_PutEssays_Call {
+	return &MockApplicationRepository_PutEssays_Call{Call: _e.mock.On("PutEssays", ctx, applicationID, essays)}
+}
+
+func (_c *MockApplicationRepository_PutEssays_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, essays []model.Essay)) *MockApplicationRepository_PutEssays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []model.Essay
+		if args[2] != nil {
+			arg2 = args[2].([]model.Essay)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutEssays_Call) Return() *MockApplicationRepository_PutEssays_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutEssays_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, essays []model.Essay)) *MockApplicationRepository_PutEssays_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PutHonors provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) PutHonors(ctx context.Context, applicationID uuid.UUID, honors []model.Honor) {
+	_mock.Called(ctx, applicationID, honors)
+	return
+}
+
+// MockApplicationRepository_PutHonors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutHonors'
+type MockApplicationRepository_PutHonors_Call struct {
+	*mock.Call
+}
+
+// PutHonors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - honors []model.Honor
+func (_e *MockApplicationRepository_Expecter) PutHonors(ctx interface{}, applicationID interface{}, honors interface{}) *MockApplicationRepository_PutHonors_Call {
+	return &MockApplicationRepository_PutHonors_Call{Call: _e.mock.On("PutHonors", ctx, applicationID, honors)}
+}
+
+func (_c *MockApplicationRepository_PutHonors_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, honors []model.Honor)) *MockApplicationRepository_PutHonors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []model.Honor
+		if args[2] != nil {
+			arg2 = args[2].([]model.Honor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutHonors_Call) Return() *MockApplicationRepository_PutHonors_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutHonors_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, honors []model.Honor)) *MockApplicationRepository_PutHonors_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PutSupplementalEssays provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) PutSupplementalEssays(ctx context.Context, applicationID uuid.UUID, essays []model.SupplementalEssay) {
+	_mock.Called(ctx, applicationID, essays)
+	return
+}
+
+// MockApplicationRepository_PutSupplementalEssays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutSupplementalEssays'
+type MockApplicationRepository_PutSupplementalEssays_Call struct {
+	*mock.Call
+}
+
+// PutSupplementalEssays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - essays []model.SupplementalEssay
+func (_e *MockApplicationRepository_Expecter) PutSupplementalEssays(ctx interface{}, applicationID interface{}, essays interface{}) *MockApplicationRepository_PutSupplementalEssays_Call {
+	return &MockApplicationRepository_PutSupplementalEssays_Call{Call: _e.mock.On("PutSupplementalEssays", ctx, applicationID, essays)}
+}
+
+func (_c *MockApplicationRepository_PutSupplementalEssays_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, essays []model.SupplementalEssay)) *MockApplicationRepository_PutSupplementalEssays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []model.SupplementalEssay
+		if args[2] != nil {
+			arg2 = args[2].([]model.SupplementalEssay)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutSupplementalEssays_Call) Return() *MockApplicationRepository_PutSupplementalEssays_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_PutSupplementalEssays_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, essays []model.SupplementalEssay)) *MockApplicationRepository_PutSupplementalEssays_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RemoveApplication provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) RemoveApplication(ctx context.Context, id uuid.UUID) {
+	_mock.Called(ctx, id)
+	return
+}
+
+// MockApplicationRepository_RemoveApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveApplication'
+type MockApplicationRepository_RemoveApplication_Call struct {
+	*mock.Call
+}
+
+// RemoveApplication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockApplicationRepository_Expecter) RemoveApplication(ctx interface{}, id interface{}) *MockApplicationRepository_RemoveApplication_Call {
+	return &MockApplicationRepository_RemoveApplication_Call{Call: _e.mock.On("RemoveApplication", ctx, id)}
+}
+
+func (_c *MockApplicationRepository_RemoveApplication_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockApplicationRepository_RemoveApplication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_RemoveApplication_Call) Return() *MockApplicationRepository_RemoveApplication_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_RemoveApplication_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID)) *MockApplicationRepository_RemoveApplication_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateApplicationName provides a mock function for the type MockApplicationRepository
+func (_mock *MockApplicationRepository) UpdateApplicationName(ctx context.Context, applicationID uuid.UUID, name string) {
+	_mock.Called(ctx, applicationID, name)
+	return
+}
+
+// MockApplicationRepository_UpdateApplicationName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateApplicationName'
+type MockApplicationRepository_UpdateApplicationName_Call struct {
+	*mock.Call
+}
+
+// UpdateApplicationName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - name string
+func (_e *MockApplicationRepository_Expecter) UpdateApplicationName(ctx interface{}, applicationID interface{}, name interface{}) *MockApplicationRepository_UpdateApplicationName_Call {
+	return &MockApplicationRepository_UpdateApplicationName_Call{Call: _e.mock.On("UpdateApplicationName", ctx, applicationID, name)}
+}
+
+func (_c *MockApplicationRepository_UpdateApplicationName_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, name string)) *MockApplicationRepository_UpdateApplicationName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationRepository_UpdateApplicationName_Call) Return() *MockApplicationRepository_UpdateApplicationName_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationRepository_UpdateApplicationName_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, name string)) *MockApplicationRepository_UpdateApplicationName_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockApplicationEvaluationRepository creates a new instance of MockApplicationEvaluationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockApplicationEvaluationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockApplicationEvaluationRepository {
+	mock := &MockApplicationEvaluationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockApplicationEvaluationRepository is an autogenerated mock type for the ApplicationEvaluationRepository type
+type MockApplicationEvaluationRepository struct {
+	mock.Mock
+}
+
+type MockApplicationEvaluationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockApplicationEvaluationRepository) EXPECT() *MockApplicationEvaluationRepository_Expecter {
+	return &MockApplicationEvaluationRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateApplicationEvaluation provides a mock function for the type MockApplicationEvaluationRepository
+func (_mock *MockApplicationEvaluationRepository) CreateApplicationEvaluation(ctx context.Context, evaluation model.ApplicationEvaluation) {
+	_mock.Called(ctx, evaluation)
+	return
+}
+
+// MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApplicationEvaluation'
+type MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call struct {
+	*mock.Call
+}
+
+// CreateApplicationEvaluation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - evaluation model.ApplicationEvaluation
+func (_e *MockApplicationEvaluationRepository_Expecter) CreateApplicationEvaluation(ctx interface{}, evaluation interface{}) *MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call {
+	return &MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call{Call: _e.mock.On("CreateApplicationEvaluation", ctx, evaluation)}
+}
+
+func (_c *MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call) Run(run func(ctx context.Context, evaluation model.ApplicationEvaluation)) *MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.ApplicationEvaluation
+		if args[1] != nil {
+			arg1 = args[1].(model.ApplicationEvaluation)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call) Return() *MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call) RunAndReturn(run func(ctx context.Context, evaluation model.ApplicationEvaluation)) *MockApplicationEvaluationRepository_CreateApplicationEvaluation_Call {
+	_c.Run(run)
+	return _c
+}
+
+// FindApplicationEvaluations provides a mock function for the type MockApplicationEvaluationRepository
+func (_mock *MockApplicationEvaluationRepository) FindApplicationEvaluations(ctx context.Context, applicationID uuid.UUID) []model.ApplicationEvaluation {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindApplicationEvaluations")
+	}
+
+	var r0 []model.ApplicationEvaluation
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.ApplicationEvaluation); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ApplicationEvaluation)
+		}
+	}
+	return r0
+}
+
+// MockApplicationEvaluationRepository_FindApplicationEvaluations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindApplicationEvaluations'
+type MockApplicationEvaluationRepository_FindApplicationEvaluations_Call struct {
+	*mock.Call
+}
+
+// FindApplicationEvaluations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationEvaluationRepository_Expecter) FindApplicationEvaluations(ctx interface{}, applicationID interface{}) *MockApplicationEvaluationRepository_FindApplicationEvaluations_Call {
+	return &MockApplicationEvaluationRepository_FindApplicationEvaluations_Call{Call: _e.mock.On("FindApplicationEvaluations", ctx, applicationID)}
+}
+
+func (_c *MockApplicationEvaluationRepository_FindApplicationEvaluations_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationEvaluationRepository_FindApplicationEvaluations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_FindApplicationEvaluations_Call) Return(applicationEvaluations []model.ApplicationEvaluation) *MockApplicationEvaluationRepository_FindApplicationEvaluations_Call {
+	_c.Call.Return(applicationEvaluations)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_FindApplicationEvaluations_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) []model.ApplicationEvaluation) *MockApplicationEvaluationRepository_FindApplicationEvaluations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApplicationEvaluation provides a mock function for the type MockApplicationEvaluationRepository
+func (_mock *MockApplicationEvaluationRepository) GetApplicationEvaluation(ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluation {
+	ret := _mock.Called(ctx, applicationID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApplicationEvaluation")
+	}
+
+	var r0 *model.ApplicationEvaluation
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.ApplicationEvaluation); ok {
+		r0 = returnFunc(ctx, applicationID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApplicationEvaluation)
+		}
+	}
+	return r0
+}
+
+// MockApplicationEvaluationRepository_GetApplicationEvaluation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApplicationEvaluation'
+type MockApplicationEvaluationRepository_GetApplicationEvaluation_Call struct {
+	*mock.Call
+}
+
+// GetApplicationEvaluation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockApplicationEvaluationRepository_Expecter) GetApplicationEvaluation(ctx interface{}, applicationID interface{}, id interface{}) *MockApplicationEvaluationRepository_GetApplicationEvaluation_Call {
+	return &MockApplicationEvaluationRepository_GetApplicationEvaluation_Call{Call: _e.mock.On("GetApplicationEvaluation", ctx, applicationID, id)}
+}
+
+func (_c *MockApplicationEvaluationRepository_GetApplicationEvaluation_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, id uuid.UUID)) *MockApplicationEvaluationRepository_GetApplicationEvaluation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_GetApplicationEvaluation_Call) Return(applicationEvaluation *model.ApplicationEvaluation) *MockApplicationEvaluationRepository_GetApplicationEvaluation_Call {
+	_c.Call.Return(applicationEvaluation)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_GetApplicationEvaluation_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluation) *MockApplicationEvaluationRepository_GetApplicationEvaluation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestApplicationEvaluation provides a mock function for the type MockApplicationEvaluationRepository
+func (_mock *MockApplicationEvaluationRepository) GetLatestApplicationEvaluation(ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluation {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestApplicationEvaluation")
+	}
+
+	var r0 *model.ApplicationEvaluation
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.ApplicationEvaluation); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApplicationEvaluation)
+		}
+	}
+	return r0
+}
+
+// MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestApplicationEvaluation'
+type MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call struct {
+	*mock.Call
+}
+
+// GetLatestApplicationEvaluation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationEvaluationRepository_Expecter) GetLatestApplicationEvaluation(ctx interface{}, applicationID interface{}) *MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call {
+	return &MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call{Call: _e.mock.On("GetLatestApplicationEvaluation", ctx, applicationID)}
+}
+
+func (_c *MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call) Return(applicationEvaluation *model.ApplicationEvaluation) *MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call {
+	_c.Call.Return(applicationEvaluation)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluation) *MockApplicationEvaluationRepository_GetLatestApplicationEvaluation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockApplicationEvaluationJobRepository creates a new instance of MockApplicationEvaluationJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockApplicationEvaluationJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockApplicationEvaluationJobRepository {
+	mock := &MockApplicationEvaluationJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockApplicationEvaluationJobRepository is an autogenerated mock type for the ApplicationEvaluationJobRepository type
+type MockApplicationEvaluationJobRepository struct {
+	mock.Mock
+}
+
+type MockApplicationEvaluationJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockApplicationEvaluationJobRepository) EXPECT() *MockApplicationEvaluationJobRepository_Expecter {
+	return &MockApplicationEvaluationJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimApplicationEvaluationJobs provides a mock function for the type MockApplicationEvaluationJobRepository
+func (_mock *MockApplicationEvaluationJobRepository) ClaimApplicationEvaluationJobs(ctx context.Context, limit int, leaseExpiredBefore time.Time, maxAttempts int) []model.ApplicationEvaluationJob {
+	ret := _mock.Called(ctx, limit, leaseExpiredBefore, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimApplicationEvaluationJobs")
+	}
+
+	var r0 []model.ApplicationEvaluationJob
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time, int) []model.ApplicationEvaluationJob); ok {
+		r0 = returnFunc(ctx, limit, leaseExpiredBefore, maxAttempts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ApplicationEvaluationJob)
+		}
+	}
+	return r0
+}
+
+// MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimApplicationEvaluationJobs'
+type MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call struct {
+	*mock.Call
+}
+
+// ClaimApplicationEvaluationJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - leaseExpiredBefore time.Time
+//   - maxAttempts int
+func (_e *MockApplicationEvaluationJobRepository_Expecter) ClaimApplicationEvaluationJobs(ctx interface{}, limit interface{}, leaseExpiredBefore interface{}, maxAttempts interface{}) *MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call {
+	return &MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call{Call: _e.mock.On("ClaimApplicationEvaluationJobs", ctx, limit, leaseExpiredBefore, maxAttempts)}
+}
+
+func (_c *MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call) Run(run func(ctx context.Context, limit int, leaseExpiredBefore time.Time, maxAttempts int)) *MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call) Return(applicationEvaluationJobs []model.ApplicationEvaluationJob) *MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call {
+	_c.Call.Return(applicationEvaluationJobs)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call) RunAndReturn(run func(ctx context.Context, limit int, leaseExpiredBefore time.Time, maxAttempts int) []model.ApplicationEvaluationJob) *MockApplicationEvaluationJobRepository_ClaimApplicationEvaluationJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateApplicationEvaluationJob provides a mock function for the type MockApplicationEvaluationJobRepository
+func (_mock *MockApplicationEvaluationJobRepository) CreateApplicationEvaluationJob(ctx context.Context, job model.ApplicationEvaluationJob) bool {
+	ret := _mock.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateApplicationEvaluationJob")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ApplicationEvaluationJob) bool); ok {
+		r0 = returnFunc(ctx, job)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApplicationEvaluationJob'
+type MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call struct {
+	*mock.Call
+}
+
+// CreateApplicationEvaluationJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job model.ApplicationEvaluationJob
+func (_e *MockApplicationEvaluationJobRepository_Expecter) CreateApplicationEvaluationJob(ctx interface{}, job interface{}) *MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call {
+	return &MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call{Call: _e.mock.On("CreateApplicationEvaluationJob", ctx, job)}
+}
+
+func (_c *MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call) Run(run func(ctx context.Context, job model.ApplicationEvaluationJob)) *MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.ApplicationEvaluationJob
+		if args[1] != nil {
+			arg1 = args[1].(model.ApplicationEvaluationJob)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call) Return(b bool) *MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call) RunAndReturn(run func(ctx context.Context, job model.ApplicationEvaluationJob) bool) *MockApplicationEvaluationJobRepository_CreateApplicationEvaluationJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailAbandonedApplicationEvaluationJobs provides a mock function for the type MockApplicationEvaluationJobRepository
+func (_mock *MockApplicationEvaluationJobRepository) FailAbandonedApplicationEvaluationJobs(ctx context.Context, leaseExpiredBefore time.Time, maxAttempts int) int {
+	ret := _mock.Called(ctx, leaseExpiredBefore, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailAbandonedApplicationEvaluationJobs")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) int); ok {
+		r0 = returnFunc(ctx, leaseExpiredBefore, maxAttempts)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailAbandonedApplicationEvaluationJobs'
+type MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call struct {
+	*mock.Call
+}
+
+// FailAbandonedApplicationEvaluationJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - leaseExpiredBefore time.Time
+//   - maxAttempts int
+func (_e *MockApplicationEvaluationJobRepository_Expecter) FailAbandonedApplicationEvaluationJobs(ctx interface{}, leaseExpiredBefore interface{}, maxAttempts interface{}) *MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call {
+	return &MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call{Call: _e.mock.On("FailAbandonedApplicationEvaluationJobs", ctx, leaseExpiredBefore, maxAttempts)}
+}
+
+func (_c *MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call) Run(run func(ctx context.Context, leaseExpiredBefore time.Time, maxAttempts int)) *MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call) Return(n int) *MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call) RunAndReturn(run func(ctx context.Context, leaseExpiredBefore time.Time, maxAttempts int) int) *MockApplicationEvaluationJobRepository_FailAbandonedApplicationEvaluationJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishApplicationEvaluationJob provides a mock function for the type MockApplicationEvaluationJobRepository
+func (_mock *MockApplicationEvaluationJobRepository) FinishApplicationEvaluationJob(ctx context.Context, job model.ApplicationEvaluationJob) {
+	_mock.Called(ctx, job)
+	return
+}
+
+// MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishApplicationEvaluationJob'
+type MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call struct {
+	*mock.Call
+}
+
+// FinishApplicationEvaluationJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job model.ApplicationEvaluationJob
+func (_e *MockApplicationEvaluationJobRepository_Expecter) FinishApplicationEvaluationJob(ctx interface{}, job interface{}) *MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call {
+	return &MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call{Call: _e.mock.On("FinishApplicationEvaluationJob", ctx, job)}
+}
+
+func (_c *MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call) Run(run func(ctx context.Context, job model.ApplicationEvaluationJob)) *MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.ApplicationEvaluationJob
+		if args[1] != nil {
+			arg1 = args[1].(model.ApplicationEvaluationJob)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call) Return() *MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call) RunAndReturn(run func(ctx context.Context, job model.ApplicationEvaluationJob)) *MockApplicationEvaluationJobRepository_FinishApplicationEvaluationJob_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetApplicationEvaluationJob provides a mock function for the type MockApplicationEvaluationJobRepository
+func (_mock *MockApplicationEvaluationJobRepository) GetApplicationEvaluationJob(ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluationJob {
+	ret := _mock.Called(ctx, applicationID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApplicationEvaluationJob")
+	}
+
+	var r0 *model.ApplicationEvaluationJob
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.ApplicationEvaluationJob); ok {
+		r0 = returnFunc(ctx, applicationID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApplicationEvaluationJob)
+		}
+	}
+	return r0
+}
+
+// MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApplicationEvaluationJob'
+type MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call struct {
+	*mock.Call
+}
+
+// GetApplicationEvaluationJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockApplicationEvaluationJobRepository_Expecter) GetApplicationEvaluationJob(ctx interface{}, applicationID interface{}, id interface{}) *MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call {
+	return &MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call{Call: _e.mock.On("GetApplicationEvaluationJob", ctx, applicationID, id)}
+}
+
+func (_c *MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call) Run(run func(ctx context.Context, applicationID uuid.UUID, id uuid.UUID)) *MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call) Return(applicationEvaluationJob *model.ApplicationEvaluationJob) *MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call {
+	_c.Call.Return(applicationEvaluationJob)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID, id uuid.UUID) *model.ApplicationEvaluationJob) *MockApplicationEvaluationJobRepository_GetApplicationEvaluationJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnfinishedApplicationEvaluationJob provides a mock function for the type MockApplicationEvaluationJobRepository
+func (_mock *MockApplicationEvaluationJobRepository) GetUnfinishedApplicationEvaluationJob(ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluationJob {
+	ret := _mock.Called(ctx, applicationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnfinishedApplicationEvaluationJob")
+	}
+
+	var r0 *model.ApplicationEvaluationJob
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.ApplicationEvaluationJob); ok {
+		r0 = returnFunc(ctx, applicationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApplicationEvaluationJob)
+		}
+	}
+	return r0
+}
+
+// MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnfinishedApplicationEvaluationJob'
+type MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call struct {
+	*mock.Call
+}
+
+// GetUnfinishedApplicationEvaluationJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - applicationID uuid.UUID
+func (_e *MockApplicationEvaluationJobRepository_Expecter) GetUnfinishedApplicationEvaluationJob(ctx interface{}, applicationID interface{}) *MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call {
+	return &MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call{Call: _e.mock.On("GetUnfinishedApplicationEvaluationJob", ctx, applicationID)}
+}
+
+func (_c *MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call) Run(run func(ctx context.Context, applicationID uuid.UUID)) *MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call) Return(applicationEvaluationJob *model.ApplicationEvaluationJob) *MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call {
+	_c.Call.Return(applicationEvaluationJob)
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call) RunAndReturn(run func(ctx context.Context, applicationID uuid.UUID) *model.ApplicationEvaluationJob) *MockApplicationEvaluationJobRepository_GetUnfinishedApplicationEvaluationJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryApplicationEvaluationJob provides a mock function for the type MockApplicationEvaluationJobRepository
+func (_mock *MockApplicationEvaluationJobRepository) RetryApplicationEvaluationJob(ctx context.Context, job model.ApplicationEvaluationJob, notBefore time.Time) {
+	_mock.Called(ctx, job, notBefore)
+	return
+}
+
+// MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryApplicationEvaluationJob'
+type MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call struct {
+	*mock.Call
+}
+
+// RetryApplicationEvaluationJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job model.ApplicationEvaluationJob
+//   - notBefore time.Time
+func (_e *MockApplicationEvaluationJobRepository_Expecter) RetryApplicationEvaluationJob(ctx interface{}, job interface{}, notBefore interface{}) *MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call {
+	return &MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call{Call: _e.mock.On("RetryApplicationEvaluationJob", ctx, job, notBefore)}
+}
+
+func (_c *MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call) Run(run func(ctx context.Context, job model.ApplicationEvaluationJob, notBefore time.Time)) *MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.ApplicationEvaluationJob
+		if args[1] != nil {
+			arg1 = args[1].(model.ApplicationEvaluationJob)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call) Return() *MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call) RunAndReturn(run func(ctx context.Context, job model.ApplicationEvaluationJob, notBefore time.Time)) *MockApplicationEvaluationJobRepository_RetryApplicationEvaluationJob_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockEssayChatRepository creates a new instance of MockEssayChatRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEssayChatRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEssayChatRepository {
+	mock := &MockEssayChatRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEssayChatRepository is an autogenerated mock type for the EssayChatRepository type
+type MockEssayChatRepository struct {
+	mock.Mock
+}
+
+type MockEssayChatRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEssayChatRepository) EXPECT() *MockEssayChatRepository_Expecter {
+	return &MockEssayChatRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateEssayChatMessages provides a mock function for the type MockEssayChatRepository
+func (_mock *MockEssayChatRepository) CreateEssayChatMessages(ctx context.Context, messages []model.EssayChatMessage) {
+	_mock.Called(ctx, messages)
+	return
+}
+
+// MockEssayChatRepository_CreateEssayChatMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEssayChatMessages'
+type MockEssayChatRepository_CreateEssayChatMessages_Call struct {
+	*mock.Call
+}
+
+// CreateEssayChatMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messages []model.EssayChatMessage
+func (_e *MockEssayChatRepository_Expecter) CreateEssayChatMessages(ctx interface{}, messages interface{}) *MockEssayChatRepository_CreateEssayChatMessages_Call {
+	return &MockEssayChatRepository_CreateEssayChatMessages_Call{Call: _e.mock.On("CreateEssayChatMessages", ctx, messages)}
+}
+
+func (_c *MockEssayChatRepository_CreateEssayChatMessages_Call) Run(run func(ctx context.Context, messages []model.EssayChatMessage)) *MockEssayChatRepository_CreateEssayChatMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []model.EssayChatMessage
+		if args[1] != nil {
+			arg1 = args[1].([]model.EssayChatMessage)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEssayChatRepository_CreateEssayChatMessages_Call) Return() *MockEssayChatRepository_CreateEssayChatMessages_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockEssayChatRepository_CreateEssayChatMessages_Call) RunAndReturn(run func(ctx context.Context, messages []model.EssayChatMessage)) *MockEssayChatRepository_CreateEssayChatMessages_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetEssayChatMessages provides a mock function for the type MockEssayChatRepository
+func (_mock *MockEssayChatRepository) GetEssayChatMessages(ctx context.Context, essayID uuid.UUID) []model.EssayChatMessage {
+	ret := _mock.Called(ctx, essayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEssayChatMessages")
+	}
+
+	var r0 []model.EssayChatMessage
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.EssayChatMessage); ok {
+		r0 = returnFunc(ctx, essayID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.EssayChatMessage)
+		}
+	}
+	return r0
+}
+
+// MockEssayChatRepository_GetEssayChatMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEssayChatMessages'
+type MockEssayChatRepository_GetEssayChatMessages_Call struct {
+	*mock.Call
+}
+
+// GetEssayChatMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - essayID uuid.UUID
+func (_e *MockEssayChatRepository_Expecter) GetEssayChatMessages(ctx interface{}, essayID interface{}) *MockEssayChatRepository_GetEssayChatMessages_Call {
+	return &MockEssayChatRepository_GetEssayChatMessages_Call{Call: _e.mock.On("GetEssayChatMessages", ctx, essayID)}
+}
+
+func (_c *MockEssayChatRepository_GetEssayChatMessages_Call) Run(run func(ctx context.Context, essayID uuid.UUID)) *MockEssayChatRepository_GetEssayChatMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEssayChatRepository_GetEssayChatMessages_Call) Return(essayChatMessages []model.EssayChatMessage) *MockEssayChatRepository_GetEssayChatMessages_Call {
+	_c.Call.Return(essayChatMessages)
+	return _c
+}
+
+func (_c *MockEssayChatRepository_GetEssayChatMessages_Call) RunAndReturn(run func(ctx context.Context, essayID uuid.UUID) []model.EssayChatMessage) *MockEssayChatRepository_GetEssayChatMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveEssayChatMessages provides a mock function for the type MockEssayChatRepository
+func (_mock *MockEssayChatRepository) RemoveEssayChatMessages(ctx context.Context, essayID uuid.UUID) {
+	_mock.Called(ctx, essayID)
+	return
+}
+
+// MockEssayChatRepository_RemoveEssayChatMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveEssayChatMessages'
+type MockEssayChatRepository_RemoveEssayChatMessages_Call struct {
+	*mock.Call
+}
+
+// RemoveEssayChatMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - essayID uuid.UUID
+func (_e *MockEssayChatRepository_Expecter) RemoveEssayChatMessages(ctx interface{}, essayID interface{}) *MockEssayChatRepository_RemoveEssayChatMessages_Call {
+	return &MockEssayChatRepository_RemoveEssayChatMessages_Call{Call: _e.mock.On("RemoveEssayChatMessages", ctx, essayID)}
+}
+
+func (_c *MockEssayChatRepository_RemoveEssayChatMessages_Call) Run(run func(ctx context.Context, essayID uuid.UUID)) *MockEssayChatRepository_RemoveEssayChatMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEssayChatRepository_RemoveEssayChatMessages_Call) Return() *MockEssayChatRepository_RemoveEssayChatMessages_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockEssayChatRepository_RemoveEssayChatMessages_Call) RunAndReturn(run func(ctx context.Context, essayID uuid.UUID)) *MockEssayChatRepository_RemoveEssayChatMessages_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockSubscriptionRepository creates a new instance of MockSubscriptionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionRepository {
+	mock := &MockSubscriptionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSubscriptionRepository is an autogenerated mock type for the SubscriptionRepository type
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+type MockSubscriptionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepository_Expecter {
+	return &MockSubscriptionRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetSubscription provides a mock function for the type MockSubscriptionRepository
+func (_mock *MockSubscriptionRepository) GetSubscription(ctx context.Context, userID uuid.UUID) *domain.Subscription {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscription")
+	}
+
+	var r0 *domain.Subscription
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Subscription); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Subscription)
+		}
+	}
+	return r0
+}
+
+// MockSubscriptionRepository_GetSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscription'
+type MockSubscriptionRepository_GetSubscription_Call struct {
+	*mock.Call
+}
+
+// GetSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockSubscriptionRepository_Expecter) GetSubscription(ctx interface{}, userID interface{}) *MockSubscriptionRepository_GetSubscription_Call {
+	return &MockSubscriptionRepository_GetSubscription_Call{Call: _e.mock.On("GetSubscription", ctx, userID)}
+}
+
+func (_c *MockSubscriptionRepository_GetSubscription_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSubscriptionRepository_GetSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetSubscription_Call) Return(subscription *domain.Subscription) *MockSubscriptionRepository_GetSubscription_Call {
+	_c.Call.Return(subscription)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_GetSubscription_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) *domain.Subscription) *MockSubscriptionRepository_GetSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSubscription provides a mock function for the type MockSubscriptionRepository
+func (_mock *MockSubscriptionRepository) SetSubscription(ctx context.Context, userID uuid.UUID, subscription domain.Subscription, ttl time.Duration) {
+	_mock.Called(ctx, userID, subscription, ttl)
+	return
+}
+
+// MockSubscriptionRepository_SetSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSubscription'
+type MockSubscriptionRepository_SetSubscription_Call struct {
+	*mock.Call
+}
+
+// SetSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - subscription domain.Subscription
+//   - ttl time.Duration
+func (_e *MockSubscriptionRepository_Expecter) SetSubscription(ctx interface{}, userID interface{}, subscription interface{}, ttl interface{}) *MockSubscriptionRepository_SetSubscription_Call {
+	return &MockSubscriptionRepository_SetSubscription_Call{Call: _e.mock.On("SetSubscription", ctx, userID, subscription, ttl)}
+}
+
+func (_c *MockSubscriptionRepository_SetSubscription_Call) Run(run func(ctx context.Context, userID uuid.UUID, subscription domain.Subscription, ttl time.Duration)) *MockSubscriptionRepository_SetSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 domain.Subscription
+		if args[2] != nil {
+			arg2 = args[2].(domain.Subscription)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_SetSubscription_Call) Return() *MockSubscriptionRepository_SetSubscription_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_SetSubscription_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, subscription domain.Subscription, ttl time.Duration)) *MockSubscriptionRepository_SetSubscription_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockUsageRepository creates a new instance of MockUsageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsageRepository {
+	mock := &MockUsageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUsageRepository is an autogenerated mock type for the UsageRepository type
+type MockUsageRepository struct {
+	mock.Mock
+}
+
+type MockUsageRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsageRepository) EXPECT() *MockUsageRepository_Expecter {
+	return &MockUsageRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountUsage provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) CountUsage(ctx context.Context, userID uuid.UUID, since time.Time) map[domain.Feature]int {
+	ret := _mock.Called(ctx, userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsage")
+	}
+
+	var r0 map[domain.Feature]int
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) map[domain.Feature]int); ok {
+		r0 = returnFunc(ctx, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.Feature]int)
+		}
+	}
+	return r0
+}
+
+// MockUsageRepository_CountUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsage'
+type MockUsageRepository_CountUsage_Call struct {
+	*mock.Call
+}
+
+// CountUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - since time.Time
+func (_e *MockUsageRepository_Expecter) CountUsage(ctx interface{}, userID interface{}, since interface{}) *MockUsageRepository_CountUsage_Call {
+	return &MockUsageRepository_CountUsage_Call{Call: _e.mock.On("CountUsage", ctx, userID, since)}
+}
+
+func (_c *MockUsageRepository_CountUsage_Call) Run(run func(ctx context.Context, userID uuid.UUID, since time.Time)) *MockUsageRepository_CountUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_CountUsage_Call) Return(featureToInt map[domain.Feature]int) *MockUsageRepository_CountUsage_Call {
+	_c.Call.Return(featureToInt)
+	return _c
+}
+
+func (_c *MockUsageRepository_CountUsage_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, since time.Time) map[domain.Feature]int) *MockUsageRepository_CountUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUsageRecordWithinQuota provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) CreateUsageRecordWithinQuota(ctx context.Context, userID uuid.UUID, feature domain.Feature, createdAt time.Time, since time.Time, quota int) *uuid.UUID {
+	ret := _mock.Called(ctx, userID, feature, createdAt, since, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUsageRecordWithinQuota")
+	}
+
+	var r0 *uuid.UUID
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.Feature, time.Time, time.Time, int) *uuid.UUID); ok {
+		r0 = returnFunc(ctx, userID, feature, createdAt, since, quota)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*uuid.UUID)
+		}
+	}
+	return r0
+}
+
+// MockUsageRepository_CreateUsageRecordWithinQuota_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUsageRecordWithinQuota'
+type MockUsageRepository_CreateUsageRecordWithinQuota_Call struct {
+	*mock.Call
+}
+
+// CreateUsageRecordWithinQuota is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - feature domain.Feature
+//   - createdAt time.Time
+//   - since time.Time
+//   - quota int
+func (_e *MockUsageRepository_Expecter) CreateUsageRecordWithinQuota(ctx interface{}, userID interface{}, feature interface{}, createdAt interface{}, since interface{}, quota interface{}) *MockUsageRepository_CreateUsageRecordWithinQuota_Call {
+	return &MockUsageRepository_CreateUsageRecordWithinQuota_Call{Call: _e.mock.On("CreateUsageRecordWithinQuota", ctx, userID, feature, createdAt, since, quota)}
+}
+
+func (_c *MockUsageRepository_CreateUsageRecordWithinQuota_Call) Run(run func(ctx context.Context, userID uuid.UUID, feature domain.Feature, createdAt time.Time, since time.Time, quota int)) *MockUsageRepository_CreateUsageRecordWithinQuota_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 domain.Feature
+		if args[2] != nil {
+			arg2 = args[2].(domain.Feature)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_CreateUsageRecordWithinQuota_Call) Return(uUID *uuid.UUID) *MockUsageRepository_CreateUsageRecordWithinQuota_Call {
+	_c.Call.Return(uUID)
+	return _c
+}
+
+func (_c *MockUsageRepository_CreateUsageRecordWithinQuota_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, feature domain.Feature, createdAt time.Time, since time.Time, quota int) *uuid.UUID) *MockUsageRepository_CreateUsageRecordWithinQuota_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveUsageRecord provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) RemoveUsageRecord(ctx context.Context, id uuid.UUID) {
+	_mock.Called(ctx, id)
+	return
+}
+
+// MockUsageRepository_RemoveUsageRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUsageRecord'
+type MockUsageRepository_RemoveUsageRecord_Call struct {
+	*mock.Call
+}
+
+// RemoveUsageRecord is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockUsageRepository_Expecter) RemoveUsageRecord(ctx interface{}, id interface{}) *MockUsageRepository_RemoveUsageRecord_Call {
+	return &MockUsageRepository_RemoveUsageRecord_Call{Call: _e.mock.On("RemoveUsageRecord", ctx, id)}
+}
+
+func (_c *MockUsageRepository_RemoveUsageRecord_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUsageRepository_RemoveUsageRecord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_RemoveUsageRecord_Call) Return() *MockUsageRepository_RemoveUsageRecord_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUsageRepository_RemoveUsageRecord_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID)) *MockUsageRepository_RemoveUsageRecord_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+)
+
+// SubscriptionRepository caches the subscriptions reported by subscription-service.
+type SubscriptionRepository interface {
+	// GetSubscription returns nil if the subscription of the user isn't cached.
+	GetSubscription(ctx context.Context, userID uuid.UUID) *domain.Subscription
+	SetSubscription(ctx context.Context, userID uuid.UUID, subscription domain.Subscription, ttl time.Duration)
+}
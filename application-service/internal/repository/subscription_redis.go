@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+)
+
+const subscriptionKeyPrefix = "application_service:subscription:"
+
+type redisSubscriptionRepository struct {
+	client *redis.Client
+}
+
+func NewRedisSubscriptionRepository(client *redis.Client) SubscriptionRepository {
+	return &redisSubscriptionRepository{client: client}
+}
+
+func (r *redisSubscriptionRepository) GetSubscription(ctx context.Context, userID uuid.UUID) *domain.Subscription {
+	encodedSubscription, err := r.client.Get(ctx, r.createSubscriptionKey(userID)).Bytes()
+
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+
+		panic(err)
+	}
+
+	subscription := &domain.Subscription{}
+	if err := json.Unmarshal(encodedSubscription, subscription); err != nil {
+		panic(err)
+	}
+
+	return subscription
+}
+
+func (r *redisSubscriptionRepository) SetSubscription(
+	ctx context.Context, userID uuid.UUID, subscription domain.Subscription, ttl time.Duration) {
+	encodedSubscription, err := json.Marshal(subscription)
+	if err != nil {
+		panic(err)
+	}
+
+	err = r.client.Set(ctx, r.createSubscriptionKey(userID), encodedSubscription, ttl).Err()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (r *redisSubscriptionRepository) createSubscriptionKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s%s", subscriptionKeyPrefix, userID)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+)
+
+type UsageRepository interface {
+	// CreateUsageRecordWithinQuota records a use of the feature by the user, unless the user already used it
+	// quota times since the time, and returns the ID of the record, or nil if the quota is used up. The calls
+	// for the same user and feature are serialized, so concurrent requests can't exceed the quota together.
+	CreateUsageRecordWithinQuota(ctx context.Context, userID uuid.UUID, feature domain.Feature, createdAt time.Time,
+		since time.Time, quota int) *uuid.UUID
+	RemoveUsageRecord(ctx context.Context, id uuid.UUID)
+	// CountUsage returns how many times the user used each feature since the time. Features the user didn't use
+	// are missing from the map.
+	CountUsage(ctx context.Context, userID uuid.UUID, since time.Time) map[domain.Feature]int
+}
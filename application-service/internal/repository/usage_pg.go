@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+)
+
+type pgUsageRepository struct {
+	db *sql.DB
+}
+
+func NewPgUsageRepository(db *sql.DB) UsageRepository {
+	return &pgUsageRepository{
+		db: db,
+	}
+}
+
+func (r *pgUsageRepository) CreateUsageRecordWithinQuota(
+	ctx context.Context, userID uuid.UUID, feature domain.Feature, createdAt time.Time,
+	since time.Time, quota int) *uuid.UUID {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	defer tx.Rollback()
+
+	// The lock is held until the transaction ends, so the count below sees the records of the concurrent
+	// calls which took it first.
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`,
+		userID.String(), feature)
+	if err != nil {
+		panic(err)
+	}
+
+	id := uuid.New()
+	query := `
+		INSERT INTO usage_records (id, user_id, feature, created_at)
+		SELECT $1, $2, $3, $4
+		WHERE (SELECT COUNT(*)
+		       FROM usage_records
+		       WHERE user_id = $2 AND feature = $3 AND created_at >= $5) < $6
+	`
+	result, err := tx.ExecContext(ctx, query, id, userID, feature, createdAt, since, quota)
+	if err != nil {
+		panic(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		panic(err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		panic(err)
+	}
+
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	return &id
+}
+
+func (r *pgUsageRepository) RemoveUsageRecord(ctx context.Context, id uuid.UUID) {
+	query := `
+		DELETE FROM usage_records
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (r *pgUsageRepository) CountUsage(
+	ctx context.Context, userID uuid.UUID, since time.Time) map[domain.Feature]int {
+	usage := make(map[domain.Feature]int)
+	query := `
+		SELECT feature, COUNT(*)
+		FROM usage_records
+		WHERE user_id = $1 AND created_at >= $2
+		GROUP BY feature
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		panic(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var feature domain.Feature
+		var count int
+
+		if err := rows.Scan(&feature, &count); err != nil {
+			panic(err)
+		}
+
+		usage[feature] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return usage
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,6 +28,8 @@ type ApplicationService interface {
 
 	GetActivities(ctx context.Context) []domain.ActivityResponse
 	PutActivities(ctx context.Context, activities []domain.UpdateActivityRequest)
+	GetAcademics(ctx context.Context) domain.AcademicsResponse
+	PutAcademics(ctx context.Context, academics domain.UpdateAcademicsRequest)
 	GetHonors(ctx context.Context) []domain.HonorResponse
 	PutHonors(ctx context.Context, honors []domain.UpdateHonorRequest)
 
@@ -166,6 +169,103 @@ func (a *applicationService) PutActivities(ctx context.Context, updateActivities
 	logger.Info("Activities put successfully")
 }
 
+func (a *applicationService) GetAcademics(ctx context.Context) domain.AcademicsResponse {
+	log.L(ctx).Info("Getting academics")
+
+	academics := a.applicationRepository.
+		GetAcademics(ctx, localcontext.GetApplication(ctx).ID)
+	academicsResponse := domain.AcademicsResponse{
+		Courses:    make([]domain.CourseResponse, len(academics.Courses)),
+		TestScores: make([]domain.TestScoreResponse, len(academics.TestScores)),
+	}
+
+	if academics.GPA != nil {
+		academicsResponse.GPA = &domain.GPAResponse{
+			Value:    academics.GPA.Value,
+			Scale:    academics.GPA.Scale,
+			Weighted: academics.GPA.Weighted,
+		}
+	}
+
+	if academics.ClassRank != nil {
+		academicsResponse.ClassRank = &domain.ClassRankResponse{
+			Rank:      academics.ClassRank.Rank,
+			ClassSize: academics.ClassRank.ClassSize,
+		}
+	}
+
+	for i, course := range academics.Courses {
+		academicsResponse.Courses[i] = domain.CourseResponse{
+			Name:   course.Name,
+			Rigor:  course.Rigor,
+			Grade:  course.Grade,
+			Result: course.Result,
+		}
+	}
+
+	for i, testScore := range academics.TestScores {
+		academicsResponse.TestScores[i] = domain.TestScoreResponse{
+			Type:  testScore.Type,
+			Score: testScore.Score,
+		}
+	}
+
+	log.L(ctx).Infof("Found %d courses and %d test scores", len(academics.Courses), len(academics.TestScores))
+	return academicsResponse
+}
+
+func (a *applicationService) PutAcademics(ctx context.Context, updateAcademicsRequest domain.UpdateAcademicsRequest) {
+	logger := log.L(ctx).WithField("courseCount", len(updateAcademicsRequest.Courses)).
+		WithField("testScoreCount", len(updateAcademicsRequest.TestScores))
+	logger.Info("Putting academics")
+
+	application := localcontext.GetApplication(ctx)
+	academics := model.Academics{
+		Courses:    make([]model.Course, len(updateAcademicsRequest.Courses)),
+		TestScores: make([]model.TestScore, len(updateAcademicsRequest.TestScores)),
+	}
+
+	if updateAcademicsRequest.GPA != nil {
+		academics.GPA = &model.GPA{
+			Value:    updateAcademicsRequest.GPA.Value,
+			Scale:    updateAcademicsRequest.GPA.Scale,
+			Weighted: updateAcademicsRequest.GPA.Weighted,
+		}
+	}
+
+	if updateAcademicsRequest.ClassRank != nil {
+		academics.ClassRank = &model.ClassRank{
+			Rank:      updateAcademicsRequest.ClassRank.Rank,
+			ClassSize: updateAcademicsRequest.ClassRank.ClassSize,
+		}
+	}
+
+	for i, updateCourseRequest := range updateAcademicsRequest.Courses {
+		academics.Courses[i] = model.Course{
+			Name:   updateCourseRequest.Name,
+			Rigor:  updateCourseRequest.Rigor,
+			Grade:  updateCourseRequest.Grade,
+			Result: updateCourseRequest.Result,
+		}
+	}
+
+	for i, updateTestScoreRequest := range updateAcademicsRequest.TestScores {
+		if !updateTestScoreRequest.Type.ValidScore(updateTestScoreRequest.Score) {
+			logger.Warnf("Invalid %s score: %v", updateTestScoreRequest.Type, updateTestScoreRequest.Score)
+			myerror.NewWithReason(myerror.RequestValidationError, fmt.Sprintf("invalid %s score: %v",
+				updateTestScoreRequest.Type, updateTestScoreRequest.Score)).Throw()
+		}
+
+		academics.TestScores[i] = model.TestScore{
+			Type:  updateTestScoreRequest.Type,
+			Score: updateTestScoreRequest.Score,
+		}
+	}
+
+	a.applicationRepository.PutAcademics(ctx, application.ID, academics)
+	logger.Info("Academics put successfully")
+}
+
 func (a *applicationService) GetHonors(ctx context.Context) []domain.HonorResponse {
 	log.L(ctx).Info("Getting honors")
 
@@ -233,9 +333,20 @@ func (a *applicationService) PutEssays(ctx context.Context, updateEssaysRequest
 	application := localcontext.GetApplication(ctx)
 	essays := make([]model.Essay, len(updateEssaysRequest))
 
+	existingEssayIDs := make(map[uuid.UUID]bool)
+	for _, essay := range a.applicationRepository.GetEssays(ctx, application.ID) {
+		existingEssayIDs[essay.ID] = true
+	}
+
 	for i, updateEssayRequest := range updateEssaysRequest {
+		essayID := uuid.New()
+		if updateEssayRequest.ID != nil && existingEssayIDs[*updateEssayRequest.ID] {
+			essayID = *updateEssayRequest.ID
+			delete(existingEssayIDs, essayID)
+		}
+
 		essays[i] = model.Essay{
-			ID:      uuid.New(),
+			ID:      essayID,
 			Type:    updateEssayRequest.Kind,
 			Content: updateEssayRequest.Content,
 		}
@@ -2,108 +2,356 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
 
 	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
 	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
 	"github.com/compendium-tech/compendium/application-service/internal/interop"
 	"github.com/compendium-tech/compendium/application-service/internal/model"
 	"github.com/compendium-tech/compendium/application-service/internal/repository"
 )
 
+// applicationEvaluationGenerationOptions make llm-service summarize applications too long for the model,
+// instead of failing the evaluation.
+var applicationEvaluationGenerationOptions = domain.LLMGenerationOptions{
+	ContextOverflowPolicy: domain.ContextOverflowSummarize,
+}
+
 type ApplicationEvaluationService interface {
+	// EvaluateCurrentApplication evaluates the current application and stores the evaluation. The latest
+	// evaluation is returned instead if neither the application nor the prompt template changed since.
 	EvaluateCurrentApplication(ctx context.Context) domain.ApplicationEvaluationResponse
+	// EvaluateCurrentApplicationStream works like EvaluateCurrentApplication, but reports
+	// the raw model output to onTextDelta as soon as it is generated. Nothing is reported
+	// if the latest evaluation is returned.
+	EvaluateCurrentApplicationStream(ctx context.Context, onTextDelta func(string)) domain.ApplicationEvaluationResponse
+	GetCurrentApplicationEvaluations(ctx context.Context) []domain.ApplicationEvaluationSummaryResponse
+	GetCurrentApplicationEvaluation(ctx context.Context, id uuid.UUID) domain.ApplicationEvaluationResponse
 }
 
 type applicationEvaluationService struct {
-	applicationRepository repository.ApplicationRepository
-	llmService            interop.LLMService
+	applicationRepository           repository.ApplicationRepository
+	applicationEvaluationRepository repository.ApplicationEvaluationRepository
+	llmService                      interop.LLMService
+	usageService                    UsageService
+	// promptVersion pins the version of the prompt template, zero means the latest version.
+	promptVersion int32
 }
 
 func NewApplicationEvaluateService(
 	applicationRepository repository.ApplicationRepository,
-	llmService interop.LLMService) ApplicationEvaluationService {
+	applicationEvaluationRepository repository.ApplicationEvaluationRepository,
+	llmService interop.LLMService, usageService UsageService, promptVersion int32) ApplicationEvaluationService {
 	return &applicationEvaluationService{
-		applicationRepository: applicationRepository,
-		llmService:            llmService,
+		applicationRepository:           applicationRepository,
+		applicationEvaluationRepository: applicationEvaluationRepository,
+		llmService:                      llmService,
+		usageService:                    usageService,
+		promptVersion:                   promptVersion,
 	}
 }
 
 func (s *applicationEvaluationService) EvaluateCurrentApplication(ctx context.Context) domain.ApplicationEvaluationResponse {
+	prompt, structuredOutputSchema, snapshotHash := s.prepareCurrentApplicationEvaluation(ctx)
+	if evaluation := s.findUnchangedEvaluation(ctx, prompt, snapshotHash); evaluation != nil {
+		return *evaluation
+	}
+
+	// The unchanged evaluations are returned for free.
+	reservationID := s.usageService.ReserveUsage(ctx, domain.FeatureApplicationEvaluation)
+	defer releaseUsageOnPanic(ctx, s.usageService, reservationID)
+
+	llmResponse := s.llmService.GenerateResponse(
+		ctx, promptChatHistory(prompt), nil, &structuredOutputSchema, applicationEvaluationGenerationOptions)
+
+	return s.saveEvaluation(ctx, llmResponse.Text, prompt, snapshotHash)
+}
+
+func (s *applicationEvaluationService) EvaluateCurrentApplicationStream(
+	ctx context.Context, onTextDelta func(string)) domain.ApplicationEvaluationResponse {
+	prompt, structuredOutputSchema, snapshotHash := s.prepareCurrentApplicationEvaluation(ctx)
+	if evaluation := s.findUnchangedEvaluation(ctx, prompt, snapshotHash); evaluation != nil {
+		return *evaluation
+	}
+
+	// The unchanged evaluations are returned for free.
+	reservationID := s.usageService.ReserveUsage(ctx, domain.FeatureApplicationEvaluation)
+	defer releaseUsageOnPanic(ctx, s.usageService, reservationID)
+
+	var text strings.Builder
+	for delta := range s.llmService.GenerateResponseStream(
+		ctx, promptChatHistory(prompt), nil, &structuredOutputSchema, applicationEvaluationGenerationOptions) {
+		if delta.Text == "" {
+			continue
+		}
+
+		text.WriteString(delta.Text)
+		onTextDelta(delta.Text)
+	}
+
+	return s.saveEvaluation(ctx, text.String(), prompt, snapshotHash)
+}
+
+func (s *applicationEvaluationService) GetCurrentApplicationEvaluations(
+	ctx context.Context) []domain.ApplicationEvaluationSummaryResponse {
+	log.L(ctx).Info("Getting application evaluations")
+
+	evaluations := s.applicationEvaluationRepository.FindApplicationEvaluations(
+		ctx, localcontext.GetApplication(ctx).ID)
+	evaluationsResponse := make([]domain.ApplicationEvaluationSummaryResponse, len(evaluations))
+
+	for i, evaluation := range evaluations {
+		response := applicationEvaluationToResponse(evaluation)
+		evaluationsResponse[i] = domain.ApplicationEvaluationSummaryResponse{
+			ID:                    response.ID,
+			Summary:               response.Summary,
+			PromptTemplate:        response.PromptTemplate,
+			PromptTemplateVersion: response.PromptTemplateVersion,
+			SnapshotHash:          response.SnapshotHash,
+			CreatedAt:             response.CreatedAt,
+		}
+	}
+
+	log.L(ctx).Infof("Found %d application evaluations", len(evaluationsResponse))
+	return evaluationsResponse
+}
+
+func (s *applicationEvaluationService) GetCurrentApplicationEvaluation(
+	ctx context.Context, id uuid.UUID) domain.ApplicationEvaluationResponse {
+	logger := log.L(ctx).WithField("evaluationId", id)
+	logger.Info("Getting application evaluation")
+
+	evaluation := s.applicationEvaluationRepository.GetApplicationEvaluation(ctx, localcontext.GetApplication(ctx).ID, id)
+	if evaluation == nil {
+		logger.Warn("Application evaluation not found")
+		myerror.New(myerror.ApplicationEvaluationNotFoundError).Throw()
+	}
+
+	return applicationEvaluationToResponse(*evaluation)
+}
+
+// prepareCurrentApplicationEvaluation renders the prompt and computes the snapshot hash, the hash
+// of the prompt variables, which identifies the evaluated state of the application.
+func (s *applicationEvaluationService) prepareCurrentApplicationEvaluation(
+	ctx context.Context) (domain.LLMRenderedPrompt, domain.LLMSchema, string) {
 	application := localcontext.GetApplication(ctx)
 
-	return s.evaluateApplication(ctx,
-		s.applicationRepository.GetActivities(ctx, application.ID),
-		s.applicationRepository.GetHonors(ctx, application.ID),
-		s.applicationRepository.GetEssays(ctx, application.ID),
-		s.applicationRepository.GetSupplementalEssays(ctx, application.ID))
+	academics := s.applicationRepository.GetAcademics(ctx, application.ID)
+	activities := s.applicationRepository.GetActivities(ctx, application.ID)
+	honors := s.applicationRepository.GetHonors(ctx, application.ID)
+	essays := s.applicationRepository.GetEssays(ctx, application.ID)
+	supplementalEssays := s.applicationRepository.GetSupplementalEssays(ctx, application.ID)
+
+	variables := applicationPromptVariables(activities, honors, essays, supplementalEssays)
+	variables["academics"] = academicsPromptVariables(academics)
+
+	// Maps are encoded with sorted keys, so the encoding is stable.
+	encodedVariables, err := json.Marshal(variables)
+	if err != nil {
+		panic(err)
+	}
+
+	snapshotHash := sha256.Sum256(encodedVariables)
+
+	prompt := s.llmService.RenderPromptTemplate(ctx, applicationEvaluationPromptTemplate, s.promptVersion, variables)
+
+	return prompt, generateApplicationEvaluationSchema(len(essays), len(supplementalEssays)),
+		hex.EncodeToString(snapshotHash[:])
 }
 
-func (s *applicationEvaluationService) evaluateApplication(
-	ctx context.Context, activities []model.Activity,
-	honors []model.Honor, essays []model.Essay,
-	supplementalEssays []model.SupplementalEssay) domain.ApplicationEvaluationResponse {
-	prompt := applicationEvaluationPromptBase
-	structuredOutputSchema := generateApplicationEvaluationSchema(len(essays), len(supplementalEssays))
+// findUnchangedEvaluation returns the latest evaluation of the current application if it was evaluated
+// in the same state with the same prompt template, or nil.
+func (s *applicationEvaluationService) findUnchangedEvaluation(
+	ctx context.Context, prompt domain.LLMRenderedPrompt, snapshotHash string) *domain.ApplicationEvaluationResponse {
+	evaluation := s.applicationEvaluationRepository.GetLatestApplicationEvaluation(
+		ctx, localcontext.GetApplication(ctx).ID)
+	if evaluation == nil || evaluation.SnapshotHash != snapshotHash ||
+		evaluation.PromptTemplate != prompt.Name || evaluation.PromptTemplateVersion != prompt.Version {
+		return nil
+	}
+
+	log.L(ctx).WithField("evaluationId", evaluation.ID).Info("Application didn't change since the latest evaluation")
+
+	response := applicationEvaluationToResponse(*evaluation)
+	return &response
+}
+
+func (s *applicationEvaluationService) saveEvaluation(
+	ctx context.Context, text string, prompt domain.LLMRenderedPrompt,
+	snapshotHash string) domain.ApplicationEvaluationResponse {
+	response := parseApplicationEvaluation(text, prompt)
+	response.ID = uuid.New()
+	response.SnapshotHash = snapshotHash
+	response.CreatedAt = time.Now().UTC()
 
-	prompt += "# Application to evaluate\n\n## Extracurricular activities"
-	for idx, activity := range activities {
-		prompt += fmt.Sprintf("%d. %s - %s\n", idx+1, activity.Role, activity.Name)
+	s.applicationEvaluationRepository.CreateApplicationEvaluation(ctx, model.ApplicationEvaluation{
+		ID:                    response.ID,
+		ApplicationID:         localcontext.GetApplication(ctx).ID,
+		SnapshotHash:          snapshotHash,
+		PromptTemplate:        prompt.Name,
+		PromptTemplateVersion: prompt.Version,
+		Evaluation:            json.RawMessage(text),
+		CreatedAt:             response.CreatedAt,
+	})
 
-		if activity.Description != nil {
-			prompt += "Description: " + *activity.Description
+	log.L(ctx).WithField("evaluationId", response.ID).Info("Application evaluation saved successfully")
+	return response
+}
+
+// applicationPromptVariables converts the application into the variables of the prompt templates.
+// Optional fields are always set, as the template fails to render if a variable is missing.
+func applicationPromptVariables(
+	activities []model.Activity, honors []model.Honor, essays []model.Essay,
+	supplementalEssays []model.SupplementalEssay) map[string]any {
+	activityVariables := make([]any, len(activities))
+	for i, activity := range activities {
+		grades := make([]any, len(activity.Grades))
+		for j, grade := range activity.Grades {
+			grades[j] = string(grade)
 		}
 
-		prompt += fmt.Sprintf("Hours per week: %d\n", activity.HoursPerWeek)
-		prompt += fmt.Sprintf("Weeks per year: %d\n", activity.WeeksPerYear)
-		prompt += fmt.Sprintf("Category: %s\n", activity.Category)
+		activityVariables[i] = map[string]any{
+			"role":         activity.Role,
+			"name":         activity.Name,
+			"description":  valueOrEmpty(activity.Description),
+			"hoursPerWeek": activity.HoursPerWeek,
+			"weeksPerYear": activity.WeeksPerYear,
+			"category":     string(activity.Category),
+			"grades":       grades,
+		}
+	}
 
-		gradeStrings := make([]string, len(activity.Grades))
-		for i, grade := range activity.Grades {
-			gradeStrings[i] = string(grade)
+	honorVariables := make([]any, len(honors))
+	for i, honor := range honors {
+		honorVariables[i] = map[string]any{
+			"title":       honor.Title,
+			"description": valueOrEmpty(honor.Description),
+			"level":       string(honor.Level),
+			"grade":       string(honor.Grade),
 		}
+	}
+
+	essayVariables := make([]any, len(essays))
+	for i, essay := range essays {
+		essayVariables[i] = map[string]any{
+			"type":    string(essay.Type),
+			"content": essay.Content,
+		}
+	}
+
+	supplementalEssayVariables := make([]any, len(supplementalEssays))
+	for i, essay := range supplementalEssays {
+		supplementalEssayVariables[i] = map[string]any{
+			"prompt":  essay.Prompt,
+			"content": essay.Content,
+		}
+	}
+
+	return map[string]any{
+		"activities":         activityVariables,
+		"honors":             honorVariables,
+		"essays":             essayVariables,
+		"supplementalEssays": supplementalEssayVariables,
+	}
+}
 
-		prompt += fmt.Sprintf("Grade levels: %s\n", strings.Join(gradeStrings, ", "))
+// academicsPromptVariables converts the academics into the variables of the evaluation prompt template.
+// The GPA and the class rank are nil if they weren't put.
+func academicsPromptVariables(academics model.Academics) map[string]any {
+	var gpa, classRank any
+	if academics.GPA != nil {
+		gpa = map[string]any{
+			"value":    academics.GPA.Value,
+			"scale":    academics.GPA.Scale,
+			"weighted": academics.GPA.Weighted,
+		}
 	}
 
-	prompt += "## Honors\n"
-	for idx, honor := range honors {
-		prompt += fmt.Sprintf("%d. %s\n", idx+1, honor.Title)
+	if academics.ClassRank != nil {
+		classRank = map[string]any{
+			"rank":      academics.ClassRank.Rank,
+			"classSize": academics.ClassRank.ClassSize,
+		}
+	}
 
-		if honor.Description != nil {
-			prompt += "Description: " + *honor.Description
+	courseVariables := make([]any, len(academics.Courses))
+	for i, course := range academics.Courses {
+		courseVariables[i] = map[string]any{
+			"name":   course.Name,
+			"rigor":  string(course.Rigor),
+			"grade":  string(course.Grade),
+			"result": valueOrEmpty(course.Result),
 		}
+	}
 
-		prompt += fmt.Sprintf("Level: %s\n", honor.Level)
-		prompt += fmt.Sprintf("Grade: %s\n", honor.Grade)
+	testScoreVariables := make([]any, len(academics.TestScores))
+	for i, testScore := range academics.TestScores {
+		testScoreVariables[i] = map[string]any{
+			"type":  string(testScore.Type),
+			"score": testScore.Score,
+		}
 	}
 
-	prompt += "## Essays\n"
-	for idx, essay := range essays {
-		prompt += fmt.Sprintf("%d. Type: %s", idx+1, essay.Type)
-		prompt += essay.Content + "\n\n\n"
+	return map[string]any{
+		"gpa":        gpa,
+		"classRank":  classRank,
+		"courses":    courseVariables,
+		"testScores": testScoreVariables,
 	}
+}
 
-	prompt += "## Supplemental essays\n"
-	for idx, essay := range supplementalEssays {
-		prompt += fmt.Sprintf("%d. Prompt: %s\n", idx+1, essay.Prompt)
-		prompt += essay.Content + "\n\n\n"
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
 	}
 
-	llmResponse := s.llmService.GenerateResponse(ctx, []domain.LLMMessage{
+	return *value
+}
+
+func promptChatHistory(prompt domain.LLMRenderedPrompt) []domain.LLMMessage {
+	return []domain.LLMMessage{
 		{
 			Role: domain.RoleSystem,
-			Text: prompt,
+			Text: prompt.Text,
 		},
-	}, nil, &structuredOutputSchema)
+	}
+}
 
+func parseApplicationEvaluation(
+	text string, prompt domain.LLMRenderedPrompt) domain.ApplicationEvaluationResponse {
 	var response domain.ApplicationEvaluationResponse
-	err := json.Unmarshal([]byte(llmResponse.Text), &response)
+	err := json.Unmarshal([]byte(text), &response)
 	if err != nil {
+		myerror.NewWithReason(myerror.InvalidLLMResponseError, err.Error()).Throw()
+	}
+
+	response.PromptTemplate = prompt.Name
+	response.PromptTemplateVersion = prompt.Version
+
+	return response
+}
+
+// applicationEvaluationToResponse decodes the stored evaluation, which was validated when it was generated.
+func applicationEvaluationToResponse(evaluation model.ApplicationEvaluation) domain.ApplicationEvaluationResponse {
+	var response domain.ApplicationEvaluationResponse
+	if err := json.Unmarshal(evaluation.Evaluation, &response); err != nil {
 		panic(err)
 	}
 
+	response.ID = evaluation.ID
+	response.PromptTemplate = evaluation.PromptTemplate
+	response.PromptTemplateVersion = evaluation.PromptTemplateVersion
+	response.SnapshotHash = evaluation.SnapshotHash
+	response.CreatedAt = evaluation.CreatedAt
+
 	return response
 }
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+const (
+	// applicationEvaluationJobPollInterval is how often the worker looks for new jobs when it is idle, as the jobs
+	// may be enqueued by other replicas.
+	applicationEvaluationJobPollInterval = 2 * time.Second
+	// applicationEvaluationJobWatchInterval is how often the watchers of a job check its status.
+	applicationEvaluationJobWatchInterval = time.Second
+	// applicationEvaluationJobMaxAttempts is how many times a job failing because llm-service is unavailable
+	// or overloaded, or abandoned by the crashed workers, is run.
+	applicationEvaluationJobMaxAttempts = 3
+	// applicationEvaluationJobRetryBackoff is the delay before the first retry of a job, doubled before every
+	// next one, giving llm-service time to recover.
+	applicationEvaluationJobRetryBackoff = 30 * time.Second
+)
+
+type ApplicationEvaluationJobConfig struct {
+	// Workers is how many jobs every replica runs at the same time.
+	Workers int
+	// Lease is how long a job may run before it is taken over by another worker.
+	Lease time.Duration
+}
+
+// ApplicationEvaluationJobService evaluates the applications in the background, so that the evaluations
+// don't depend on the clients staying connected. The jobs are persisted, so that the clients can poll them
+// or watch their status change, and the processing survives restarts.
+type ApplicationEvaluationJobService interface {
+	// EnqueueCurrentApplicationEvaluation creates a job evaluating the current application. The unfinished job
+	// is returned instead if the application is already being evaluated.
+	EnqueueCurrentApplicationEvaluation(ctx context.Context) domain.ApplicationEvaluationJobResponse
+	GetCurrentApplicationEvaluationJob(ctx context.Context, id uuid.UUID) domain.ApplicationEvaluationJobResponse
+	// WatchCurrentApplicationEvaluationJob reports the job to onUpdate, and then again every time its status
+	// changes, until it is finished or the context is cancelled.
+	WatchCurrentApplicationEvaluationJob(
+		ctx context.Context, id uuid.UUID, onUpdate func(domain.ApplicationEvaluationJobResponse))
+	// Run runs the jobs until the context is cancelled.
+	Run(ctx context.Context)
+}
+
+type applicationEvaluationJobService struct {
+	applicationRepository              repository.ApplicationRepository
+	applicationEvaluationJobRepository repository.ApplicationEvaluationJobRepository
+	applicationEvaluationService       ApplicationEvaluationService
+	config                             ApplicationEvaluationJobConfig
+	// wake interrupts the idle worker when a job is enqueued or finished.
+	wake chan struct{}
+}
+
+func NewApplicationEvaluationJobService(
+	applicationRepository repository.ApplicationRepository,
+	applicationEvaluationJobRepository repository.ApplicationEvaluationJobRepository,
+	applicationEvaluationService ApplicationEvaluationService,
+	config ApplicationEvaluationJobConfig) ApplicationEvaluationJobService {
+	config.Workers = max(config.Workers, 1)
+
+	return &applicationEvaluationJobService{
+		applicationRepository:              applicationRepository,
+		applicationEvaluationJobRepository: applicationEvaluationJobRepository,
+		applicationEvaluationService:       applicationEvaluationService,
+		config:                             config,
+		wake:                               make(chan struct{}, 1),
+	}
+}
+
+func (s *applicationEvaluationJobService) EnqueueCurrentApplicationEvaluation(
+	ctx context.Context) domain.ApplicationEvaluationJobResponse {
+	application := localcontext.GetApplication(ctx)
+	subscription := localcontext.GetSubscription(ctx)
+
+	job := model.ApplicationEvaluationJob{
+		ID:                      uuid.New(),
+		ApplicationID:           application.ID,
+		UserID:                  auth.GetUserID(ctx),
+		SubscriptionTier:        string(subscription.Tier),
+		SubscriptionPeriodStart: subscription.PeriodStart,
+		SubscriptionPeriodEnd:   subscription.PeriodEnd,
+		Status:                  model.ApplicationEvaluationJobQueued,
+		CreatedAt:               time.Now().UTC(),
+	}
+
+	// The unfinished job may finish before it is found, in which case the job is created again.
+	for {
+		if s.applicationEvaluationJobRepository.CreateApplicationEvaluationJob(ctx, job) {
+			s.wakeWorker()
+
+			log.L(ctx).WithField("jobId", job.ID).Info("Application evaluation job enqueued successfully")
+			return applicationEvaluationJobToResponse(job)
+		}
+
+		if unfinishedJob := s.applicationEvaluationJobRepository.GetUnfinishedApplicationEvaluationJob(
+			ctx, application.ID); unfinishedJob != nil {
+			log.L(ctx).WithField("jobId", unfinishedJob.ID).Info("Application is already being evaluated")
+			return applicationEvaluationJobToResponse(*unfinishedJob)
+		}
+	}
+}
+
+func (s *applicationEvaluationJobService) GetCurrentApplicationEvaluationJob(
+	ctx context.Context, id uuid.UUID) domain.ApplicationEvaluationJobResponse {
+	return applicationEvaluationJobToResponse(s.getCurrentApplicationEvaluationJob(ctx, id))
+}
+
+func (s *applicationEvaluationJobService) WatchCurrentApplicationEvaluationJob(
+	ctx context.Context, id uuid.UUID, onUpdate func(domain.ApplicationEvaluationJobResponse)) {
+	ticker := time.NewTicker(applicationEvaluationJobWatchInterval)
+	defer ticker.Stop()
+
+	var status model.ApplicationEvaluationJobStatus
+
+	for {
+		job := s.getCurrentApplicationEvaluationJob(ctx, id)
+		if job.Status != status {
+			status = job.Status
+			onUpdate(applicationEvaluationJobToResponse(job))
+		}
+
+		if job.Finished() {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *applicationEvaluationJobService) getCurrentApplicationEvaluationJob(
+	ctx context.Context, id uuid.UUID) model.ApplicationEvaluationJob {
+	job := s.applicationEvaluationJobRepository.GetApplicationEvaluationJob(
+		ctx, localcontext.GetApplication(ctx).ID, id)
+	if job == nil {
+		log.L(ctx).WithField("jobId", id).Warn("Application evaluation job not found")
+		myerror.New(myerror.ApplicationEvaluationJobNotFoundError).Throw()
+	}
+
+	return *job
+}
+
+func (s *applicationEvaluationJobService) Run(ctx context.Context) {
+	// The semaphore holds a slot for every job being run.
+	semaphore := make(chan struct{}, s.config.Workers)
+
+	ticker := time.NewTicker(applicationEvaluationJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.claimJobs(ctx, semaphore)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *applicationEvaluationJobService) wakeWorker() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// claimJobs claims as many jobs as there are free slots and runs them in the background.
+func (s *applicationEvaluationJobService) claimJobs(ctx context.Context, semaphore chan struct{}) {
+	free := cap(semaphore) - len(semaphore)
+	if free == 0 {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.L(ctx).Errorf("Failed to claim application evaluation jobs: %v", r)
+		}
+	}()
+
+	leaseExpiredBefore := time.Now().Add(-s.config.Lease)
+
+	if failed := s.applicationEvaluationJobRepository.FailAbandonedApplicationEvaluationJobs(
+		ctx, leaseExpiredBefore, applicationEvaluationJobMaxAttempts); failed > 0 {
+		log.L(ctx).Warnf("%d application evaluation jobs failed after their last attempt was abandoned", failed)
+	}
+
+	jobs := s.applicationEvaluationJobRepository.ClaimApplicationEvaluationJobs(
+		ctx, free, leaseExpiredBefore, applicationEvaluationJobMaxAttempts)
+
+	for _, job := range jobs {
+		semaphore <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-semaphore
+				s.wakeWorker()
+			}()
+
+			s.runJob(ctx, job)
+		}()
+	}
+}
+
+func (s *applicationEvaluationJobService) runJob(ctx context.Context, job model.ApplicationEvaluationJob) {
+	log.SetLogger(&ctx, log.L(ctx).WithFields(logrus.Fields{
+		"jobId":         job.ID,
+		"applicationId": job.ApplicationID,
+	}))
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.L(ctx).Errorf("Failed to run application evaluation job: %v", r)
+		}
+	}()
+
+	// The job is removed together with the application.
+	application := s.applicationRepository.GetApplication(ctx, job.ApplicationID)
+	if application == nil {
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, s.config.Lease)
+	defer cancel()
+
+	auth.SetUserID(&jobCtx, job.UserID)
+	localcontext.SetApplication(&jobCtx, *application)
+	localcontext.SetSubscription(&jobCtx, domain.Subscription{
+		Tier:        domain.SubscriptionTier(job.SubscriptionTier),
+		PeriodStart: job.SubscriptionPeriodStart,
+		PeriodEnd:   job.SubscriptionPeriodEnd,
+	})
+
+	log.L(ctx).Infof("Running application evaluation job, attempt %d", job.Attempts)
+	evaluation, err := s.evaluate(jobCtx)
+
+	// The worker is stopping, so the job is taken over by another worker once its lease expires.
+	if ctx.Err() != nil {
+		return
+	}
+
+	// llm-service is unavailable or overloaded, so the job is put back to the queue with a backoff.
+	var myErr myerror.MyError
+	if errors.As(err, &myErr) && isRetryableLLMError(myErr) && job.Attempts < applicationEvaluationJobMaxAttempts {
+		backoff := applicationEvaluationJobRetryBackoff << max(job.Attempts-1, 0)
+		log.L(ctx).Warnf("Application evaluation job failed in attempt %d, retrying in %s: %v",
+			job.Attempts, backoff, err)
+
+		s.applicationEvaluationJobRepository.RetryApplicationEvaluationJob(ctx, job, time.Now().Add(backoff))
+		return
+	}
+
+	finishedAt := time.Now().UTC()
+	job.FinishedAt = &finishedAt
+
+	if err == nil {
+		job.Status = model.ApplicationEvaluationJobSucceeded
+		job.EvaluationID = &evaluation.ID
+
+		log.L(ctx).WithField("evaluationId", evaluation.ID).Info("Application evaluation job succeeded")
+	} else {
+		job.Status = model.ApplicationEvaluationJobFailed
+		job.ErrorType, job.ErrorDetails = applicationEvaluationJobError(err)
+
+		log.L(ctx).Warnf("Application evaluation job failed: %v", err)
+	}
+
+	s.applicationEvaluationJobRepository.FinishApplicationEvaluationJob(ctx, job)
+}
+
+// evaluate converts the errors thrown by the evaluation into the returned error.
+func (s *applicationEvaluationJobService) evaluate(
+	ctx context.Context) (evaluation domain.ApplicationEvaluationResponse, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if recoveredErr, ok := r.(error); ok {
+				err = recoveredErr
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	return s.applicationEvaluationService.EvaluateCurrentApplication(ctx), nil
+}
+
+func isRetryableLLMError(err myerror.MyError) bool {
+	return err.ErrorType() == myerror.LLMUnavailableError || err.ErrorType() == myerror.TooManyLLMRequestsError
+}
+
+// applicationEvaluationJobError returns the type and the encoded details of the error as the API would
+// have returned them: the details of internal errors are hidden.
+func applicationEvaluationJobError(err error) (*int, json.RawMessage) {
+	var myErr myerror.MyError
+	if !errors.As(err, &myErr) {
+		errorType := 0
+		return &errorType, nil
+	}
+
+	errorType := myErr.ErrorType()
+	if myErr.ErrorDetails() == nil {
+		return &errorType, nil
+	}
+
+	details, err := json.Marshal(myErr.ErrorDetails())
+	if err != nil {
+		panic(err)
+	}
+
+	return &errorType, details
+}
+
+func applicationEvaluationJobToResponse(job model.ApplicationEvaluationJob) domain.ApplicationEvaluationJobResponse {
+	response := domain.ApplicationEvaluationJobResponse{
+		ID:           job.ID,
+		Status:       job.Status,
+		EvaluationID: job.EvaluationID,
+		CreatedAt:    job.CreatedAt,
+		StartedAt:    job.StartedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+
+	if job.ErrorType != nil {
+		response.Error = &domain.ApplicationEvaluationJobError{
+			ErrorType:    *job.ErrorType,
+			ErrorDetails: job.ErrorDetails,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+// stubApplicationEvaluationService evaluates the applications with evaluate.
+type stubApplicationEvaluationService struct {
+	ApplicationEvaluationService
+	evaluate func(ctx context.Context) domain.ApplicationEvaluationResponse
+}
+
+func (s *stubApplicationEvaluationService) EvaluateCurrentApplication(
+	ctx context.Context) domain.ApplicationEvaluationResponse {
+	return s.evaluate(ctx)
+}
+
+type ApplicationEvaluationJobServiceTestSuite struct {
+	suite.Suite
+	ctx                                context.Context
+	application                        model.Application
+	subscription                       domain.Subscription
+	applicationRepository              *repository.MockApplicationRepository
+	applicationEvaluationJobRepository *repository.MockApplicationEvaluationJobRepository
+	applicationEvaluationService       *stubApplicationEvaluationService
+	service                            *applicationEvaluationJobService
+}
+
+func TestApplicationEvaluationJobService(t *testing.T) {
+	suite.Run(t, new(ApplicationEvaluationJobServiceTestSuite))
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) SetupTest() {
+	s.application = model.Application{ID: uuid.New(), UserID: uuid.New(), Name: "Test"}
+
+	periodStart := time.Now().UTC().Add(-24 * time.Hour)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	s.subscription = domain.Subscription{Tier: domain.TierStudent, PeriodStart: &periodStart, PeriodEnd: &periodEnd}
+
+	s.ctx = context.Background()
+	auth.SetUserID(&s.ctx, s.application.UserID)
+	localcontext.SetApplication(&s.ctx, s.application)
+	localcontext.SetSubscription(&s.ctx, s.subscription)
+
+	s.applicationRepository = repository.NewMockApplicationRepository(s.T())
+	s.applicationRepository.EXPECT().GetApplication(mock.Anything, s.application.ID).
+		Return(&s.application).Maybe()
+
+	s.applicationEvaluationJobRepository = repository.NewMockApplicationEvaluationJobRepository(s.T())
+	s.applicationEvaluationService = &stubApplicationEvaluationService{
+		evaluate: func(context.Context) domain.ApplicationEvaluationResponse {
+			return domain.ApplicationEvaluationResponse{ID: uuid.New()}
+		},
+	}
+
+	s.service = NewApplicationEvaluationJobService(
+		s.applicationRepository, s.applicationEvaluationJobRepository, s.applicationEvaluationService,
+		ApplicationEvaluationJobConfig{Workers: 1, Lease: time.Minute}).(*applicationEvaluationJobService)
+}
+
+// claimedJob returns a job of the application claimed for the attempt.
+func (s *ApplicationEvaluationJobServiceTestSuite) claimedJob(attempts int) model.ApplicationEvaluationJob {
+	startedAt := time.Now().UTC()
+
+	return model.ApplicationEvaluationJob{
+		ID:                      uuid.New(),
+		ApplicationID:           s.application.ID,
+		UserID:                  s.application.UserID,
+		SubscriptionTier:        string(s.subscription.Tier),
+		SubscriptionPeriodStart: s.subscription.PeriodStart,
+		SubscriptionPeriodEnd:   s.subscription.PeriodEnd,
+		Status:                  model.ApplicationEvaluationJobRunning,
+		Attempts:                attempts,
+		CreatedAt:               startedAt,
+		StartedAt:               &startedAt,
+	}
+}
+
+// expectFinishedJob captures the job stored as finished.
+func (s *ApplicationEvaluationJobServiceTestSuite) expectFinishedJob() *model.ApplicationEvaluationJob {
+	finishedJob := &model.ApplicationEvaluationJob{}
+	s.applicationEvaluationJobRepository.EXPECT().FinishApplicationEvaluationJob(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, job model.ApplicationEvaluationJob) { *finishedJob = job })
+
+	return finishedJob
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestEnqueueCurrentApplicationEvaluation() {
+	var createdJob model.ApplicationEvaluationJob
+
+	s.applicationEvaluationJobRepository.EXPECT().CreateApplicationEvaluationJob(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, job model.ApplicationEvaluationJob) { createdJob = job }).Return(true)
+
+	job := s.service.EnqueueCurrentApplicationEvaluation(s.ctx)
+
+	s.Equal(model.ApplicationEvaluationJobQueued, job.Status)
+	s.Equal(createdJob.ID, job.ID)
+	s.Equal(s.application.ID, createdJob.ApplicationID)
+	s.Equal(s.application.UserID, createdJob.UserID)
+	s.Equal(string(domain.TierStudent), createdJob.SubscriptionTier)
+	s.Equal(s.subscription.PeriodEnd, createdJob.SubscriptionPeriodEnd)
+	s.Nil(job.EvaluationID)
+	s.Nil(job.Error)
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestEnqueueCurrentApplicationEvaluationReturnsUnfinishedJob() {
+	runningJob := s.claimedJob(1)
+
+	s.applicationEvaluationJobRepository.EXPECT().CreateApplicationEvaluationJob(mock.Anything, mock.Anything).
+		Return(false)
+	s.applicationEvaluationJobRepository.EXPECT().GetUnfinishedApplicationEvaluationJob(mock.Anything, s.application.ID).
+		Return(&runningJob)
+
+	job := s.service.EnqueueCurrentApplicationEvaluation(s.ctx)
+
+	s.Equal(runningJob.ID, job.ID)
+	s.Equal(model.ApplicationEvaluationJobRunning, job.Status)
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestRunJobSavesEvaluation() {
+	evaluationID := uuid.New()
+	s.applicationEvaluationService.evaluate = func(ctx context.Context) domain.ApplicationEvaluationResponse {
+		// The evaluation runs on behalf of the user who enqueued it.
+		s.Equal(s.application.UserID, auth.GetUserID(ctx))
+		s.Equal(s.application, localcontext.GetApplication(ctx))
+		s.Equal(s.subscription, localcontext.GetSubscription(ctx))
+
+		return domain.ApplicationEvaluationResponse{ID: evaluationID}
+	}
+	finishedJob := s.expectFinishedJob()
+
+	s.service.runJob(context.Background(), s.claimedJob(1))
+
+	s.Equal(model.ApplicationEvaluationJobSucceeded, finishedJob.Status)
+	s.Require().NotNil(finishedJob.EvaluationID)
+	s.Equal(evaluationID, *finishedJob.EvaluationID)
+	s.NotNil(finishedJob.FinishedAt)
+
+	response := applicationEvaluationJobToResponse(*finishedJob)
+	s.Nil(response.Error)
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestRunJobSavesError() {
+	s.applicationEvaluationService.evaluate = func(context.Context) domain.ApplicationEvaluationResponse {
+		myerror.NewWithReason(myerror.InvalidLLMResponseError, "unexpected end of JSON input").Throw()
+		return domain.ApplicationEvaluationResponse{}
+	}
+	finishedJob := s.expectFinishedJob()
+
+	s.service.runJob(context.Background(), s.claimedJob(1))
+
+	s.Equal(model.ApplicationEvaluationJobFailed, finishedJob.Status)
+	s.Nil(finishedJob.EvaluationID)
+
+	response := applicationEvaluationJobToResponse(*finishedJob)
+	s.Require().NotNil(response.Error)
+	s.Equal(myerror.InvalidLLMResponseError, response.Error.ErrorType)
+	s.JSONEq(`{"reason": "unexpected end of JSON input"}`, string(response.Error.ErrorDetails))
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestRunJobHidesInternalError() {
+	s.applicationEvaluationService.evaluate = func(context.Context) domain.ApplicationEvaluationResponse {
+		panic("connection refused")
+	}
+	finishedJob := s.expectFinishedJob()
+
+	s.service.runJob(context.Background(), s.claimedJob(1))
+
+	s.Equal(model.ApplicationEvaluationJobFailed, finishedJob.Status)
+	s.Require().NotNil(finishedJob.ErrorType)
+	s.Equal(0, *finishedJob.ErrorType)
+	s.Nil(finishedJob.ErrorDetails)
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestRunJobRetriesWhileLLMIsUnavailable() {
+	s.applicationEvaluationService.evaluate = func(context.Context) domain.ApplicationEvaluationResponse {
+		myerror.New(myerror.LLMUnavailableError).Throw()
+		return domain.ApplicationEvaluationResponse{}
+	}
+
+	// The job is put back to the queue with a backoff.
+	job := s.claimedJob(2)
+	s.applicationEvaluationJobRepository.EXPECT().RetryApplicationEvaluationJob(mock.Anything, job, mock.Anything).
+		Run(func(_ context.Context, _ model.ApplicationEvaluationJob, notBefore time.Time) {
+			s.WithinDuration(time.Now().Add(2*applicationEvaluationJobRetryBackoff), notBefore, time.Second)
+		})
+
+	s.service.runJob(context.Background(), job)
+
+	finishedJob := s.expectFinishedJob()
+
+	s.service.runJob(context.Background(), s.claimedJob(applicationEvaluationJobMaxAttempts))
+
+	s.Equal(model.ApplicationEvaluationJobFailed, finishedJob.Status)
+	s.Equal(myerror.LLMUnavailableError, *finishedJob.ErrorType)
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestClaimJobsFailsAbandonedJobs() {
+	s.applicationEvaluationJobRepository.EXPECT().FailAbandonedApplicationEvaluationJobs(
+		mock.Anything, mock.Anything, applicationEvaluationJobMaxAttempts).Return(1)
+	s.applicationEvaluationJobRepository.EXPECT().ClaimApplicationEvaluationJobs(
+		mock.Anything, 1, mock.Anything, applicationEvaluationJobMaxAttempts).Return(nil)
+
+	s.service.claimJobs(s.ctx, make(chan struct{}, 1))
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestWatchCurrentApplicationEvaluationJob() {
+	job := s.claimedJob(0)
+	job.Status = model.ApplicationEvaluationJobQueued
+
+	finishedJob := job
+	finishedJob.Status = model.ApplicationEvaluationJobSucceeded
+
+	s.applicationEvaluationJobRepository.EXPECT().GetApplicationEvaluationJob(mock.Anything, s.application.ID, job.ID).
+		Return(&job).Twice()
+	s.applicationEvaluationJobRepository.EXPECT().GetApplicationEvaluationJob(mock.Anything, s.application.ID, job.ID).
+		Return(&finishedJob).Once()
+
+	var statuses []model.ApplicationEvaluationJobStatus
+	s.service.WatchCurrentApplicationEvaluationJob(s.ctx, job.ID, func(job domain.ApplicationEvaluationJobResponse) {
+		statuses = append(statuses, job.Status)
+	})
+
+	// The job is reported once per status.
+	s.Equal([]model.ApplicationEvaluationJobStatus{
+		model.ApplicationEvaluationJobQueued,
+		model.ApplicationEvaluationJobSucceeded,
+	}, statuses)
+}
+
+func (s *ApplicationEvaluationJobServiceTestSuite) TestGetCurrentApplicationEvaluationJobNotFound() {
+	s.applicationEvaluationJobRepository.EXPECT().GetApplicationEvaluationJob(mock.Anything, s.application.ID,
+		mock.Anything).Return(nil)
+
+	defer func() {
+		err, ok := recover().(myerror.MyError)
+		s.Require().True(ok)
+		s.Equal(myerror.ApplicationEvaluationJobNotFoundError, err.ErrorType())
+	}()
+
+	s.service.GetCurrentApplicationEvaluationJob(s.ctx, uuid.New())
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+type ApplicationEvaluationServiceTestSuite struct {
+	suite.Suite
+	ctx                             context.Context
+	applicationRepository           *repository.MockApplicationRepository
+	applicationEvaluationRepository *repository.MockApplicationEvaluationRepository
+	usageRepository                 *repository.MockUsageRepository
+	service                         ApplicationEvaluationService
+	// latestEvaluation is the evaluation saved last, which the repository returns as the latest one.
+	latestEvaluation *model.ApplicationEvaluation
+}
+
+func TestApplicationEvaluationService(t *testing.T) {
+	suite.Run(t, new(ApplicationEvaluationServiceTestSuite))
+}
+
+func (s *ApplicationEvaluationServiceTestSuite) SetupTest() {
+	userID := uuid.New()
+
+	s.ctx = context.Background()
+	auth.SetUserID(&s.ctx, userID)
+	localcontext.SetApplication(&s.ctx, model.Application{ID: uuid.New(), UserID: userID, Name: "Test"})
+	localcontext.SetSubscription(&s.ctx, domain.Subscription{Tier: domain.TierStudent})
+
+	s.applicationRepository = repository.NewMockApplicationRepository(s.T())
+	s.applicationRepository.EXPECT().GetAcademics(mock.Anything, mock.Anything).Return(model.Academics{
+		GPA:        &model.GPA{Value: 3.9, Scale: 4},
+		Courses:    []model.Course{{Name: "AP Calculus BC", Rigor: model.CourseRigorAP, Grade: model.Grade11}},
+		TestScores: []model.TestScore{{Type: model.TestTypeSAT, Score: 1520}},
+	})
+	s.applicationRepository.EXPECT().GetActivities(mock.Anything, mock.Anything).Return([]model.Activity{
+		{Name: "Robotics Club", Role: "Captain", HoursPerWeek: 6, WeeksPerYear: 30,
+			Category: model.ActivityCategoryRobotics, Grades: []model.Grade{model.Grade11}},
+	})
+	s.applicationRepository.EXPECT().GetHonors(mock.Anything, mock.Anything).Return(nil)
+	s.applicationRepository.EXPECT().GetEssays(mock.Anything, mock.Anything).Return([]model.Essay{
+		{Type: model.EssayTypePersonalStatement, Content: "Once upon a time..."},
+	})
+	s.applicationRepository.EXPECT().GetSupplementalEssays(mock.Anything, mock.Anything).Return([]model.SupplementalEssay{
+		{Prompt: "Why us?", Content: "Because."},
+		{Prompt: "Describe your community.", Content: "It is small."},
+	})
+
+	s.latestEvaluation = nil
+	s.applicationEvaluationRepository = repository.NewMockApplicationEvaluationRepository(s.T())
+	s.applicationEvaluationRepository.EXPECT().GetLatestApplicationEvaluation(mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, uuid.UUID) *model.ApplicationEvaluation { return s.latestEvaluation }).
+		Maybe()
+	s.applicationEvaluationRepository.EXPECT().CreateApplicationEvaluation(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, evaluation model.ApplicationEvaluation) { s.latestEvaluation = &evaluation }).
+		Maybe()
+
+	s.usageRepository = repository.NewMockUsageRepository(s.T())
+	reservationID := uuid.New()
+	s.usageRepository.EXPECT().CreateUsageRecordWithinQuota(mock.Anything, userID,
+		domain.FeatureApplicationEvaluation, mock.Anything, mock.Anything, 10).Return(&reservationID).Maybe()
+
+	usageService := NewUsageService(s.usageRepository, nil, UsageConfig{
+		MonthlyQuotas: map[domain.Feature]map[domain.SubscriptionTier]int{
+			domain.FeatureApplicationEvaluation: {domain.TierStudent: 10},
+		},
+	})
+
+	s.service = NewApplicationEvaluateService(
+		s.applicationRepository, s.applicationEvaluationRepository, interop.NewFakeLLMService(nil), usageService, 0)
+}
+
+func (s *ApplicationEvaluationServiceTestSuite) TestEvaluateCurrentApplication() {
+	evaluation := s.service.EvaluateCurrentApplication(s.ctx)
+
+	s.Len(evaluation.EssaysEvaluationResponse.IndividualEvaluations, 1)
+	s.Len(evaluation.SupplementalEssaysEvaluationResponse.IndividualEvaluations, 2)
+	s.NotEmpty(evaluation.Suggestions)
+	s.Equal(applicationEvaluationPromptTemplate, evaluation.PromptTemplate)
+	s.Equal(int32(1), evaluation.PromptTemplateVersion)
+}
+
+func (s *ApplicationEvaluationServiceTestSuite) TestEvaluateCurrentApplicationStream() {
+	var streamed strings.Builder
+	evaluation := s.service.EvaluateCurrentApplicationStream(s.ctx, func(delta string) {
+		streamed.WriteString(delta)
+	})
+
+	s.NotEmpty(streamed.String())
+
+	// The application didn't change, so the streamed evaluation is returned again and isn't counted as usage.
+	s.Equal(evaluation, s.service.EvaluateCurrentApplication(s.ctx))
+	s.usageRepository.AssertNumberOfCalls(s.T(), "CreateUsageRecordWithinQuota", 1)
+}
+
+func (s *ApplicationEvaluationServiceTestSuite) TestEvaluateCurrentApplicationSavesEvaluation() {
+	evaluation := s.service.EvaluateCurrentApplication(s.ctx)
+
+	s.Require().NotNil(s.latestEvaluation)
+	s.Equal(evaluation.ID, s.latestEvaluation.ID)
+	s.Equal(evaluation.SnapshotHash, s.latestEvaluation.SnapshotHash)
+	s.Equal(localcontext.GetApplication(s.ctx).ID, s.latestEvaluation.ApplicationID)
+	s.Equal(int32(1), s.latestEvaluation.PromptTemplateVersion)
+	s.Len(evaluation.SnapshotHash, 64)
+}
+
+func (s *ApplicationEvaluationServiceTestSuite) TestEvaluateCurrentApplicationAgainIfApplicationChanged() {
+	evaluation := s.service.EvaluateCurrentApplication(s.ctx)
+
+	s.latestEvaluation.SnapshotHash = "0000"
+
+	reevaluation := s.service.EvaluateCurrentApplication(s.ctx)
+	s.NotEqual(evaluation.ID, reevaluation.ID)
+	s.Equal(evaluation.SnapshotHash, reevaluation.SnapshotHash)
+}
+
+func (s *ApplicationEvaluationServiceTestSuite) TestGetCurrentApplicationEvaluations() {
+	evaluation := s.service.EvaluateCurrentApplication(s.ctx)
+
+	s.applicationEvaluationRepository.EXPECT().FindApplicationEvaluations(mock.Anything, mock.Anything).
+		Return([]model.ApplicationEvaluation{*s.latestEvaluation})
+
+	summaries := s.service.GetCurrentApplicationEvaluations(s.ctx)
+	s.Require().Len(summaries, 1)
+	s.Equal(evaluation.ID, summaries[0].ID)
+	s.Equal(evaluation.Summary, summaries[0].Summary)
+	s.Equal(evaluation.CreatedAt, summaries[0].CreatedAt)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+type ApplicationServiceTestSuite struct {
+	suite.Suite
+	ctx                   context.Context
+	application           model.Application
+	applicationRepository *repository.MockApplicationRepository
+	service               ApplicationService
+}
+
+func TestApplicationService(t *testing.T) {
+	suite.Run(t, new(ApplicationServiceTestSuite))
+}
+
+func (s *ApplicationServiceTestSuite) SetupTest() {
+	s.application = model.Application{ID: uuid.New(), UserID: uuid.New(), Name: "Test"}
+
+	s.ctx = context.Background()
+	localcontext.SetApplication(&s.ctx, s.application)
+
+	s.applicationRepository = repository.NewMockApplicationRepository(s.T())
+	s.service = NewApplicationService(s.applicationRepository)
+}
+
+func (s *ApplicationServiceTestSuite) TestPutAcademics() {
+	result := "5"
+
+	var academics model.Academics
+	s.applicationRepository.EXPECT().PutAcademics(mock.Anything, s.application.ID, mock.Anything).
+		Run(func(_ context.Context, _ uuid.UUID, put model.Academics) { academics = put })
+
+	s.service.PutAcademics(s.ctx, domain.UpdateAcademicsRequest{
+		GPA: &domain.UpdateGPARequest{Value: 4.6, Scale: 5, Weighted: true},
+		Courses: []domain.UpdateCourseRequest{
+			{Name: "AP Calculus BC", Rigor: model.CourseRigorAP, Grade: model.Grade11, Result: &result},
+		},
+		TestScores: []domain.UpdateTestScoreRequest{
+			{Type: model.TestTypeSAT, Score: 1520},
+			{Type: model.TestTypeIELTS, Score: 7.5},
+		},
+	})
+
+	s.Equal(model.Academics{
+		GPA:     &model.GPA{Value: 4.6, Scale: 5, Weighted: true},
+		Courses: []model.Course{{Name: "AP Calculus BC", Rigor: model.CourseRigorAP, Grade: model.Grade11, Result: &result}},
+		TestScores: []model.TestScore{
+			{Type: model.TestTypeSAT, Score: 1520},
+			{Type: model.TestTypeIELTS, Score: 7.5},
+		},
+	}, academics)
+}
+
+func (s *ApplicationServiceTestSuite) TestPutAcademicsFailsIfTestScoreIsInvalid() {
+	defer func() {
+		err, ok := recover().(myerror.MyError)
+		s.Require().True(ok)
+		s.Equal(myerror.RequestValidationError, err.ErrorType())
+		s.Equal(map[string]any{"reason": "invalid ielts score: 7.3"}, err.ErrorDetails())
+	}()
+
+	// The academics aren't put.
+	s.service.PutAcademics(s.ctx, domain.UpdateAcademicsRequest{
+		TestScores: []domain.UpdateTestScoreRequest{{Type: model.TestTypeIELTS, Score: 7.3}},
+	})
+}
+
+func (s *ApplicationServiceTestSuite) TestGetAcademicsWithoutGPAAndClassRank() {
+	s.applicationRepository.EXPECT().GetAcademics(mock.Anything, s.application.ID).Return(model.Academics{
+		TestScores: []model.TestScore{{Type: model.TestTypeTOEFL, Score: 110}},
+	})
+
+	academics := s.service.GetAcademics(s.ctx)
+
+	s.Nil(academics.GPA)
+	s.Nil(academics.ClassRank)
+	s.Empty(academics.Courses)
+	s.Equal([]domain.TestScoreResponse{{Type: model.TestTypeTOEFL, Score: 110}}, academics.TestScores)
+}
+
+func (s *ApplicationServiceTestSuite) TestTestScoreScales() {
+	s.True(model.TestTypeSAT.ValidScore(1600))
+	s.False(model.TestTypeSAT.ValidScore(1605))
+	s.False(model.TestTypeSAT.ValidScore(390))
+	s.True(model.TestTypeACT.ValidScore(36))
+	s.False(model.TestTypeACT.ValidScore(0))
+	s.True(model.TestTypeIELTS.ValidScore(0))
+	s.False(model.TestTypeIELTS.ValidScore(9.5))
+	s.True(model.TestTypeTOEFL.ValidScore(120))
+	s.False(model.TestTypeTOEFL.ValidScore(99.5))
+	s.False(model.TestType("gre").ValidScore(320))
+}
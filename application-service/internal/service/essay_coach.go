@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+// essayCoachGenerationOptions make llm-service drop the earliest messages of chats too long for the model,
+// which matter the least, instead of failing the reply.
+var essayCoachGenerationOptions = domain.LLMGenerationOptions{
+	ContextOverflowPolicy: domain.ContextOverflowTruncate,
+}
+
+type EssayCoachConfig struct {
+	// PromptVersion pins the version of the prompt template, zero means the latest version.
+	PromptVersion int32
+}
+
+// EssayCoachService lets the student talk to the essay coach about an essay of the current application.
+type EssayCoachService interface {
+	GetChatMessages(ctx context.Context, essayID uuid.UUID) []domain.EssayChatMessageResponse
+	// SendChatMessage sends the message of the student to the essay coach and returns the reply.
+	SendChatMessage(
+		ctx context.Context, essayID uuid.UUID, request domain.SendEssayChatMessageRequest) domain.EssayChatMessageResponse
+	// SendChatMessageStream works like SendChatMessage, but reports the reply to onTextDelta as soon as
+	// it is generated.
+	SendChatMessageStream(
+		ctx context.Context, essayID uuid.UUID, request domain.SendEssayChatMessageRequest,
+		onTextDelta func(string)) domain.EssayChatMessageResponse
+	RemoveChatMessages(ctx context.Context, essayID uuid.UUID)
+}
+
+type essayCoachService struct {
+	applicationRepository repository.ApplicationRepository
+	essayChatRepository   repository.EssayChatRepository
+	llmService            interop.LLMService
+	usageService          UsageService
+	config                EssayCoachConfig
+}
+
+func NewEssayCoachService(
+	applicationRepository repository.ApplicationRepository,
+	essayChatRepository repository.EssayChatRepository,
+	llmService interop.LLMService,
+	usageService UsageService,
+	config EssayCoachConfig) EssayCoachService {
+	return &essayCoachService{
+		applicationRepository: applicationRepository,
+		essayChatRepository:   essayChatRepository,
+		llmService:            llmService,
+		usageService:          usageService,
+		config:                config,
+	}
+}
+
+func (s *essayCoachService) GetChatMessages(ctx context.Context, essayID uuid.UUID) []domain.EssayChatMessageResponse {
+	logger := log.L(ctx).WithField("essayId", essayID)
+	logger.Info("Getting essay chat messages")
+
+	s.getEssays(ctx, essayID)
+
+	messages := s.essayChatRepository.GetEssayChatMessages(ctx, essayID)
+	messagesResponse := make([]domain.EssayChatMessageResponse, len(messages))
+
+	for i, message := range messages {
+		messagesResponse[i] = essayChatMessageToResponse(message)
+	}
+
+	logger.Infof("Found %d essay chat messages", len(messagesResponse))
+	return messagesResponse
+}
+
+func (s *essayCoachService) SendChatMessage(
+	ctx context.Context, essayID uuid.UUID, request domain.SendEssayChatMessageRequest) domain.EssayChatMessageResponse {
+	question, chatHistory := s.prepareChatMessage(ctx, essayID, request.Message)
+
+	reservationID := s.usageService.ReserveUsage(ctx, domain.FeatureEssayCoach)
+	defer releaseUsageOnPanic(ctx, s.usageService, reservationID)
+
+	reply := s.llmService.GenerateResponse(ctx, chatHistory, nil, nil, essayCoachGenerationOptions)
+
+	return s.saveChatMessages(ctx, question, reply.Text)
+}
+
+func (s *essayCoachService) SendChatMessageStream(
+	ctx context.Context, essayID uuid.UUID, request domain.SendEssayChatMessageRequest,
+	onTextDelta func(string)) domain.EssayChatMessageResponse {
+	question, chatHistory := s.prepareChatMessage(ctx, essayID, request.Message)
+
+	reservationID := s.usageService.ReserveUsage(ctx, domain.FeatureEssayCoach)
+	defer releaseUsageOnPanic(ctx, s.usageService, reservationID)
+
+	var text strings.Builder
+	for delta := range s.llmService.GenerateResponseStream(ctx, chatHistory, nil, nil, essayCoachGenerationOptions) {
+		if delta.Text == "" {
+			continue
+		}
+
+		text.WriteString(delta.Text)
+		onTextDelta(delta.Text)
+	}
+
+	return s.saveChatMessages(ctx, question, text.String())
+}
+
+func (s *essayCoachService) RemoveChatMessages(ctx context.Context, essayID uuid.UUID) {
+	logger := log.L(ctx).WithField("essayId", essayID)
+	logger.Info("Removing essay chat messages")
+
+	s.getEssays(ctx, essayID)
+	s.essayChatRepository.RemoveEssayChatMessages(ctx, essayID)
+
+	logger.Info("Essay chat messages removed successfully")
+}
+
+// prepareChatMessage builds the chat history for the model: the system prompt grounded in the essay and the rest
+// of the application, the previous messages and the new question.
+func (s *essayCoachService) prepareChatMessage(
+	ctx context.Context, essayID uuid.UUID, text string) (model.EssayChatMessage, []domain.LLMMessage) {
+	application := localcontext.GetApplication(ctx)
+	userID := auth.GetUserID(ctx)
+
+	essays, essayIndex := s.getEssays(ctx, essayID)
+
+	activities := s.applicationRepository.GetActivities(ctx, application.ID)
+	honors := s.applicationRepository.GetHonors(ctx, application.ID)
+	supplementalEssays := s.applicationRepository.GetSupplementalEssays(ctx, application.ID)
+
+	essay := essays[essayIndex]
+	otherEssays := slices.Concat(essays[:essayIndex], essays[essayIndex+1:])
+
+	variables := applicationPromptVariables(activities, honors, otherEssays, supplementalEssays)
+	variables["essay"] = map[string]any{
+		"type":    string(essay.Type),
+		"content": essay.Content,
+	}
+
+	prompt := s.llmService.RenderPromptTemplate(ctx, essayCoachPromptTemplate, s.config.PromptVersion, variables)
+
+	chatHistory := promptChatHistory(prompt)
+	for _, message := range s.essayChatRepository.GetEssayChatMessages(ctx, essayID) {
+		role := domain.RoleUser
+		if message.Role == model.EssayChatRoleAssistant {
+			role = domain.RoleAssistant
+		}
+
+		chatHistory = append(chatHistory, domain.LLMMessage{Role: role, Text: message.Content})
+	}
+
+	question := model.EssayChatMessage{
+		ID:            uuid.New(),
+		ApplicationID: application.ID,
+		EssayID:       essayID,
+		UserID:        userID,
+		Role:          model.EssayChatRoleUser,
+		Content:       text,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	return question, append(chatHistory, domain.LLMMessage{Role: domain.RoleUser, Text: text})
+}
+
+// saveChatMessages stores the question only together with the reply, so that the chat never ends with
+// an unanswered question.
+func (s *essayCoachService) saveChatMessages(
+	ctx context.Context, question model.EssayChatMessage, text string) domain.EssayChatMessageResponse {
+	reply := model.EssayChatMessage{
+		ID:            uuid.New(),
+		ApplicationID: question.ApplicationID,
+		EssayID:       question.EssayID,
+		UserID:        question.UserID,
+		Role:          model.EssayChatRoleAssistant,
+		Content:       text,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	s.essayChatRepository.CreateEssayChatMessages(ctx, []model.EssayChatMessage{question, reply})
+	log.L(ctx).WithField("essayId", question.EssayID).Info("Essay chat messages saved successfully")
+
+	return essayChatMessageToResponse(reply)
+}
+
+// getEssays returns the essays of the current application and the index of the essay with the ID, or throws
+// EssayNotFoundError if there is no such essay.
+func (s *essayCoachService) getEssays(ctx context.Context, essayID uuid.UUID) ([]model.Essay, int) {
+	essays := s.applicationRepository.GetEssays(ctx, localcontext.GetApplication(ctx).ID)
+
+	essayIndex := slices.IndexFunc(essays, func(essay model.Essay) bool { return essay.ID == essayID })
+	if essayIndex < 0 {
+		log.L(ctx).WithField("essayId", essayID).Warn("Essay not found")
+		myerror.New(myerror.EssayNotFoundError).Throw()
+	}
+
+	return essays, essayIndex
+}
+
+func essayChatMessageToResponse(message model.EssayChatMessage) domain.EssayChatMessageResponse {
+	return domain.EssayChatMessageResponse{
+		ID:        message.ID,
+		Role:      message.Role,
+		Content:   message.Content,
+		CreatedAt: message.CreatedAt,
+	}
+}
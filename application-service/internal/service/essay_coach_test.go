@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+	"github.com/compendium-tech/compendium/application-service/internal/model"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+type EssayCoachServiceTestSuite struct {
+	suite.Suite
+	ctx                   context.Context
+	essayID               uuid.UUID
+	userID                uuid.UUID
+	applicationRepository *repository.MockApplicationRepository
+	essayChatRepository   *repository.MockEssayChatRepository
+	usageRepository       *repository.MockUsageRepository
+	service               EssayCoachService
+}
+
+func TestEssayCoachService(t *testing.T) {
+	suite.Run(t, new(EssayCoachServiceTestSuite))
+}
+
+func (s *EssayCoachServiceTestSuite) SetupTest() {
+	s.essayID = uuid.New()
+	s.userID = uuid.New()
+
+	s.ctx = context.Background()
+	auth.SetUserID(&s.ctx, s.userID)
+	localcontext.SetApplication(&s.ctx, model.Application{ID: uuid.New(), UserID: s.userID, Name: "Test"})
+	localcontext.SetSubscription(&s.ctx, domain.Subscription{Tier: domain.TierStudent})
+
+	s.applicationRepository = repository.NewMockApplicationRepository(s.T())
+	s.applicationRepository.EXPECT().GetEssays(mock.Anything, mock.Anything).Return([]model.Essay{
+		{ID: uuid.New(), Type: model.EssayTypeTeacherRecommendation, Content: "A curious student."},
+		{ID: s.essayID, Type: model.EssayTypePersonalStatement, Content: "Once upon a time..."},
+	})
+	s.applicationRepository.EXPECT().GetActivities(mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.applicationRepository.EXPECT().GetHonors(mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.applicationRepository.EXPECT().GetSupplementalEssays(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	s.essayChatRepository = repository.NewMockEssayChatRepository(s.T())
+
+	s.usageRepository = repository.NewMockUsageRepository(s.T())
+	usageService := NewUsageService(s.usageRepository, nil, UsageConfig{
+		MonthlyQuotas: map[domain.Feature]map[domain.SubscriptionTier]int{
+			domain.FeatureEssayCoach: {domain.TierStudent: 100},
+		},
+	})
+
+	s.service = NewEssayCoachService(s.applicationRepository, s.essayChatRepository, interop.NewFakeLLMService(nil),
+		usageService, EssayCoachConfig{})
+}
+
+func (s *EssayCoachServiceTestSuite) TestSendChatMessageSavesQuestionAndReply() {
+	s.essayChatRepository.EXPECT().GetEssayChatMessages(mock.Anything, s.essayID).Return([]model.EssayChatMessage{
+		{Role: model.EssayChatRoleUser, Content: "Is my hook too cliché?"},
+		{Role: model.EssayChatRoleAssistant, Content: "A little."},
+	})
+
+	var saved []model.EssayChatMessage
+	s.essayChatRepository.EXPECT().CreateEssayChatMessages(mock.Anything, mock.Anything).Run(
+		func(_ context.Context, messages []model.EssayChatMessage) { saved = messages })
+	reservationID := uuid.New()
+	s.usageRepository.EXPECT().CreateUsageRecordWithinQuota(
+		mock.Anything, s.userID, domain.FeatureEssayCoach, mock.Anything, mock.Anything, 100).Return(&reservationID)
+
+	reply := s.service.SendChatMessage(s.ctx, s.essayID,
+		domain.SendEssayChatMessageRequest{Message: "How do I fix it?"})
+
+	s.Require().Len(saved, 2)
+	s.Equal(model.EssayChatRoleUser, saved[0].Role)
+	s.Equal("How do I fix it?", saved[0].Content)
+	s.Equal(s.essayID, saved[0].EssayID)
+	s.Equal(s.userID, saved[0].UserID)
+	s.Equal(model.EssayChatRoleAssistant, saved[1].Role)
+	s.Equal(saved[1].ID, reply.ID)
+	s.True(strings.HasPrefix(reply.Content, "Fake response to prompt"))
+}
+
+func (s *EssayCoachServiceTestSuite) TestSendChatMessageStream() {
+	s.essayChatRepository.EXPECT().GetEssayChatMessages(mock.Anything, s.essayID).Return(nil)
+	s.essayChatRepository.EXPECT().CreateEssayChatMessages(mock.Anything, mock.Anything).Return()
+	reservationID := uuid.New()
+	s.usageRepository.EXPECT().CreateUsageRecordWithinQuota(
+		mock.Anything, s.userID, domain.FeatureEssayCoach, mock.Anything, mock.Anything, 100).Return(&reservationID)
+
+	var streamed strings.Builder
+	reply := s.service.SendChatMessageStream(s.ctx, s.essayID,
+		domain.SendEssayChatMessageRequest{Message: "Is my hook too cliché?"}, func(delta string) {
+			streamed.WriteString(delta)
+		})
+
+	s.Equal(streamed.String(), reply.Content)
+}
+
+func (s *EssayCoachServiceTestSuite) TestSendChatMessageReleasesUsageIfItFails() {
+	s.essayChatRepository.EXPECT().GetEssayChatMessages(mock.Anything, s.essayID).Return(nil)
+	s.essayChatRepository.EXPECT().CreateEssayChatMessages(mock.Anything, mock.Anything).Run(
+		func(context.Context, []model.EssayChatMessage) { panic(errors.New("connection refused")) })
+	reservationID := uuid.New()
+	s.usageRepository.EXPECT().CreateUsageRecordWithinQuota(
+		mock.Anything, s.userID, domain.FeatureEssayCoach, mock.Anything, mock.Anything, 100).Return(&reservationID)
+	s.usageRepository.EXPECT().RemoveUsageRecord(mock.Anything, reservationID)
+
+	s.Panics(func() {
+		s.service.SendChatMessage(s.ctx, s.essayID, domain.SendEssayChatMessageRequest{Message: "How do I fix it?"})
+	})
+}
+
+func (s *EssayCoachServiceTestSuite) TestSendChatMessageFailsIfQuotaIsUsedUp() {
+	s.essayChatRepository.EXPECT().GetEssayChatMessages(mock.Anything, s.essayID).Return(nil)
+	s.usageRepository.EXPECT().CreateUsageRecordWithinQuota(
+		mock.Anything, s.userID, domain.FeatureEssayCoach, mock.Anything, mock.Anything, 100).Return(nil)
+
+	defer func() {
+		err, ok := recover().(myerror.MyError)
+		s.Require().True(ok)
+		s.Equal(myerror.UsageQuotaExceededError, err.ErrorType())
+	}()
+
+	// Nothing is saved.
+	s.service.SendChatMessage(s.ctx, s.essayID, domain.SendEssayChatMessageRequest{Message: "How do I fix it?"})
+}
+
+func (s *EssayCoachServiceTestSuite) TestGetChatMessagesFailsIfEssayIsNotFound() {
+	defer func() {
+		err, ok := recover().(myerror.MyError)
+		s.Require().True(ok)
+		s.Equal(myerror.EssayNotFoundError, err.ErrorType())
+	}()
+
+	s.service.GetChatMessages(s.ctx, uuid.New())
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+type SubscriptionTierConfig struct {
+	// CacheTTL is how long the subscriptions reported by subscription-service are cached, so it is also how long
+	// the changes of the subscriptions may take to apply.
+	CacheTTL time.Duration
+	// FeatureTiers lists the tiers which may use each feature. Features missing from the map aren't
+	// available to any tier.
+	FeatureTiers map[domain.Feature][]domain.SubscriptionTier
+}
+
+// SubscriptionTierService decides which features the users may use according to their subscription tiers.
+type SubscriptionTierService interface {
+	// GetCurrentSubscription returns the subscription of the current user.
+	GetCurrentSubscription(ctx context.Context) domain.Subscription
+	// RequireFeature throws FeatureNotAvailableError unless the tier may use the feature.
+	RequireFeature(ctx context.Context, tier domain.SubscriptionTier, feature domain.Feature)
+}
+
+type subscriptionTierService struct {
+	subscriptionService    interop.SubscriptionService
+	subscriptionRepository repository.SubscriptionRepository
+	config                 SubscriptionTierConfig
+}
+
+func NewSubscriptionTierService(
+	subscriptionService interop.SubscriptionService,
+	subscriptionRepository repository.SubscriptionRepository,
+	config SubscriptionTierConfig) SubscriptionTierService {
+	return &subscriptionTierService{
+		subscriptionService:    subscriptionService,
+		subscriptionRepository: subscriptionRepository,
+		config:                 config,
+	}
+}
+
+func (s *subscriptionTierService) GetCurrentSubscription(ctx context.Context) domain.Subscription {
+	userID := auth.GetUserID(ctx)
+
+	if subscription := s.subscriptionRepository.GetSubscription(ctx, userID); subscription != nil {
+		return *subscription
+	}
+
+	subscription := s.subscriptionService.GetSubscription(ctx, userID)
+	s.subscriptionRepository.SetSubscription(ctx, userID, subscription, s.config.CacheTTL)
+
+	log.L(ctx).WithField("tier", subscription.Tier).Info("Subscription fetched successfully")
+	return subscription
+}
+
+func (s *subscriptionTierService) RequireFeature(
+	ctx context.Context, tier domain.SubscriptionTier, feature domain.Feature) {
+	tiers := s.config.FeatureTiers[feature]
+	if slices.Contains(tiers, tier) {
+		return
+	}
+
+	log.L(ctx).Warnf("Subscription tier %s doesn't include %s", tier, feature)
+	myerror.NewWithDetails(myerror.FeatureNotAvailableError, map[string]any{
+		"feature":       feature,
+		"tier":          tier,
+		"requiredTiers": tiers,
+	}).Throw()
+}
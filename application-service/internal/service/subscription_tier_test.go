@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+type SubscriptionTierServiceTestSuite struct {
+	suite.Suite
+	ctx                    context.Context
+	userID                 uuid.UUID
+	subscriptionService    *interop.MockSubscriptionService
+	subscriptionRepository *repository.MockSubscriptionRepository
+	service                SubscriptionTierService
+}
+
+func TestSubscriptionTierService(t *testing.T) {
+	suite.Run(t, new(SubscriptionTierServiceTestSuite))
+}
+
+func (s *SubscriptionTierServiceTestSuite) SetupTest() {
+	s.userID = uuid.New()
+
+	s.ctx = context.Background()
+	auth.SetUserID(&s.ctx, s.userID)
+
+	s.subscriptionService = interop.NewMockSubscriptionService(s.T())
+	s.subscriptionRepository = repository.NewMockSubscriptionRepository(s.T())
+
+	s.service = NewSubscriptionTierService(s.subscriptionService, s.subscriptionRepository,
+		SubscriptionTierConfig{
+			CacheTTL: time.Minute,
+			FeatureTiers: map[domain.Feature][]domain.SubscriptionTier{
+				domain.FeatureApplicationEvaluation: {domain.TierStudent, domain.TierTeam},
+			},
+		})
+}
+
+func (s *SubscriptionTierServiceTestSuite) TestGetCurrentSubscriptionCachesSubscription() {
+	periodStart := time.Now().UTC()
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	subscription := domain.Subscription{Tier: domain.TierTeam, PeriodStart: &periodStart, PeriodEnd: &periodEnd}
+
+	s.subscriptionRepository.EXPECT().GetSubscription(mock.Anything, s.userID).Return(nil)
+	s.subscriptionService.EXPECT().GetSubscription(mock.Anything, s.userID).Return(subscription)
+	s.subscriptionRepository.EXPECT().SetSubscription(mock.Anything, s.userID, subscription, time.Minute)
+
+	s.Equal(subscription, s.service.GetCurrentSubscription(s.ctx))
+}
+
+func (s *SubscriptionTierServiceTestSuite) TestGetCurrentSubscriptionReturnsCachedSubscription() {
+	s.subscriptionRepository.EXPECT().GetSubscription(mock.Anything, s.userID).
+		Return(&domain.Subscription{Tier: domain.TierStudent})
+
+	// subscription-service isn't called.
+	s.Equal(domain.TierStudent, s.service.GetCurrentSubscription(s.ctx).Tier)
+}
+
+func (s *SubscriptionTierServiceTestSuite) TestRequireFeature() {
+	s.NotPanics(func() {
+		s.service.RequireFeature(s.ctx, domain.TierStudent, domain.FeatureApplicationEvaluation)
+	})
+}
+
+func (s *SubscriptionTierServiceTestSuite) TestRequireFeatureNotAvailable() {
+	defer func() {
+		err, ok := recover().(myerror.MyError)
+		s.Require().True(ok)
+		s.Equal(myerror.FeatureNotAvailableError, err.ErrorType())
+		s.Equal(map[string]any{
+			"feature":       domain.FeatureApplicationEvaluation,
+			"tier":          domain.TierNone,
+			"requiredTiers": []domain.SubscriptionTier{domain.TierStudent, domain.TierTeam},
+		}, err.ErrorDetails())
+	}()
+
+	s.service.RequireFeature(s.ctx, domain.TierNone, domain.FeatureApplicationEvaluation)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+// usageFeatures are the features which may have quotas, in the order they are reported.
+var usageFeatures = []domain.Feature{
+	domain.FeatureApplicationEvaluation,
+	domain.FeatureEssayCoach,
+	domain.FeatureEssayRewrite,
+}
+
+type UsageConfig struct {
+	// MonthlyQuotas is how many times the users of each tier may use each feature per billing period. Features
+	// missing from the map aren't limited, tiers missing from the quotas of a feature can't use it at all.
+	MonthlyQuotas map[domain.Feature]map[domain.SubscriptionTier]int
+}
+
+// UsageService counts how many times the users use the features with quotas, which reset with the billing
+// period of their subscription.
+type UsageService interface {
+	// GetCurrentUsage reports the usage and the quotas of the current user in the current billing period.
+	GetCurrentUsage(ctx context.Context) domain.UsageResponse
+	// RequireQuota throws UsageQuotaExceededError if the current user used up the quota of the feature. The
+	// subscription is taken from the context. It doesn't reserve anything, so it only rejects the requests
+	// early, e.g. before a job is enqueued or a stream is started; the feature itself must call ReserveUsage.
+	RequireQuota(ctx context.Context, feature domain.Feature)
+	// ReserveUsage counts a use of the feature by the current user, or throws UsageQuotaExceededError if the
+	// quota is used up. It returns the reservation, which must be released with ReleaseUsage if the feature
+	// fails, so that failed requests don't use up the quotas. Features without quotas return nil.
+	ReserveUsage(ctx context.Context, feature domain.Feature) *uuid.UUID
+	// ReleaseUsage cancels the reservation made by ReserveUsage. A nil reservation is ignored.
+	ReleaseUsage(ctx context.Context, reservationID *uuid.UUID)
+}
+
+type usageService struct {
+	usageRepository         repository.UsageRepository
+	subscriptionTierService SubscriptionTierService
+	config                  UsageConfig
+}
+
+func NewUsageService(
+	usageRepository repository.UsageRepository,
+	subscriptionTierService SubscriptionTierService,
+	config UsageConfig) UsageService {
+	return &usageService{
+		usageRepository:         usageRepository,
+		subscriptionTierService: subscriptionTierService,
+		config:                  config,
+	}
+}
+
+func (s *usageService) GetCurrentUsage(ctx context.Context) domain.UsageResponse {
+	subscription := s.subscriptionTierService.GetCurrentSubscription(ctx)
+	periodStart, periodEnd := billingPeriod(subscription, time.Now().UTC())
+	usage := s.usageRepository.CountUsage(ctx, auth.GetUserID(ctx), periodStart)
+
+	features := make([]domain.FeatureUsageResponse, 0, len(usageFeatures))
+	for _, feature := range usageFeatures {
+		quotas, ok := s.config.MonthlyQuotas[feature]
+		if !ok {
+			continue
+		}
+
+		features = append(features, domain.FeatureUsageResponse{
+			Feature: feature,
+			Used:    usage[feature],
+			Quota:   quotas[subscription.Tier],
+		})
+	}
+
+	return domain.UsageResponse{
+		Tier:        subscription.Tier,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Features:    features,
+	}
+}
+
+func (s *usageService) RequireQuota(ctx context.Context, feature domain.Feature) {
+	quotas, ok := s.config.MonthlyQuotas[feature]
+	if !ok {
+		return
+	}
+
+	subscription := localcontext.GetSubscription(ctx)
+	quota := quotas[subscription.Tier]
+
+	periodStart, periodEnd := billingPeriod(subscription, time.Now().UTC())
+	used := s.usageRepository.CountUsage(ctx, auth.GetUserID(ctx), periodStart)[feature]
+	if used < quota {
+		return
+	}
+
+	log.L(ctx).Warnf("Monthly quota of %s is exceeded: %d/%d", feature, used, quota)
+	throwUsageQuotaExceeded(feature, subscription.Tier, quota, periodEnd)
+}
+
+func (s *usageService) ReserveUsage(ctx context.Context, feature domain.Feature) *uuid.UUID {
+	quotas, ok := s.config.MonthlyQuotas[feature]
+	if !ok {
+		return nil
+	}
+
+	subscription := localcontext.GetSubscription(ctx)
+	quota := quotas[subscription.Tier]
+
+	now := time.Now().UTC()
+	periodStart, periodEnd := billingPeriod(subscription, now)
+	reservationID := s.usageRepository.CreateUsageRecordWithinQuota(
+		ctx, auth.GetUserID(ctx), feature, now, periodStart, quota)
+	if reservationID != nil {
+		return reservationID
+	}
+
+	log.L(ctx).Warnf("Monthly quota of %s is exceeded: %d", feature, quota)
+	throwUsageQuotaExceeded(feature, subscription.Tier, quota, periodEnd)
+	return nil
+}
+
+func (s *usageService) ReleaseUsage(ctx context.Context, reservationID *uuid.UUID) {
+	if reservationID == nil {
+		return
+	}
+
+	s.usageRepository.RemoveUsageRecord(ctx, *reservationID)
+}
+
+// releaseUsageOnPanic must be deferred right after the usage is reserved. If the feature fails, it releases
+// the reservation and lets the panic go on.
+func releaseUsageOnPanic(ctx context.Context, usageService UsageService, reservationID *uuid.UUID) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	// The request may be cancelled already, which is one of the reasons the feature could have failed.
+	usageService.ReleaseUsage(context.WithoutCancel(ctx), reservationID)
+	panic(r)
+}
+
+func throwUsageQuotaExceeded(
+	feature domain.Feature, tier domain.SubscriptionTier, quota int, resetsAt time.Time) {
+	myerror.NewWithDetails(myerror.UsageQuotaExceededError, map[string]any{
+		"feature":  feature,
+		"tier":     tier,
+		"quota":    quota,
+		"resetsAt": resetsAt,
+	}).Throw()
+}
+
+// billingPeriod returns the billing period of the subscription which includes now. The users without
+// a subscription, and the subscriptions whose renewal isn't reported yet, are billed by calendar months.
+func billingPeriod(subscription domain.Subscription, now time.Time) (time.Time, time.Time) {
+	if subscription.PeriodStart != nil && subscription.PeriodEnd != nil &&
+		!now.Before(*subscription.PeriodStart) && now.Before(*subscription.PeriodEnd) {
+		return *subscription.PeriodStart, *subscription.PeriodEnd
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return monthStart, monthStart.AddDate(0, 1, 0)
+}
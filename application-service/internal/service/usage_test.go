@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/compendium-tech/compendium/common/pkg/auth"
+
+	localcontext "github.com/compendium-tech/compendium/application-service/internal/context"
+	"github.com/compendium-tech/compendium/application-service/internal/domain"
+	myerror "github.com/compendium-tech/compendium/application-service/internal/error"
+	"github.com/compendium-tech/compendium/application-service/internal/interop"
+	"github.com/compendium-tech/compendium/application-service/internal/repository"
+)
+
+type UsageServiceTestSuite struct {
+	suite.Suite
+	ctx                    context.Context
+	userID                 uuid.UUID
+	subscription           domain.Subscription
+	subscriptionService    *interop.MockSubscriptionService
+	subscriptionRepository *repository.MockSubscriptionRepository
+	usageRepository        *repository.MockUsageRepository
+	service                UsageService
+}
+
+func TestUsageService(t *testing.T) {
+	suite.Run(t, new(UsageServiceTestSuite))
+}
+
+func (s *UsageServiceTestSuite) SetupTest() {
+	s.userID = uuid.New()
+
+	periodStart := time.Now().UTC().Add(-24 * time.Hour)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	s.subscription = domain.Subscription{Tier: domain.TierStudent, PeriodStart: &periodStart, PeriodEnd: &periodEnd}
+
+	s.ctx = context.Background()
+	auth.SetUserID(&s.ctx, s.userID)
+	localcontext.SetSubscription(&s.ctx, s.subscription)
+
+	s.subscriptionService = interop.NewMockSubscriptionService(s.T())
+	s.subscriptionRepository = repository.NewMockSubscriptionRepository(s.T())
+	s.usageRepository = repository.NewMockUsageRepository(s.T())
+
+	s.service = NewUsageService(
+		s.usageRepository,
+		NewSubscriptionTierService(s.subscriptionService, s.subscriptionRepository, SubscriptionTierConfig{}),
+		UsageConfig{
+			MonthlyQuotas: map[domain.Feature]map[domain.SubscriptionTier]int{
+				domain.FeatureApplicationEvaluation: {domain.TierStudent: 20},
+				domain.FeatureEssayCoach:            {domain.TierStudent: 500},
+			},
+		})
+}
+
+func (s *UsageServiceTestSuite) TestGetCurrentUsage() {
+	s.subscriptionRepository.EXPECT().GetSubscription(mock.Anything, s.userID).Return(&s.subscription)
+	s.usageRepository.EXPECT().CountUsage(mock.Anything, s.userID, *s.subscription.PeriodStart).
+		Return(map[domain.Feature]int{domain.FeatureEssayCoach: 42})
+
+	usage := s.service.GetCurrentUsage(s.ctx)
+
+	s.Equal(domain.TierStudent, usage.Tier)
+	s.Equal(*s.subscription.PeriodStart, usage.PeriodStart)
+	s.Equal(*s.subscription.PeriodEnd, usage.PeriodEnd)
+	s.Equal([]domain.FeatureUsageResponse{
+		{Feature: domain.FeatureApplicationEvaluation, Used: 0, Quota: 20},
+		{Feature: domain.FeatureEssayCoach, Used: 42, Quota: 500},
+	}, usage.Features)
+}
+
+func (s *UsageServiceTestSuite) TestRequireQuota() {
+	s.usageRepository.EXPECT().CountUsage(mock.Anything, s.userID, *s.subscription.PeriodStart).
+		Return(map[domain.Feature]int{domain.FeatureApplicationEvaluation: 19})
+
+	s.NotPanics(func() {
+		s.service.RequireQuota(s.ctx, domain.FeatureApplicationEvaluation)
+	})
+}
+
+func (s *UsageServiceTestSuite) TestRequireQuotaExceeded() {
+	s.usageRepository.EXPECT().CountUsage(mock.Anything, s.userID, *s.subscription.PeriodStart).
+		Return(map[domain.Feature]int{domain.FeatureApplicationEvaluation: 20})
+
+	defer func() {
+		err, ok := recover().(myerror.MyError)
+		s.Require().True(ok)
+		s.Equal(myerror.UsageQuotaExceededError, err.ErrorType())
+		s.Equal(map[string]any{
+			"feature":  domain.FeatureApplicationEvaluation,
+			"tier":     domain.TierStudent,
+			"quota":    20,
+			"resetsAt": *s.subscription.PeriodEnd,
+		}, err.ErrorDetails())
+	}()
+
+	s.service.RequireQuota(s.ctx, domain.FeatureApplicationEvaluation)
+}
+
+func (s *UsageServiceTestSuite) TestReserveUsage() {
+	reservationID := uuid.New()
+	s.usageRepository.EXPECT().CreateUsageRecordWithinQuota(mock.Anything, s.userID,
+		domain.FeatureApplicationEvaluation, mock.Anything, *s.subscription.PeriodStart, 20).Return(&reservationID)
+	s.usageRepository.EXPECT().RemoveUsageRecord(mock.Anything, reservationID)
+
+	s.Equal(&reservationID, s.service.ReserveUsage(s.ctx, domain.FeatureApplicationEvaluation))
+	s.service.ReleaseUsage(s.ctx, &reservationID)
+}
+
+func (s *UsageServiceTestSuite) TestReserveUsageExceeded() {
+	s.usageRepository.EXPECT().CreateUsageRecordWithinQuota(mock.Anything, s.userID,
+		domain.FeatureApplicationEvaluation, mock.Anything, *s.subscription.PeriodStart, 20).Return(nil)
+
+	defer func() {
+		err, ok := recover().(myerror.MyError)
+		s.Require().True(ok)
+		s.Equal(myerror.UsageQuotaExceededError, err.ErrorType())
+		s.Equal(map[string]any{
+			"feature":  domain.FeatureApplicationEvaluation,
+			"tier":     domain.TierStudent,
+			"quota":    20,
+			"resetsAt": *s.subscription.PeriodEnd,
+		}, err.ErrorDetails())
+	}()
+
+	s.service.ReserveUsage(s.ctx, domain.FeatureApplicationEvaluation)
+}
+
+func (s *UsageServiceTestSuite) TestReserveUsageSkipsFeaturesWithoutQuotas() {
+	// The repository isn't called.
+	s.Nil(s.service.ReserveUsage(s.ctx, domain.FeatureEssayRewrite))
+	s.service.ReleaseUsage(s.ctx, nil)
+}
+
+func (s *UsageServiceTestSuite) TestBillingPeriod() {
+	now := time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+	periodStart := time.Date(2025, time.March, 10, 8, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, time.April, 10, 8, 0, 0, 0, time.UTC)
+
+	start, end := billingPeriod(domain.Subscription{
+		Tier: domain.TierTeam, PeriodStart: &periodStart, PeriodEnd: &periodEnd}, now)
+	s.Equal(periodStart, start)
+	s.Equal(periodEnd, end)
+
+	// The users without a subscription are billed by calendar months.
+	start, end = billingPeriod(domain.Subscription{Tier: domain.TierNone}, now)
+	s.Equal(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), start)
+	s.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), end)
+
+	// So are the subscriptions whose renewal isn't reported yet.
+	start, _ = billingPeriod(domain.Subscription{
+		Tier: domain.TierTeam, PeriodStart: &periodStart, PeriodEnd: &periodEnd}, periodEnd.Add(time.Hour))
+	s.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), start)
+}
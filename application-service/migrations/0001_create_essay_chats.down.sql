@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS essay_chat_messages;
+
+DROP TYPE IF EXISTS essay_chat_role;
+
+ALTER TABLE essays DROP COLUMN IF EXISTS id;
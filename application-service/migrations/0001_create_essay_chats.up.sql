@@ -0,0 +1,21 @@
+-- Essays get stable IDs, so that the chats with the essay coach survive updates of the essays.
+ALTER TABLE essays ADD COLUMN IF NOT EXISTS id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid();
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_type WHERE typname = 'essay_chat_role') THEN
+        CREATE TYPE essay_chat_role AS ENUM ('user', 'assistant');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS essay_chat_messages (
+  id UUID PRIMARY KEY,
+  application_id UUID NOT NULL REFERENCES applications (id) ON DELETE CASCADE,
+  essay_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  role essay_chat_role NOT NULL,
+  content TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS essay_chat_messages_essay_id_idx ON essay_chat_messages (essay_id, created_at);
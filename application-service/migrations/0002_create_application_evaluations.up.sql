@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS application_evaluations (
+  id UUID PRIMARY KEY,
+  application_id UUID NOT NULL REFERENCES applications (id) ON DELETE CASCADE,
+  -- snapshot_hash identifies the evaluated activities, honors and essays, so that unchanged applications
+  -- aren't evaluated again.
+  snapshot_hash TEXT NOT NULL,
+  prompt_template TEXT NOT NULL,
+  prompt_template_version INTEGER NOT NULL,
+  evaluation JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS application_evaluations_application_id_idx
+  ON application_evaluations (application_id, created_at DESC);
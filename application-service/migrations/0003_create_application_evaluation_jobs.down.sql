@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS application_evaluation_jobs;
+
+DROP TYPE IF EXISTS application_evaluation_job_status;
@@ -0,0 +1,40 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_type WHERE typname = 'application_evaluation_job_status') THEN
+        CREATE TYPE application_evaluation_job_status AS ENUM ('queued', 'running', 'succeeded', 'failed');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS application_evaluation_jobs (
+  id UUID PRIMARY KEY,
+  application_id UUID NOT NULL REFERENCES applications (id) ON DELETE CASCADE,
+  user_id UUID NOT NULL,
+  -- The subscription the job was enqueued with, as the evaluation is run on behalf of the user.
+  subscription_tier TEXT NOT NULL,
+  subscription_period_start TIMESTAMPTZ,
+  subscription_period_end TIMESTAMPTZ,
+  status application_evaluation_job_status NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  evaluation_id UUID REFERENCES application_evaluations (id) ON DELETE SET NULL,
+  -- error_type and error_details are the error the job failed with, as the API would have returned it.
+  error_type INTEGER,
+  error_details JSONB,
+  created_at TIMESTAMPTZ NOT NULL,
+  started_at TIMESTAMPTZ,
+  finished_at TIMESTAMPTZ,
+  -- The jobs retried after llm-service failed aren't claimed before not_before.
+  not_before TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS application_evaluation_jobs_application_id_idx
+  ON application_evaluation_jobs (application_id, created_at DESC);
+
+-- Queued jobs and the running jobs whose lease may expire are claimed by the workers.
+CREATE INDEX IF NOT EXISTS application_evaluation_jobs_unfinished_idx
+  ON application_evaluation_jobs (created_at)
+  WHERE status IN ('queued', 'running');
+
+-- An application is evaluated by a single job at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS application_evaluation_jobs_application_id_unfinished_idx
+  ON application_evaluation_jobs (application_id)
+  WHERE status IN ('queued', 'running');
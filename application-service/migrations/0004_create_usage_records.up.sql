@@ -0,0 +1,9 @@
+-- Every use of a feature with a quota, counted within the billing period of the user.
+CREATE TABLE IF NOT EXISTS usage_records (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL,
+  feature TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS usage_records_user_id_idx ON usage_records (user_id, created_at);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS test_scores;
+DROP TABLE IF EXISTS courses;
+DROP TABLE IF EXISTS academics;
+
+DROP TYPE IF EXISTS test_type;
+DROP TYPE IF EXISTS course_rigor;
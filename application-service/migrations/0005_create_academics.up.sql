@@ -0,0 +1,45 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_type WHERE typname = 'course_rigor') THEN
+        CREATE TYPE course_rigor AS ENUM (
+            'regular', 'honors', 'ap', 'ib_sl', 'ib_hl', 'as_level', 'a_level', 'dual_enrollment'
+        );
+    END IF;
+
+    IF NOT EXISTS (SELECT FROM pg_type WHERE typname = 'test_type') THEN
+        CREATE TYPE test_type AS ENUM ('sat', 'act', 'ielts', 'toefl');
+    END IF;
+END $$;
+
+-- The GPA and the class rank of the application, the courses and the test scores are kept in their own tables.
+CREATE TABLE IF NOT EXISTS academics (
+  application_id UUID PRIMARY KEY REFERENCES applications (id) ON DELETE CASCADE,
+  gpa NUMERIC(5, 2),
+  gpa_scale NUMERIC(5, 2),
+  gpa_weighted BOOLEAN,
+  class_rank INTEGER,
+  class_size INTEGER,
+
+  CHECK ((gpa IS NULL) = (gpa_scale IS NULL) AND (gpa IS NULL) = (gpa_weighted IS NULL)),
+  CHECK ((class_rank IS NULL) = (class_size IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS courses (
+  index INTEGER NOT NULL,
+  application_id UUID NOT NULL REFERENCES applications (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  rigor course_rigor NOT NULL,
+  grade grade NOT NULL,
+  result TEXT,
+
+  PRIMARY KEY (application_id, index)
+);
+
+CREATE TABLE IF NOT EXISTS test_scores (
+  index INTEGER NOT NULL,
+  application_id UUID NOT NULL REFERENCES applications (id) ON DELETE CASCADE,
+  type test_type NOT NULL,
+  score NUMERIC(5, 1) NOT NULL,
+
+  PRIMARY KEY (application_id, index)
+);
@@ -0,0 +1,7 @@
+package domain
+
+// Embeddings are the vectors of the embedded texts, in the same order as the texts.
+type Embeddings struct {
+	Vectors [][]float32
+	Model   string
+}
@@ -0,0 +1,52 @@
+package interop
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/compendium-tech/compendium/college-service/internal/domain"
+	pb "github.com/compendium-tech/compendium/college-service/internal/proto/v1"
+)
+
+// llmServiceCaller is reported to llm-service, which keeps the usage ledger per caller.
+const llmServiceCaller = "college-service"
+
+type LLMService interface {
+	// Embed computes the embeddings of the texts. Empty model means the default embedding model of llm-service.
+	Embed(ctx context.Context, texts []string, model string) domain.Embeddings
+}
+
+type llmServiceGrpcClient struct {
+	client pb.LLMServiceClient
+}
+
+func NewGrpcLLMServiceClient(target string) (LLMService, error) {
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+
+	return &llmServiceGrpcClient{
+		client: pb.NewLLMServiceClient(conn),
+	}, nil
+}
+
+func (c *llmServiceGrpcClient) Embed(ctx context.Context, texts []string, model string) domain.Embeddings {
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-caller", llmServiceCaller)
+
+	resp, err := c.client.Embed(ctx, &pb.EmbedRequest{Texts: texts, Model: model})
+	if err != nil {
+		panic(err)
+	}
+
+	vectors := make([][]float32, len(resp.Embeddings))
+	for i, embedding := range resp.Embeddings {
+		vectors[i] = embedding.Values
+	}
+
+	return domain.Embeddings{Vectors: vectors, Model: resp.Model}
+}
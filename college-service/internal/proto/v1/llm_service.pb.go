@@ -0,0 +1,3787 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v6.32.0
+// source: college-service/proto/llm_service.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Role int32
+
+const (
+	Role_SYSTEM    Role = 0
+	Role_USER      Role = 1
+	Role_ASSISTANT Role = 2
+	Role_TOOL      Role = 3
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "SYSTEM",
+		1: "USER",
+		2: "ASSISTANT",
+		3: "TOOL",
+	}
+	Role_value = map[string]int32{
+		"SYSTEM":    0,
+		"USER":      1,
+		"ASSISTANT": 2,
+		"TOOL":      3,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_college_service_proto_llm_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_college_service_proto_llm_service_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{0}
+}
+
+// ContextOverflowPolicy tells what to do when the chat history doesn't fit into the context window of the model.
+type ContextOverflowPolicy int32
+
+const (
+	// The request fails with the CONTEXT_WINDOW_EXCEEDED reason.
+	ContextOverflowPolicy_CONTEXT_OVERFLOW_REJECT ContextOverflowPolicy = 0
+	// The longest messages are cut to the same length, keeping their beginnings.
+	ContextOverflowPolicy_CONTEXT_OVERFLOW_TRUNCATE ContextOverflowPolicy = 1
+	// The longest messages are replaced with their summaries, generated by the same model
+	// chunk by chunk and then combined.
+	ContextOverflowPolicy_CONTEXT_OVERFLOW_SUMMARIZE ContextOverflowPolicy = 2
+)
+
+// Enum value maps for ContextOverflowPolicy.
+var (
+	ContextOverflowPolicy_name = map[int32]string{
+		0: "CONTEXT_OVERFLOW_REJECT",
+		1: "CONTEXT_OVERFLOW_TRUNCATE",
+		2: "CONTEXT_OVERFLOW_SUMMARIZE",
+	}
+	ContextOverflowPolicy_value = map[string]int32{
+		"CONTEXT_OVERFLOW_REJECT":    0,
+		"CONTEXT_OVERFLOW_TRUNCATE":  1,
+		"CONTEXT_OVERFLOW_SUMMARIZE": 2,
+	}
+)
+
+func (x ContextOverflowPolicy) Enum() *ContextOverflowPolicy {
+	p := new(ContextOverflowPolicy)
+	*p = x
+	return p
+}
+
+func (x ContextOverflowPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContextOverflowPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_college_service_proto_llm_service_proto_enumTypes[1].Descriptor()
+}
+
+func (ContextOverflowPolicy) Type() protoreflect.EnumType {
+	return &file_college_service_proto_llm_service_proto_enumTypes[1]
+}
+
+func (x ContextOverflowPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContextOverflowPolicy.Descriptor instead.
+func (ContextOverflowPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{1}
+}
+
+type CacheStatus int32
+
+const (
+	CacheStatus_CACHE_BYPASS CacheStatus = 0
+	CacheStatus_CACHE_MISS   CacheStatus = 1
+	CacheStatus_CACHE_HIT    CacheStatus = 2
+)
+
+// Enum value maps for CacheStatus.
+var (
+	CacheStatus_name = map[int32]string{
+		0: "CACHE_BYPASS",
+		1: "CACHE_MISS",
+		2: "CACHE_HIT",
+	}
+	CacheStatus_value = map[string]int32{
+		"CACHE_BYPASS": 0,
+		"CACHE_MISS":   1,
+		"CACHE_HIT":    2,
+	}
+)
+
+func (x CacheStatus) Enum() *CacheStatus {
+	p := new(CacheStatus)
+	*p = x
+	return p
+}
+
+func (x CacheStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CacheStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_college_service_proto_llm_service_proto_enumTypes[2].Descriptor()
+}
+
+func (CacheStatus) Type() protoreflect.EnumType {
+	return &file_college_service_proto_llm_service_proto_enumTypes[2]
+}
+
+func (x CacheStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CacheStatus.Descriptor instead.
+func (CacheStatus) EnumDescriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{2}
+}
+
+type SafetyCategory int32
+
+const (
+	SafetyCategory_SELF_HARM SafetyCategory = 0
+	SafetyCategory_ABUSE     SafetyCategory = 1
+)
+
+// Enum value maps for SafetyCategory.
+var (
+	SafetyCategory_name = map[int32]string{
+		0: "SELF_HARM",
+		1: "ABUSE",
+	}
+	SafetyCategory_value = map[string]int32{
+		"SELF_HARM": 0,
+		"ABUSE":     1,
+	}
+)
+
+func (x SafetyCategory) Enum() *SafetyCategory {
+	p := new(SafetyCategory)
+	*p = x
+	return p
+}
+
+func (x SafetyCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SafetyCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_college_service_proto_llm_service_proto_enumTypes[3].Descriptor()
+}
+
+func (SafetyCategory) Type() protoreflect.EnumType {
+	return &file_college_service_proto_llm_service_proto_enumTypes[3]
+}
+
+func (x SafetyCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SafetyCategory.Descriptor instead.
+func (SafetyCategory) EnumDescriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{3}
+}
+
+type BatchStatus int32
+
+const (
+	BatchStatus_BATCH_PENDING BatchStatus = 0
+	BatchStatus_BATCH_RUNNING BatchStatus = 1
+	// All items of the batch succeeded or failed.
+	BatchStatus_BATCH_COMPLETED BatchStatus = 2
+)
+
+// Enum value maps for BatchStatus.
+var (
+	BatchStatus_name = map[int32]string{
+		0: "BATCH_PENDING",
+		1: "BATCH_RUNNING",
+		2: "BATCH_COMPLETED",
+	}
+	BatchStatus_value = map[string]int32{
+		"BATCH_PENDING":   0,
+		"BATCH_RUNNING":   1,
+		"BATCH_COMPLETED": 2,
+	}
+)
+
+func (x BatchStatus) Enum() *BatchStatus {
+	p := new(BatchStatus)
+	*p = x
+	return p
+}
+
+func (x BatchStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_college_service_proto_llm_service_proto_enumTypes[4].Descriptor()
+}
+
+func (BatchStatus) Type() protoreflect.EnumType {
+	return &file_college_service_proto_llm_service_proto_enumTypes[4]
+}
+
+func (x BatchStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchStatus.Descriptor instead.
+func (BatchStatus) EnumDescriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{4}
+}
+
+type BatchItemStatus int32
+
+const (
+	BatchItemStatus_BATCH_ITEM_PENDING   BatchItemStatus = 0
+	BatchItemStatus_BATCH_ITEM_RUNNING   BatchItemStatus = 1
+	BatchItemStatus_BATCH_ITEM_SUCCEEDED BatchItemStatus = 2
+	BatchItemStatus_BATCH_ITEM_FAILED    BatchItemStatus = 3
+)
+
+// Enum value maps for BatchItemStatus.
+var (
+	BatchItemStatus_name = map[int32]string{
+		0: "BATCH_ITEM_PENDING",
+		1: "BATCH_ITEM_RUNNING",
+		2: "BATCH_ITEM_SUCCEEDED",
+		3: "BATCH_ITEM_FAILED",
+	}
+	BatchItemStatus_value = map[string]int32{
+		"BATCH_ITEM_PENDING":   0,
+		"BATCH_ITEM_RUNNING":   1,
+		"BATCH_ITEM_SUCCEEDED": 2,
+		"BATCH_ITEM_FAILED":    3,
+	}
+)
+
+func (x BatchItemStatus) Enum() *BatchItemStatus {
+	p := new(BatchItemStatus)
+	*p = x
+	return p
+}
+
+func (x BatchItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_college_service_proto_llm_service_proto_enumTypes[5].Descriptor()
+}
+
+func (BatchItemStatus) Type() protoreflect.EnumType {
+	return &file_college_service_proto_llm_service_proto_enumTypes[5]
+}
+
+func (x BatchItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchItemStatus.Descriptor instead.
+func (BatchItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{5}
+}
+
+// ConversationHistoryPolicy tells what to do with the earliest messages of a conversation once its history
+// exceeds max_history_tokens. The leading system messages are always kept.
+type ConversationHistoryPolicy int32
+
+const (
+	// The whole history is sent, subject to the context overflow policy of the generation options.
+	ConversationHistoryPolicy_CONVERSATION_HISTORY_KEEP_ALL ConversationHistoryPolicy = 0
+	// The earliest messages are no longer sent to the model.
+	ConversationHistoryPolicy_CONVERSATION_HISTORY_TRIM ConversationHistoryPolicy = 1
+	// The earliest messages are replaced with their summary, which is updated as the conversation goes on.
+	ConversationHistoryPolicy_CONVERSATION_HISTORY_SUMMARIZE ConversationHistoryPolicy = 2
+)
+
+// Enum value maps for ConversationHistoryPolicy.
+var (
+	ConversationHistoryPolicy_name = map[int32]string{
+		0: "CONVERSATION_HISTORY_KEEP_ALL",
+		1: "CONVERSATION_HISTORY_TRIM",
+		2: "CONVERSATION_HISTORY_SUMMARIZE",
+	}
+	ConversationHistoryPolicy_value = map[string]int32{
+		"CONVERSATION_HISTORY_KEEP_ALL":  0,
+		"CONVERSATION_HISTORY_TRIM":      1,
+		"CONVERSATION_HISTORY_SUMMARIZE": 2,
+	}
+)
+
+func (x ConversationHistoryPolicy) Enum() *ConversationHistoryPolicy {
+	p := new(ConversationHistoryPolicy)
+	*p = x
+	return p
+}
+
+func (x ConversationHistoryPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConversationHistoryPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_college_service_proto_llm_service_proto_enumTypes[6].Descriptor()
+}
+
+func (ConversationHistoryPolicy) Type() protoreflect.EnumType {
+	return &file_college_service_proto_llm_service_proto_enumTypes[6]
+}
+
+func (x ConversationHistoryPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConversationHistoryPolicy.Descriptor instead.
+func (ConversationHistoryPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{6}
+}
+
+type Type struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Type) Reset() {
+	*x = Type{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Type) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type) ProtoMessage() {}
+
+func (x *Type) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type.ProtoReflect.Descriptor instead.
+func (*Type) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Type) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ToolCall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Parameters    map[string]*anypb.Any  `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCall) Reset() {
+	*x = ToolCall{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCall) ProtoMessage() {}
+
+func (x *ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
+func (*ToolCall) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ToolCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ToolCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolCall) GetParameters() map[string]*anypb.Any {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// ToolResult answers the tool call with the given ID made by the assistant.
+type ToolResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ToolCallId    string                 `protobuf:"bytes,1,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Result        map[string]*anypb.Any  `protobuf:"bytes,3,rep,name=result,proto3" json:"result,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolResult) Reset() {
+	*x = ToolResult{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolResult) ProtoMessage() {}
+
+func (x *ToolResult) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolResult.ProtoReflect.Descriptor instead.
+func (*ToolResult) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ToolResult) GetToolCallId() string {
+	if x != nil {
+		return x.ToolCallId
+	}
+	return ""
+}
+
+func (x *ToolResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolResult) GetResult() map[string]*anypb.Any {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Attachment is a document or an image sent to the model together with the text of the message.
+type Attachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MIME type of the content, e.g. application/pdf or image/png.
+	MimeType string `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*Attachment_Data
+	//	*Attachment_Uri
+	Source        isAttachment_Source `protobuf_oneof:"source"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Attachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSource() isAttachment_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *Attachment) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Source.(*Attachment_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *Attachment) GetUri() string {
+	if x != nil {
+		if x, ok := x.Source.(*Attachment_Uri); ok {
+			return x.Uri
+		}
+	}
+	return ""
+}
+
+type isAttachment_Source interface {
+	isAttachment_Source()
+}
+
+type Attachment_Data struct {
+	// Content sent inline, at most 20 MB, which is the limit of inline data of the providers.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+type Attachment_Uri struct {
+	// Reference to the content uploaded to the provider, e.g. a URI of the Gemini Files API,
+	// or a public https:// URI.
+	Uri string `protobuf:"bytes,3,opt,name=uri,proto3,oneof"`
+}
+
+func (*Attachment_Data) isAttachment_Source() {}
+
+func (*Attachment_Uri) isAttachment_Source() {}
+
+type Message struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Role      Role                   `protobuf:"varint,1,opt,name=role,proto3,enum=llm_service.v1.Role" json:"role,omitempty"`
+	Text      string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	ToolCalls []*ToolCall            `protobuf:"bytes,3,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	// Only set in messages with the TOOL role.
+	ToolResult *ToolResult `protobuf:"bytes,4,opt,name=tool_result,json=toolResult,proto3" json:"tool_result,omitempty"`
+	// Only supported in messages with the USER role.
+	Attachments   []*Attachment `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Message) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_SYSTEM
+}
+
+func (x *Message) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetToolCalls() []*ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *Message) GetToolResult() *ToolResult {
+	if x != nil {
+		return x.ToolResult
+	}
+	return nil
+}
+
+func (x *Message) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// Deprecated: tool parameters are described by ToolDefinition.parameters_schema, which supports enums.
+type ToolParameter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          *Type                  `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	IsRequired    bool                   `protobuf:"varint,4,opt,name=is_required,json=isRequired,proto3" json:"is_required,omitempty"`
+	Enum          []string               `protobuf:"bytes,5,rep,name=enum,proto3" json:"enum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolParameter) Reset() {
+	*x = ToolParameter{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolParameter) ProtoMessage() {}
+
+func (x *ToolParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolParameter.ProtoReflect.Descriptor instead.
+func (*ToolParameter) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ToolParameter) GetType() *Type {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *ToolParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolParameter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ToolParameter) GetIsRequired() bool {
+	if x != nil {
+		return x.IsRequired
+	}
+	return false
+}
+
+func (x *ToolParameter) GetEnum() []string {
+	if x != nil {
+		return x.Enum
+	}
+	return nil
+}
+
+type ToolDefinition struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description      string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ParametersSchema *Schema                `protobuf:"bytes,3,opt,name=parameters_schema,json=parametersSchema,proto3" json:"parameters_schema,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ToolDefinition) Reset() {
+	*x = ToolDefinition{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolDefinition) ProtoMessage() {}
+
+func (x *ToolDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolDefinition.ProtoReflect.Descriptor instead.
+func (*ToolDefinition) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ToolDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetParametersSchema() *Schema {
+	if x != nil {
+		return x.ParametersSchema
+	}
+	return nil
+}
+
+// Schema is a subset of OpenAPI schema supported by the providers. Type may be unset
+// when the value is described by any_of.
+type Schema struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Type        *Type                  `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Properties  map[string]*Schema     `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Items       *Schema                `protobuf:"bytes,4,opt,name=items,proto3" json:"items,omitempty"`
+	MaxItems    *int64                 `protobuf:"varint,5,opt,name=max_items,json=maxItems,proto3,oneof" json:"max_items,omitempty"`
+	MinItems    *int64                 `protobuf:"varint,6,opt,name=min_items,json=minItems,proto3,oneof" json:"min_items,omitempty"`
+	Required    []string               `protobuf:"bytes,7,rep,name=required,proto3" json:"required,omitempty"`
+	// Allowed values of a string.
+	Enum     []string `protobuf:"bytes,8,rep,name=enum,proto3" json:"enum,omitempty"`
+	Nullable bool     `protobuf:"varint,9,opt,name=nullable,proto3" json:"nullable,omitempty"`
+	// Bounds of a number or an integer, inclusive.
+	Minimum *float64 `protobuf:"fixed64,10,opt,name=minimum,proto3,oneof" json:"minimum,omitempty"`
+	Maximum *float64 `protobuf:"fixed64,11,opt,name=maximum,proto3,oneof" json:"maximum,omitempty"`
+	// Format of a string, for example "date-time".
+	Format string `protobuf:"bytes,12,opt,name=format,proto3" json:"format,omitempty"`
+	// Order in which the properties are generated, which affects the quality of the output.
+	PropertyOrdering []string `protobuf:"bytes,13,rep,name=property_ordering,json=propertyOrdering,proto3" json:"property_ordering,omitempty"`
+	// The value must conform to at least one of the schemas.
+	AnyOf         []*Schema `protobuf:"bytes,14,rep,name=any_of,json=anyOf,proto3" json:"any_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schema) Reset() {
+	*x = Schema{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schema) ProtoMessage() {}
+
+func (x *Schema) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schema.ProtoReflect.Descriptor instead.
+func (*Schema) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Schema) GetType() *Type {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *Schema) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Schema) GetProperties() map[string]*Schema {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Schema) GetItems() *Schema {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Schema) GetMaxItems() int64 {
+	if x != nil && x.MaxItems != nil {
+		return *x.MaxItems
+	}
+	return 0
+}
+
+func (x *Schema) GetMinItems() int64 {
+	if x != nil && x.MinItems != nil {
+		return *x.MinItems
+	}
+	return 0
+}
+
+func (x *Schema) GetRequired() []string {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+func (x *Schema) GetEnum() []string {
+	if x != nil {
+		return x.Enum
+	}
+	return nil
+}
+
+func (x *Schema) GetNullable() bool {
+	if x != nil {
+		return x.Nullable
+	}
+	return false
+}
+
+func (x *Schema) GetMinimum() float64 {
+	if x != nil && x.Minimum != nil {
+		return *x.Minimum
+	}
+	return 0
+}
+
+func (x *Schema) GetMaximum() float64 {
+	if x != nil && x.Maximum != nil {
+		return *x.Maximum
+	}
+	return 0
+}
+
+func (x *Schema) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Schema) GetPropertyOrdering() []string {
+	if x != nil {
+		return x.PropertyOrdering
+	}
+	return nil
+}
+
+func (x *Schema) GetAnyOf() []*Schema {
+	if x != nil {
+		return x.AnyOf
+	}
+	return nil
+}
+
+// Unset fields fall back to the defaults of the provider. Temperature defaults to 0
+// to keep the responses deterministic.
+type GenerationOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Model           string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Temperature     *float32               `protobuf:"fixed32,2,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	TopP            *float32               `protobuf:"fixed32,3,opt,name=top_p,json=topP,proto3,oneof" json:"top_p,omitempty"`
+	MaxOutputTokens *int32                 `protobuf:"varint,4,opt,name=max_output_tokens,json=maxOutputTokens,proto3,oneof" json:"max_output_tokens,omitempty"`
+	StopSequences   []string               `protobuf:"bytes,5,rep,name=stop_sequences,json=stopSequences,proto3" json:"stop_sequences,omitempty"`
+	Seed            *int32                 `protobuf:"varint,6,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	// Disables the response cache, which is also bypassed for non-zero temperature.
+	NoCache               bool                  `protobuf:"varint,7,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+	ContextOverflowPolicy ContextOverflowPolicy `protobuf:"varint,8,opt,name=context_overflow_policy,json=contextOverflowPolicy,proto3,enum=llm_service.v1.ContextOverflowPolicy" json:"context_overflow_policy,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GenerationOptions) Reset() {
+	*x = GenerationOptions{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationOptions) ProtoMessage() {}
+
+func (x *GenerationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationOptions.ProtoReflect.Descriptor instead.
+func (*GenerationOptions) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GenerationOptions) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GenerationOptions) GetTemperature() float32 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetTopP() float32 {
+	if x != nil && x.TopP != nil {
+		return *x.TopP
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetMaxOutputTokens() int32 {
+	if x != nil && x.MaxOutputTokens != nil {
+		return *x.MaxOutputTokens
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetStopSequences() []string {
+	if x != nil {
+		return x.StopSequences
+	}
+	return nil
+}
+
+func (x *GenerationOptions) GetSeed() int32 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+func (x *GenerationOptions) GetContextOverflowPolicy() ContextOverflowPolicy {
+	if x != nil {
+		return x.ContextOverflowPolicy
+	}
+	return ContextOverflowPolicy_CONTEXT_OVERFLOW_REJECT
+}
+
+type GenerateResponseRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ChatHistory            []*Message             `protobuf:"bytes,1,rep,name=chat_history,json=chatHistory,proto3" json:"chat_history,omitempty"`
+	Tools                  []*ToolDefinition      `protobuf:"bytes,2,rep,name=tools,proto3" json:"tools,omitempty"`
+	StructuredOutputSchema *Schema                `protobuf:"bytes,3,opt,name=structured_output_schema,json=structuredOutputSchema,proto3" json:"structured_output_schema,omitempty"`
+	GenerationOptions      *GenerationOptions     `protobuf:"bytes,4,opt,name=generation_options,json=generationOptions,proto3" json:"generation_options,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GenerateResponseRequest) Reset() {
+	*x = GenerateResponseRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponseRequest) ProtoMessage() {}
+
+func (x *GenerateResponseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponseRequest.ProtoReflect.Descriptor instead.
+func (*GenerateResponseRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GenerateResponseRequest) GetChatHistory() []*Message {
+	if x != nil {
+		return x.ChatHistory
+	}
+	return nil
+}
+
+func (x *GenerateResponseRequest) GetTools() []*ToolDefinition {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *GenerateResponseRequest) GetStructuredOutputSchema() *Schema {
+	if x != nil {
+		return x.StructuredOutputSchema
+	}
+	return nil
+}
+
+func (x *GenerateResponseRequest) GetGenerationOptions() *GenerationOptions {
+	if x != nil {
+		return x.GenerationOptions
+	}
+	return nil
+}
+
+// Responses served from the cache report zero tokens, as nothing was spent on them.
+type Usage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PromptTokens     int32                  `protobuf:"varint,1,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int32                  `protobuf:"varint,2,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	Model            string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	LatencyMs        int64                  `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	CacheStatus      CacheStatus            `protobuf:"varint,5,opt,name=cache_status,json=cacheStatus,proto3,enum=llm_service.v1.CacheStatus" json:"cache_status,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Usage) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCompletionTokens() int32 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Usage) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *Usage) GetCacheStatus() CacheStatus {
+	if x != nil {
+		return x.CacheStatus
+	}
+	return CacheStatus_CACHE_BYPASS
+}
+
+// TrimmedMessage is a message of the chat history which was shortened to fit into the context window.
+// The token counts of single messages are estimates.
+type TrimmedMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the message in the chat history of the request.
+	Index          int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	OriginalTokens int32 `protobuf:"varint,2,opt,name=original_tokens,json=originalTokens,proto3" json:"original_tokens,omitempty"`
+	Tokens         int32 `protobuf:"varint,3,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TrimmedMessage) Reset() {
+	*x = TrimmedMessage{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrimmedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrimmedMessage) ProtoMessage() {}
+
+func (x *TrimmedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrimmedMessage.ProtoReflect.Descriptor instead.
+func (*TrimmedMessage) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TrimmedMessage) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TrimmedMessage) GetOriginalTokens() int32 {
+	if x != nil {
+		return x.OriginalTokens
+	}
+	return 0
+}
+
+func (x *TrimmedMessage) GetTokens() int32 {
+	if x != nil {
+		return x.Tokens
+	}
+	return 0
+}
+
+// ContextTrimming reports how the chat history was shortened to fit into the context window of the model.
+type ContextTrimming struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Policy               ContextOverflowPolicy  `protobuf:"varint,1,opt,name=policy,proto3,enum=llm_service.v1.ContextOverflowPolicy" json:"policy,omitempty"`
+	OriginalPromptTokens int32                  `protobuf:"varint,2,opt,name=original_prompt_tokens,json=originalPromptTokens,proto3" json:"original_prompt_tokens,omitempty"`
+	PromptTokens         int32                  `protobuf:"varint,3,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	InputTokenLimit      int32                  `protobuf:"varint,4,opt,name=input_token_limit,json=inputTokenLimit,proto3" json:"input_token_limit,omitempty"`
+	Messages             []*TrimmedMessage      `protobuf:"bytes,5,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ContextTrimming) Reset() {
+	*x = ContextTrimming{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContextTrimming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContextTrimming) ProtoMessage() {}
+
+func (x *ContextTrimming) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContextTrimming.ProtoReflect.Descriptor instead.
+func (*ContextTrimming) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ContextTrimming) GetPolicy() ContextOverflowPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return ContextOverflowPolicy_CONTEXT_OVERFLOW_REJECT
+}
+
+func (x *ContextTrimming) GetOriginalPromptTokens() int32 {
+	if x != nil {
+		return x.OriginalPromptTokens
+	}
+	return 0
+}
+
+func (x *ContextTrimming) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *ContextTrimming) GetInputTokenLimit() int32 {
+	if x != nil {
+		return x.InputTokenLimit
+	}
+	return 0
+}
+
+func (x *ContextTrimming) GetMessages() []*TrimmedMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// SafetyFlag marks a disclosure of self-harm or abuse in a message of the chat history,
+// which a counselor should be alerted about.
+type SafetyFlag struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category SafetyCategory         `protobuf:"varint,1,opt,name=category,proto3,enum=llm_service.v1.SafetyCategory" json:"category,omitempty"`
+	// Index of the message in the chat history of the request.
+	MessageIndex int32 `protobuf:"varint,2,opt,name=message_index,json=messageIndex,proto3" json:"message_index,omitempty"`
+	// The matched phrase, without the surrounding text.
+	Phrase        string `protobuf:"bytes,3,opt,name=phrase,proto3" json:"phrase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SafetyFlag) Reset() {
+	*x = SafetyFlag{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SafetyFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SafetyFlag) ProtoMessage() {}
+
+func (x *SafetyFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SafetyFlag.ProtoReflect.Descriptor instead.
+func (*SafetyFlag) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SafetyFlag) GetCategory() SafetyCategory {
+	if x != nil {
+		return x.Category
+	}
+	return SafetyCategory_SELF_HARM
+}
+
+func (x *SafetyFlag) GetMessageIndex() int32 {
+	if x != nil {
+		return x.MessageIndex
+	}
+	return 0
+}
+
+func (x *SafetyFlag) GetPhrase() string {
+	if x != nil {
+		return x.Phrase
+	}
+	return ""
+}
+
+// Context trimming is only set if the chat history had to be shortened. The usage includes
+// the tokens spent on summarizing it. Safety flags are only set if the prompts of the caller
+// are moderated.
+type GenerateResponseResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Message         *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Usage           *Usage                 `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	ContextTrimming *ContextTrimming       `protobuf:"bytes,3,opt,name=context_trimming,json=contextTrimming,proto3" json:"context_trimming,omitempty"`
+	SafetyFlags     []*SafetyFlag          `protobuf:"bytes,4,rep,name=safety_flags,json=safetyFlags,proto3" json:"safety_flags,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerateResponseResponse) Reset() {
+	*x = GenerateResponseResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponseResponse) ProtoMessage() {}
+
+func (x *GenerateResponseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponseResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponseResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GenerateResponseResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *GenerateResponseResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *GenerateResponseResponse) GetContextTrimming() *ContextTrimming {
+	if x != nil {
+		return x.ContextTrimming
+	}
+	return nil
+}
+
+func (x *GenerateResponseResponse) GetSafetyFlags() []*SafetyFlag {
+	if x != nil {
+		return x.SafetyFlags
+	}
+	return nil
+}
+
+// Usage, context trimming and safety flags are only set in the last message of the stream.
+type GenerateResponseStreamResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TextDelta       string                 `protobuf:"bytes,1,opt,name=text_delta,json=textDelta,proto3" json:"text_delta,omitempty"`
+	ToolCalls       []*ToolCall            `protobuf:"bytes,2,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	Usage           *Usage                 `protobuf:"bytes,3,opt,name=usage,proto3" json:"usage,omitempty"`
+	ContextTrimming *ContextTrimming       `protobuf:"bytes,4,opt,name=context_trimming,json=contextTrimming,proto3" json:"context_trimming,omitempty"`
+	SafetyFlags     []*SafetyFlag          `protobuf:"bytes,5,rep,name=safety_flags,json=safetyFlags,proto3" json:"safety_flags,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerateResponseStreamResponse) Reset() {
+	*x = GenerateResponseStreamResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponseStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponseStreamResponse) ProtoMessage() {}
+
+func (x *GenerateResponseStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponseStreamResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponseStreamResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GenerateResponseStreamResponse) GetTextDelta() string {
+	if x != nil {
+		return x.TextDelta
+	}
+	return ""
+}
+
+func (x *GenerateResponseStreamResponse) GetToolCalls() []*ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *GenerateResponseStreamResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *GenerateResponseStreamResponse) GetContextTrimming() *ContextTrimming {
+	if x != nil {
+		return x.ContextTrimming
+	}
+	return nil
+}
+
+func (x *GenerateResponseStreamResponse) GetSafetyFlags() []*SafetyFlag {
+	if x != nil {
+		return x.SafetyFlags
+	}
+	return nil
+}
+
+type GetAdmissionStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAdmissionStatusRequest) Reset() {
+	*x = GetAdmissionStatusRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAdmissionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAdmissionStatusRequest) ProtoMessage() {}
+
+func (x *GetAdmissionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAdmissionStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetAdmissionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{16}
+}
+
+// GetAdmissionStatusResponse reports the load of llm-service: how many requests are processed
+// and how many are waiting for their turn.
+type GetAdmissionStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InFlight      int32                  `protobuf:"varint,1,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	Queued        int32                  `protobuf:"varint,2,opt,name=queued,proto3" json:"queued,omitempty"`
+	MaxConcurrent int32                  `protobuf:"varint,3,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	MaxQueued     int32                  `protobuf:"varint,4,opt,name=max_queued,json=maxQueued,proto3" json:"max_queued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAdmissionStatusResponse) Reset() {
+	*x = GetAdmissionStatusResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAdmissionStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAdmissionStatusResponse) ProtoMessage() {}
+
+func (x *GetAdmissionStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAdmissionStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetAdmissionStatusResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAdmissionStatusResponse) GetInFlight() int32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *GetAdmissionStatusResponse) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *GetAdmissionStatusResponse) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *GetAdmissionStatusResponse) GetMaxQueued() int32 {
+	if x != nil {
+		return x.MaxQueued
+	}
+	return 0
+}
+
+// Empty caller and user_id match all callers and users.
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	Caller        string                 `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetUsageRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetUsageRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetUsageRequest) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UsageAggregate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Day              *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Caller           string                 `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	UserId           string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Requests         int64                  `protobuf:"varint,4,opt,name=requests,proto3" json:"requests,omitempty"`
+	PromptTokens     int64                  `protobuf:"varint,5,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int64                  `protobuf:"varint,6,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UsageAggregate) Reset() {
+	*x = UsageAggregate{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageAggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageAggregate) ProtoMessage() {}
+
+func (x *UsageAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageAggregate.ProtoReflect.Descriptor instead.
+func (*UsageAggregate) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UsageAggregate) GetDay() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Day
+	}
+	return nil
+}
+
+func (x *UsageAggregate) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *UsageAggregate) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UsageAggregate) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *UsageAggregate) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *UsageAggregate) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aggregates    []*UsageAggregate      `protobuf:"bytes,1,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetUsageResponse) GetAggregates() []*UsageAggregate {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+// Empty model means the default embedding model of the provider.
+type EmbedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Texts         []string               `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedRequest) Reset() {
+	*x = EmbedRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedRequest) ProtoMessage() {}
+
+func (x *EmbedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedRequest.ProtoReflect.Descriptor instead.
+func (*EmbedRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EmbedRequest) GetTexts() []string {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+func (x *EmbedRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type Embedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Embedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Embeddings are in the same order as the texts in the request.
+type EmbedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Embeddings    []*Embedding           `protobuf:"bytes,1,rep,name=embeddings,proto3" json:"embeddings,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedResponse) Reset() {
+	*x = EmbedResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedResponse) ProtoMessage() {}
+
+func (x *EmbedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedResponse.ProtoReflect.Descriptor instead.
+func (*EmbedResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EmbedResponse) GetEmbeddings() []*Embedding {
+	if x != nil {
+		return x.Embeddings
+	}
+	return nil
+}
+
+func (x *EmbedResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type RenderPromptTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Zero means the latest version of the template.
+	Version       int32            `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Variables     *structpb.Struct `protobuf:"bytes,3,opt,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderPromptTemplateRequest) Reset() {
+	*x = RenderPromptTemplateRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderPromptTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPromptTemplateRequest) ProtoMessage() {}
+
+func (x *RenderPromptTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPromptTemplateRequest.ProtoReflect.Descriptor instead.
+func (*RenderPromptTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RenderPromptTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenderPromptTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RenderPromptTemplateRequest) GetVariables() *structpb.Struct {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type RenderPromptTemplateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// The rendered version, which callers record with the results generated from the prompt.
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderPromptTemplateResponse) Reset() {
+	*x = RenderPromptTemplateResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderPromptTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPromptTemplateResponse) ProtoMessage() {}
+
+func (x *RenderPromptTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPromptTemplateResponse.ProtoReflect.Descriptor instead.
+func (*RenderPromptTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RenderPromptTemplateResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *RenderPromptTemplateResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Templates are immutable, so changing a template creates its next version.
+type CreatePromptTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Body in the syntax of Go text/template.
+	Body          string `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromptTemplateRequest) Reset() {
+	*x = CreatePromptTemplateRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromptTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromptTemplateRequest) ProtoMessage() {}
+
+func (x *CreatePromptTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromptTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromptTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreatePromptTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePromptTemplateRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type CreatePromptTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromptTemplateResponse) Reset() {
+	*x = CreatePromptTemplateResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromptTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromptTemplateResponse) ProtoMessage() {}
+
+func (x *CreatePromptTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromptTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreatePromptTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreatePromptTemplateResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type BatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the request to the caller, which doesn't have to be unique.
+	CustomId      string                   `protobuf:"bytes,1,opt,name=custom_id,json=customId,proto3" json:"custom_id,omitempty"`
+	Request       *GenerateResponseRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRequest) Reset() {
+	*x = BatchRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRequest) ProtoMessage() {}
+
+func (x *BatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRequest.ProtoReflect.Descriptor instead.
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BatchRequest) GetCustomId() string {
+	if x != nil {
+		return x.CustomId
+	}
+	return ""
+}
+
+func (x *BatchRequest) GetRequest() *GenerateResponseRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// The requests of a batch are independent and generated asynchronously, in any order.
+type SubmitBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*BatchRequest        `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitBatchRequest) Reset() {
+	*x = SubmitBatchRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBatchRequest) ProtoMessage() {}
+
+func (x *SubmitBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitBatchRequest.ProtoReflect.Descriptor instead.
+func (*SubmitBatchRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SubmitBatchRequest) GetRequests() []*BatchRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type Batch struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         BatchStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=llm_service.v1.BatchStatus" json:"status,omitempty"`
+	TotalItems     int32                  `protobuf:"varint,3,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	SucceededItems int32                  `protobuf:"varint,4,opt,name=succeeded_items,json=succeededItems,proto3" json:"succeeded_items,omitempty"`
+	FailedItems    int32                  `protobuf:"varint,5,opt,name=failed_items,json=failedItems,proto3" json:"failed_items,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Only set if the batch is completed.
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Batch) Reset() {
+	*x = Batch{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Batch) ProtoMessage() {}
+
+func (x *Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Batch.ProtoReflect.Descriptor instead.
+func (*Batch) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Batch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Batch) GetStatus() BatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchStatus_BATCH_PENDING
+}
+
+func (x *Batch) GetTotalItems() int32 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *Batch) GetSucceededItems() int32 {
+	if x != nil {
+		return x.SucceededItems
+	}
+	return 0
+}
+
+func (x *Batch) GetFailedItems() int32 {
+	if x != nil {
+		return x.FailedItems
+	}
+	return 0
+}
+
+func (x *Batch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Batch) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type SubmitBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Batch         *Batch                 `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitBatchResponse) Reset() {
+	*x = SubmitBatchResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBatchResponse) ProtoMessage() {}
+
+func (x *SubmitBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitBatchResponse.ProtoReflect.Descriptor instead.
+func (*SubmitBatchResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SubmitBatchResponse) GetBatch() *Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+// BatchItem is the result of a request of the batch. Response is only set if the item succeeded
+// and error only if it failed.
+type BatchItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the request in the submitted batch.
+	Index         int32                     `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	CustomId      string                    `protobuf:"bytes,2,opt,name=custom_id,json=customId,proto3" json:"custom_id,omitempty"`
+	Status        BatchItemStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=llm_service.v1.BatchItemStatus" json:"status,omitempty"`
+	Response      *GenerateResponseResponse `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
+	Error         string                    `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BatchItem) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItem) GetCustomId() string {
+	if x != nil {
+		return x.CustomId
+	}
+	return ""
+}
+
+func (x *BatchItem) GetStatus() BatchItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchItemStatus_BATCH_ITEM_PENDING
+}
+
+func (x *BatchItem) GetResponse() *GenerateResponseResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *BatchItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Batches are only visible to the caller which submitted them.
+type GetBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchRequest) Reset() {
+	*x = GetBatchRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchRequest) ProtoMessage() {}
+
+func (x *GetBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetBatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Items are ordered by index.
+type GetBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Batch         *Batch                 `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	Items         []*BatchItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchResponse) Reset() {
+	*x = GetBatchResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchResponse) ProtoMessage() {}
+
+func (x *GetBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetBatchResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetBatchResponse) GetBatch() *Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *GetBatchResponse) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WatchBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBatchRequest) Reset() {
+	*x = WatchBatchRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBatchRequest) ProtoMessage() {}
+
+func (x *WatchBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchBatchRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WatchBatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Conversation struct {
+	state            protoimpl.MessageState    `protogen:"open.v1"`
+	Id               string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	HistoryPolicy    ConversationHistoryPolicy `protobuf:"varint,2,opt,name=history_policy,json=historyPolicy,proto3,enum=llm_service.v1.ConversationHistoryPolicy" json:"history_policy,omitempty"`
+	MaxHistoryTokens int32                     `protobuf:"varint,3,opt,name=max_history_tokens,json=maxHistoryTokens,proto3" json:"max_history_tokens,omitempty"`
+	MessageCount     int32                     `protobuf:"varint,4,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	// Number of the messages following the leading system messages which are trimmed or summarized.
+	CompactedMessages int32                  `protobuf:"varint,5,opt,name=compacted_messages,json=compactedMessages,proto3" json:"compacted_messages,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Conversations expire some time after their last message.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Conversation) Reset() {
+	*x = Conversation{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conversation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversation) ProtoMessage() {}
+
+func (x *Conversation) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
+func (*Conversation) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Conversation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Conversation) GetHistoryPolicy() ConversationHistoryPolicy {
+	if x != nil {
+		return x.HistoryPolicy
+	}
+	return ConversationHistoryPolicy_CONVERSATION_HISTORY_KEEP_ALL
+}
+
+func (x *Conversation) GetMaxHistoryTokens() int32 {
+	if x != nil {
+		return x.MaxHistoryTokens
+	}
+	return 0
+}
+
+func (x *Conversation) GetMessageCount() int32 {
+	if x != nil {
+		return x.MessageCount
+	}
+	return 0
+}
+
+func (x *Conversation) GetCompactedMessages() int32 {
+	if x != nil {
+		return x.CompactedMessages
+	}
+	return 0
+}
+
+func (x *Conversation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Conversation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateConversationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Initial messages, usually the system prompt.
+	Messages      []*Message                `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	HistoryPolicy ConversationHistoryPolicy `protobuf:"varint,2,opt,name=history_policy,json=historyPolicy,proto3,enum=llm_service.v1.ConversationHistoryPolicy" json:"history_policy,omitempty"`
+	// Estimated size of the history above which it is trimmed or summarized. Zero means the default
+	// of llm-service.
+	MaxHistoryTokens int32 `protobuf:"varint,3,opt,name=max_history_tokens,json=maxHistoryTokens,proto3" json:"max_history_tokens,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateConversationRequest) Reset() {
+	*x = CreateConversationRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConversationRequest) ProtoMessage() {}
+
+func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConversationRequest.ProtoReflect.Descriptor instead.
+func (*CreateConversationRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateConversationRequest) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *CreateConversationRequest) GetHistoryPolicy() ConversationHistoryPolicy {
+	if x != nil {
+		return x.HistoryPolicy
+	}
+	return ConversationHistoryPolicy_CONVERSATION_HISTORY_KEEP_ALL
+}
+
+func (x *CreateConversationRequest) GetMaxHistoryTokens() int32 {
+	if x != nil {
+		return x.MaxHistoryTokens
+	}
+	return 0
+}
+
+type CreateConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConversationResponse) Reset() {
+	*x = CreateConversationResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConversationResponse) ProtoMessage() {}
+
+func (x *CreateConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConversationResponse.ProtoReflect.Descriptor instead.
+func (*CreateConversationResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateConversationResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+// Conversations are only visible to the caller which created them.
+type AppendConversationMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Messages       []*Message             `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AppendConversationMessagesRequest) Reset() {
+	*x = AppendConversationMessagesRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendConversationMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendConversationMessagesRequest) ProtoMessage() {}
+
+func (x *AppendConversationMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendConversationMessagesRequest.ProtoReflect.Descriptor instead.
+func (*AppendConversationMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AppendConversationMessagesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *AppendConversationMessagesRequest) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type AppendConversationMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendConversationMessagesResponse) Reset() {
+	*x = AppendConversationMessagesResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendConversationMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendConversationMessagesResponse) ProtoMessage() {}
+
+func (x *AppendConversationMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendConversationMessagesResponse.ProtoReflect.Descriptor instead.
+func (*AppendConversationMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AppendConversationMessagesResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+// The new messages are appended to the conversation together with the generated message, only if
+// the generation succeeds.
+type GenerateConversationMessageRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId         string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Messages               []*Message             `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	Tools                  []*ToolDefinition      `protobuf:"bytes,3,rep,name=tools,proto3" json:"tools,omitempty"`
+	StructuredOutputSchema *Schema                `protobuf:"bytes,4,opt,name=structured_output_schema,json=structuredOutputSchema,proto3" json:"structured_output_schema,omitempty"`
+	GenerationOptions      *GenerationOptions     `protobuf:"bytes,5,opt,name=generation_options,json=generationOptions,proto3" json:"generation_options,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GenerateConversationMessageRequest) Reset() {
+	*x = GenerateConversationMessageRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateConversationMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateConversationMessageRequest) ProtoMessage() {}
+
+func (x *GenerateConversationMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateConversationMessageRequest.ProtoReflect.Descriptor instead.
+func (*GenerateConversationMessageRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GenerateConversationMessageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *GenerateConversationMessageRequest) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *GenerateConversationMessageRequest) GetTools() []*ToolDefinition {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *GenerateConversationMessageRequest) GetStructuredOutputSchema() *Schema {
+	if x != nil {
+		return x.StructuredOutputSchema
+	}
+	return nil
+}
+
+func (x *GenerateConversationMessageRequest) GetGenerationOptions() *GenerationOptions {
+	if x != nil {
+		return x.GenerationOptions
+	}
+	return nil
+}
+
+type ListConversationMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListConversationMessagesRequest) Reset() {
+	*x = ListConversationMessagesRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationMessagesRequest) ProtoMessage() {}
+
+func (x *ListConversationMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListConversationMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListConversationMessagesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+// Messages include the trimmed and summarized ones.
+type ListConversationMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	Messages      []*Message             `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConversationMessagesResponse) Reset() {
+	*x = ListConversationMessagesResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationMessagesResponse) ProtoMessage() {}
+
+func (x *ListConversationMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListConversationMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListConversationMessagesResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+func (x *ListConversationMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type DeleteConversationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteConversationRequest) Reset() {
+	*x = DeleteConversationRequest{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConversationRequest) ProtoMessage() {}
+
+func (x *DeleteConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConversationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConversationRequest) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteConversationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type DeleteConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConversationResponse) Reset() {
+	*x = DeleteConversationResponse{}
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConversationResponse) ProtoMessage() {}
+
+func (x *DeleteConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_college_service_proto_llm_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConversationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteConversationResponse) Descriptor() ([]byte, []int) {
+	return file_college_service_proto_llm_service_proto_rawDescGZIP(), []int{45}
+}
+
+var File_college_service_proto_llm_service_proto protoreflect.FileDescriptor
+
+const file_college_service_proto_llm_service_proto_rawDesc = "" +
+	"\n" +
+	"'college-service/proto/llm_service.proto\x12\x0ellm_service.v1\x1a\x19google/protobuf/any.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1c\n" +
+	"\x04Type\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"\xcd\x01\n" +
+	"\bToolCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12H\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2(.llm_service.v1.ToolCall.ParametersEntryR\n" +
+	"parameters\x1aS\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xd3\x01\n" +
+	"\n" +
+	"ToolResult\x12 \n" +
+	"\ftool_call_id\x18\x01 \x01(\tR\n" +
+	"toolCallId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12>\n" +
+	"\x06result\x18\x03 \x03(\v2&.llm_service.v1.ToolResult.ResultEntryR\x06result\x1aO\n" +
+	"\vResultEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"]\n" +
+	"\n" +
+	"Attachment\x12\x1b\n" +
+	"\tmime_type\x18\x01 \x01(\tR\bmimeType\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04data\x12\x12\n" +
+	"\x03uri\x18\x03 \x01(\tH\x00R\x03uriB\b\n" +
+	"\x06source\"\xfb\x01\n" +
+	"\aMessage\x12(\n" +
+	"\x04role\x18\x01 \x01(\x0e2\x14.llm_service.v1.RoleR\x04role\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x127\n" +
+	"\n" +
+	"tool_calls\x18\x03 \x03(\v2\x18.llm_service.v1.ToolCallR\ttoolCalls\x12;\n" +
+	"\vtool_result\x18\x04 \x01(\v2\x1a.llm_service.v1.ToolResultR\n" +
+	"toolResult\x12<\n" +
+	"\vattachments\x18\x05 \x03(\v2\x1a.llm_service.v1.AttachmentR\vattachments\"\xa4\x01\n" +
+	"\rToolParameter\x12(\n" +
+	"\x04type\x18\x01 \x01(\v2\x14.llm_service.v1.TypeR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vis_required\x18\x04 \x01(\bR\n" +
+	"isRequired\x12\x12\n" +
+	"\x04enum\x18\x05 \x03(\tR\x04enum\"\x8b\x01\n" +
+	"\x0eToolDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12C\n" +
+	"\x11parameters_schema\x18\x03 \x01(\v2\x16.llm_service.v1.SchemaR\x10parametersSchema\"\x97\x05\n" +
+	"\x06Schema\x12(\n" +
+	"\x04type\x18\x01 \x01(\v2\x14.llm_service.v1.TypeR\x04type\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12F\n" +
+	"\n" +
+	"properties\x18\x03 \x03(\v2&.llm_service.v1.Schema.PropertiesEntryR\n" +
+	"properties\x12,\n" +
+	"\x05items\x18\x04 \x01(\v2\x16.llm_service.v1.SchemaR\x05items\x12 \n" +
+	"\tmax_items\x18\x05 \x01(\x03H\x00R\bmaxItems\x88\x01\x01\x12 \n" +
+	"\tmin_items\x18\x06 \x01(\x03H\x01R\bminItems\x88\x01\x01\x12\x1a\n" +
+	"\brequired\x18\a \x03(\tR\brequired\x12\x12\n" +
+	"\x04enum\x18\b \x03(\tR\x04enum\x12\x1a\n" +
+	"\bnullable\x18\t \x01(\bR\bnullable\x12\x1d\n" +
+	"\aminimum\x18\n" +
+	" \x01(\x01H\x02R\aminimum\x88\x01\x01\x12\x1d\n" +
+	"\amaximum\x18\v \x01(\x01H\x03R\amaximum\x88\x01\x01\x12\x16\n" +
+	"\x06format\x18\f \x01(\tR\x06format\x12+\n" +
+	"\x11property_ordering\x18\r \x03(\tR\x10propertyOrdering\x12-\n" +
+	"\x06any_of\x18\x0e \x03(\v2\x16.llm_service.v1.SchemaR\x05anyOf\x1aU\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.llm_service.v1.SchemaR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_max_itemsB\f\n" +
+	"\n" +
+	"_min_itemsB\n" +
+	"\n" +
+	"\b_minimumB\n" +
+	"\n" +
+	"\b_maximum\"\x8e\x03\n" +
+	"\x11GenerationOptions\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12%\n" +
+	"\vtemperature\x18\x02 \x01(\x02H\x00R\vtemperature\x88\x01\x01\x12\x18\n" +
+	"\x05top_p\x18\x03 \x01(\x02H\x01R\x04topP\x88\x01\x01\x12/\n" +
+	"\x11max_output_tokens\x18\x04 \x01(\x05H\x02R\x0fmaxOutputTokens\x88\x01\x01\x12%\n" +
+	"\x0estop_sequences\x18\x05 \x03(\tR\rstopSequences\x12\x17\n" +
+	"\x04seed\x18\x06 \x01(\x05H\x03R\x04seed\x88\x01\x01\x12\x19\n" +
+	"\bno_cache\x18\a \x01(\bR\anoCache\x12]\n" +
+	"\x17context_overflow_policy\x18\b \x01(\x0e2%.llm_service.v1.ContextOverflowPolicyR\x15contextOverflowPolicyB\x0e\n" +
+	"\f_temperatureB\b\n" +
+	"\x06_top_pB\x14\n" +
+	"\x12_max_output_tokensB\a\n" +
+	"\x05_seed\"\xaf\x02\n" +
+	"\x17GenerateResponseRequest\x12:\n" +
+	"\fchat_history\x18\x01 \x03(\v2\x17.llm_service.v1.MessageR\vchatHistory\x124\n" +
+	"\x05tools\x18\x02 \x03(\v2\x1e.llm_service.v1.ToolDefinitionR\x05tools\x12P\n" +
+	"\x18structured_output_schema\x18\x03 \x01(\v2\x16.llm_service.v1.SchemaR\x16structuredOutputSchema\x12P\n" +
+	"\x12generation_options\x18\x04 \x01(\v2!.llm_service.v1.GenerationOptionsR\x11generationOptions\"\xce\x01\n" +
+	"\x05Usage\x12#\n" +
+	"\rprompt_tokens\x18\x01 \x01(\x05R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\x02 \x01(\x05R\x10completionTokens\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\x12>\n" +
+	"\fcache_status\x18\x05 \x01(\x0e2\x1b.llm_service.v1.CacheStatusR\vcacheStatus\"g\n" +
+	"\x0eTrimmedMessage\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12'\n" +
+	"\x0foriginal_tokens\x18\x02 \x01(\x05R\x0eoriginalTokens\x12\x16\n" +
+	"\x06tokens\x18\x03 \x01(\x05R\x06tokens\"\x93\x02\n" +
+	"\x0fContextTrimming\x12=\n" +
+	"\x06policy\x18\x01 \x01(\x0e2%.llm_service.v1.ContextOverflowPolicyR\x06policy\x124\n" +
+	"\x16original_prompt_tokens\x18\x02 \x01(\x05R\x14originalPromptTokens\x12#\n" +
+	"\rprompt_tokens\x18\x03 \x01(\x05R\fpromptTokens\x12*\n" +
+	"\x11input_token_limit\x18\x04 \x01(\x05R\x0finputTokenLimit\x12:\n" +
+	"\bmessages\x18\x05 \x03(\v2\x1e.llm_service.v1.TrimmedMessageR\bmessages\"\x85\x01\n" +
+	"\n" +
+	"SafetyFlag\x12:\n" +
+	"\bcategory\x18\x01 \x01(\x0e2\x1e.llm_service.v1.SafetyCategoryR\bcategory\x12#\n" +
+	"\rmessage_index\x18\x02 \x01(\x05R\fmessageIndex\x12\x16\n" +
+	"\x06phrase\x18\x03 \x01(\tR\x06phrase\"\x85\x02\n" +
+	"\x18GenerateResponseResponse\x121\n" +
+	"\amessage\x18\x01 \x01(\v2\x17.llm_service.v1.MessageR\amessage\x12+\n" +
+	"\x05usage\x18\x02 \x01(\v2\x15.llm_service.v1.UsageR\x05usage\x12J\n" +
+	"\x10context_trimming\x18\x03 \x01(\v2\x1f.llm_service.v1.ContextTrimmingR\x0fcontextTrimming\x12=\n" +
+	"\fsafety_flags\x18\x04 \x03(\v2\x1a.llm_service.v1.SafetyFlagR\vsafetyFlags\"\xb0\x02\n" +
+	"\x1eGenerateResponseStreamResponse\x12\x1d\n" +
+	"\n" +
+	"text_delta\x18\x01 \x01(\tR\ttextDelta\x127\n" +
+	"\n" +
+	"tool_calls\x18\x02 \x03(\v2\x18.llm_service.v1.ToolCallR\ttoolCalls\x12+\n" +
+	"\x05usage\x18\x03 \x01(\v2\x15.llm_service.v1.UsageR\x05usage\x12J\n" +
+	"\x10context_trimming\x18\x04 \x01(\v2\x1f.llm_service.v1.ContextTrimmingR\x0fcontextTrimming\x12=\n" +
+	"\fsafety_flags\x18\x05 \x03(\v2\x1a.llm_service.v1.SafetyFlagR\vsafetyFlags\"\x1b\n" +
+	"\x19GetAdmissionStatusRequest\"\x97\x01\n" +
+	"\x1aGetAdmissionStatusResponse\x12\x1b\n" +
+	"\tin_flight\x18\x01 \x01(\x05R\binFlight\x12\x16\n" +
+	"\x06queued\x18\x02 \x01(\x05R\x06queued\x12%\n" +
+	"\x0emax_concurrent\x18\x03 \x01(\x05R\rmaxConcurrent\x12\x1d\n" +
+	"\n" +
+	"max_queued\x18\x04 \x01(\x05R\tmaxQueued\"\xa6\x01\n" +
+	"\x0fGetUsageRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x16\n" +
+	"\x06caller\x18\x03 \x01(\tR\x06caller\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\"\xdd\x01\n" +
+	"\x0eUsageAggregate\x12,\n" +
+	"\x03day\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x03day\x12\x16\n" +
+	"\x06caller\x18\x02 \x01(\tR\x06caller\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\brequests\x18\x04 \x01(\x03R\brequests\x12#\n" +
+	"\rprompt_tokens\x18\x05 \x01(\x03R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\x06 \x01(\x03R\x10completionTokens\"R\n" +
+	"\x10GetUsageResponse\x12>\n" +
+	"\n" +
+	"aggregates\x18\x01 \x03(\v2\x1e.llm_service.v1.UsageAggregateR\n" +
+	"aggregates\":\n" +
+	"\fEmbedRequest\x12\x14\n" +
+	"\x05texts\x18\x01 \x03(\tR\x05texts\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\"#\n" +
+	"\tEmbedding\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"`\n" +
+	"\rEmbedResponse\x129\n" +
+	"\n" +
+	"embeddings\x18\x01 \x03(\v2\x19.llm_service.v1.EmbeddingR\n" +
+	"embeddings\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\"\x82\x01\n" +
+	"\x1bRenderPromptTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x125\n" +
+	"\tvariables\x18\x03 \x01(\v2\x17.google.protobuf.StructR\tvariables\"L\n" +
+	"\x1cRenderPromptTemplateResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"E\n" +
+	"\x1bCreatePromptTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\"8\n" +
+	"\x1cCreatePromptTemplateResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\"n\n" +
+	"\fBatchRequest\x12\x1b\n" +
+	"\tcustom_id\x18\x01 \x01(\tR\bcustomId\x12A\n" +
+	"\arequest\x18\x02 \x01(\v2'.llm_service.v1.GenerateResponseRequestR\arequest\"N\n" +
+	"\x12SubmitBatchRequest\x128\n" +
+	"\brequests\x18\x01 \x03(\v2\x1c.llm_service.v1.BatchRequestR\brequests\"\xb3\x02\n" +
+	"\x05Batch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1b.llm_service.v1.BatchStatusR\x06status\x12\x1f\n" +
+	"\vtotal_items\x18\x03 \x01(\x05R\n" +
+	"totalItems\x12'\n" +
+	"\x0fsucceeded_items\x18\x04 \x01(\x05R\x0esucceededItems\x12!\n" +
+	"\ffailed_items\x18\x05 \x01(\x05R\vfailedItems\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"B\n" +
+	"\x13SubmitBatchResponse\x12+\n" +
+	"\x05batch\x18\x01 \x01(\v2\x15.llm_service.v1.BatchR\x05batch\"\xd3\x01\n" +
+	"\tBatchItem\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1b\n" +
+	"\tcustom_id\x18\x02 \x01(\tR\bcustomId\x127\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1f.llm_service.v1.BatchItemStatusR\x06status\x12D\n" +
+	"\bresponse\x18\x04 \x01(\v2(.llm_service.v1.GenerateResponseResponseR\bresponse\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"!\n" +
+	"\x0fGetBatchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"p\n" +
+	"\x10GetBatchResponse\x12+\n" +
+	"\x05batch\x18\x01 \x01(\v2\x15.llm_service.v1.BatchR\x05batch\x12/\n" +
+	"\x05items\x18\x02 \x03(\v2\x19.llm_service.v1.BatchItemR\x05items\"#\n" +
+	"\x11WatchBatchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe8\x02\n" +
+	"\fConversation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12P\n" +
+	"\x0ehistory_policy\x18\x02 \x01(\x0e2).llm_service.v1.ConversationHistoryPolicyR\rhistoryPolicy\x12,\n" +
+	"\x12max_history_tokens\x18\x03 \x01(\x05R\x10maxHistoryTokens\x12#\n" +
+	"\rmessage_count\x18\x04 \x01(\x05R\fmessageCount\x12-\n" +
+	"\x12compacted_messages\x18\x05 \x01(\x05R\x11compactedMessages\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xd0\x01\n" +
+	"\x19CreateConversationRequest\x123\n" +
+	"\bmessages\x18\x01 \x03(\v2\x17.llm_service.v1.MessageR\bmessages\x12P\n" +
+	"\x0ehistory_policy\x18\x02 \x01(\x0e2).llm_service.v1.ConversationHistoryPolicyR\rhistoryPolicy\x12,\n" +
+	"\x12max_history_tokens\x18\x03 \x01(\x05R\x10maxHistoryTokens\"^\n" +
+	"\x1aCreateConversationResponse\x12@\n" +
+	"\fconversation\x18\x01 \x01(\v2\x1c.llm_service.v1.ConversationR\fconversation\"\x81\x01\n" +
+	"!AppendConversationMessagesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x123\n" +
+	"\bmessages\x18\x02 \x03(\v2\x17.llm_service.v1.MessageR\bmessages\"f\n" +
+	"\"AppendConversationMessagesResponse\x12@\n" +
+	"\fconversation\x18\x01 \x01(\v2\x1c.llm_service.v1.ConversationR\fconversation\"\xdc\x02\n" +
+	"\"GenerateConversationMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x123\n" +
+	"\bmessages\x18\x02 \x03(\v2\x17.llm_service.v1.MessageR\bmessages\x124\n" +
+	"\x05tools\x18\x03 \x03(\v2\x1e.llm_service.v1.ToolDefinitionR\x05tools\x12P\n" +
+	"\x18structured_output_schema\x18\x04 \x01(\v2\x16.llm_service.v1.SchemaR\x16structuredOutputSchema\x12P\n" +
+	"\x12generation_options\x18\x05 \x01(\v2!.llm_service.v1.GenerationOptionsR\x11generationOptions\"J\n" +
+	"\x1fListConversationMessagesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"\x99\x01\n" +
+	" ListConversationMessagesResponse\x12@\n" +
+	"\fconversation\x18\x01 \x01(\v2\x1c.llm_service.v1.ConversationR\fconversation\x123\n" +
+	"\bmessages\x18\x02 \x03(\v2\x17.llm_service.v1.MessageR\bmessages\"D\n" +
+	"\x19DeleteConversationRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"\x1c\n" +
+	"\x1aDeleteConversationResponse*5\n" +
+	"\x04Role\x12\n" +
+	"\n" +
+	"\x06SYSTEM\x10\x00\x12\b\n" +
+	"\x04USER\x10\x01\x12\r\n" +
+	"\tASSISTANT\x10\x02\x12\b\n" +
+	"\x04TOOL\x10\x03*s\n" +
+	"\x15ContextOverflowPolicy\x12\x1b\n" +
+	"\x17CONTEXT_OVERFLOW_REJECT\x10\x00\x12\x1d\n" +
+	"\x19CONTEXT_OVERFLOW_TRUNCATE\x10\x01\x12\x1e\n" +
+	"\x1aCONTEXT_OVERFLOW_SUMMARIZE\x10\x02*>\n" +
+	"\vCacheStatus\x12\x10\n" +
+	"\fCACHE_BYPASS\x10\x00\x12\x0e\n" +
+	"\n" +
+	"CACHE_MISS\x10\x01\x12\r\n" +
+	"\tCACHE_HIT\x10\x02**\n" +
+	"\x0eSafetyCategory\x12\r\n" +
+	"\tSELF_HARM\x10\x00\x12\t\n" +
+	"\x05ABUSE\x10\x01*H\n" +
+	"\vBatchStatus\x12\x11\n" +
+	"\rBATCH_PENDING\x10\x00\x12\x11\n" +
+	"\rBATCH_RUNNING\x10\x01\x12\x13\n" +
+	"\x0fBATCH_COMPLETED\x10\x02*r\n" +
+	"\x0fBatchItemStatus\x12\x16\n" +
+	"\x12BATCH_ITEM_PENDING\x10\x00\x12\x16\n" +
+	"\x12BATCH_ITEM_RUNNING\x10\x01\x12\x18\n" +
+	"\x14BATCH_ITEM_SUCCEEDED\x10\x02\x12\x15\n" +
+	"\x11BATCH_ITEM_FAILED\x10\x03*\x81\x01\n" +
+	"\x19ConversationHistoryPolicy\x12!\n" +
+	"\x1dCONVERSATION_HISTORY_KEEP_ALL\x10\x00\x12\x1d\n" +
+	"\x19CONVERSATION_HISTORY_TRIM\x10\x01\x12\"\n" +
+	"\x1eCONVERSATION_HISTORY_SUMMARIZE\x10\x022\xad\r\n" +
+	"\n" +
+	"LLMService\x12e\n" +
+	"\x10GenerateResponse\x12'.llm_service.v1.GenerateResponseRequest\x1a(.llm_service.v1.GenerateResponseResponse\x12s\n" +
+	"\x16GenerateResponseStream\x12'.llm_service.v1.GenerateResponseRequest\x1a..llm_service.v1.GenerateResponseStreamResponse0\x01\x12M\n" +
+	"\bGetUsage\x12\x1f.llm_service.v1.GetUsageRequest\x1a .llm_service.v1.GetUsageResponse\x12D\n" +
+	"\x05Embed\x12\x1c.llm_service.v1.EmbedRequest\x1a\x1d.llm_service.v1.EmbedResponse\x12k\n" +
+	"\x12GetAdmissionStatus\x12).llm_service.v1.GetAdmissionStatusRequest\x1a*.llm_service.v1.GetAdmissionStatusResponse\x12q\n" +
+	"\x14RenderPromptTemplate\x12+.llm_service.v1.RenderPromptTemplateRequest\x1a,.llm_service.v1.RenderPromptTemplateResponse\x12q\n" +
+	"\x14CreatePromptTemplate\x12+.llm_service.v1.CreatePromptTemplateRequest\x1a,.llm_service.v1.CreatePromptTemplateResponse\x12V\n" +
+	"\vSubmitBatch\x12\".llm_service.v1.SubmitBatchRequest\x1a#.llm_service.v1.SubmitBatchResponse\x12M\n" +
+	"\bGetBatch\x12\x1f.llm_service.v1.GetBatchRequest\x1a .llm_service.v1.GetBatchResponse\x12L\n" +
+	"\n" +
+	"WatchBatch\x12!.llm_service.v1.WatchBatchRequest\x1a\x19.llm_service.v1.BatchItem0\x01\x12k\n" +
+	"\x12CreateConversation\x12).llm_service.v1.CreateConversationRequest\x1a*.llm_service.v1.CreateConversationResponse\x12\x83\x01\n" +
+	"\x1aAppendConversationMessages\x121.llm_service.v1.AppendConversationMessagesRequest\x1a2.llm_service.v1.AppendConversationMessagesResponse\x12{\n" +
+	"\x1bGenerateConversationMessage\x122.llm_service.v1.GenerateConversationMessageRequest\x1a(.llm_service.v1.GenerateResponseResponse\x12\x89\x01\n" +
+	"!GenerateConversationMessageStream\x122.llm_service.v1.GenerateConversationMessageRequest\x1a..llm_service.v1.GenerateResponseStreamResponse0\x01\x12}\n" +
+	"\x18ListConversationMessages\x12/.llm_service.v1.ListConversationMessagesRequest\x1a0.llm_service.v1.ListConversationMessagesResponse\x12k\n" +
+	"\x12DeleteConversation\x12).llm_service.v1.DeleteConversationRequest\x1a*.llm_service.v1.DeleteConversationResponseB\x13Z\x11internal/proto/v1b\x06proto3"
+
+var (
+	file_college_service_proto_llm_service_proto_rawDescOnce sync.Once
+	file_college_service_proto_llm_service_proto_rawDescData []byte
+)
+
+func file_college_service_proto_llm_service_proto_rawDescGZIP() []byte {
+	file_college_service_proto_llm_service_proto_rawDescOnce.Do(func() {
+		file_college_service_proto_llm_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_college_service_proto_llm_service_proto_rawDesc), len(file_college_service_proto_llm_service_proto_rawDesc)))
+	})
+	return file_college_service_proto_llm_service_proto_rawDescData
+}
+
+var file_college_service_proto_llm_service_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_college_service_proto_llm_service_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_college_service_proto_llm_service_proto_goTypes = []any{
+	(Role)(0),                                  // 0: llm_service.v1.Role
+	(ContextOverflowPolicy)(0),                 // 1: llm_service.v1.ContextOverflowPolicy
+	(CacheStatus)(0),                           // 2: llm_service.v1.CacheStatus
+	(SafetyCategory)(0),                        // 3: llm_service.v1.SafetyCategory
+	(BatchStatus)(0),                           // 4: llm_service.v1.BatchStatus
+	(BatchItemStatus)(0),                       // 5: llm_service.v1.BatchItemStatus
+	(ConversationHistoryPolicy)(0),             // 6: llm_service.v1.ConversationHistoryPolicy
+	(*Type)(nil),                               // 7: llm_service.v1.Type
+	(*ToolCall)(nil),                           // 8: llm_service.v1.ToolCall
+	(*ToolResult)(nil),                         // 9: llm_service.v1.ToolResult
+	(*Attachment)(nil),                         // 10: llm_service.v1.Attachment
+	(*Message)(nil),                            // 11: llm_service.v1.Message
+	(*ToolParameter)(nil),                      // 12: llm_service.v1.ToolParameter
+	(*ToolDefinition)(nil),                     // 13: llm_service.v1.ToolDefinition
+	(*Schema)(nil),                             // 14: llm_service.v1.Schema
+	(*GenerationOptions)(nil),                  // 15: llm_service.v1.GenerationOptions
+	(*GenerateResponseRequest)(nil),            // 16: llm_service.v1.GenerateResponseRequest
+	(*Usage)(nil),                              // 17: llm_service.v1.Usage
+	(*TrimmedMessage)(nil),                     // 18: llm_service.v1.TrimmedMessage
+	(*ContextTrimming)(nil),                    // 19: llm_service.v1.ContextTrimming
+	(*SafetyFlag)(nil),                         // 20: llm_service.v1.SafetyFlag
+	(*GenerateResponseResponse)(nil),           // 21: llm_service.v1.GenerateResponseResponse
+	(*GenerateResponseStreamResponse)(nil),     // 22: llm_service.v1.GenerateResponseStreamResponse
+	(*GetAdmissionStatusRequest)(nil),          // 23: llm_service.v1.GetAdmissionStatusRequest
+	(*GetAdmissionStatusResponse)(nil),         // 24: llm_service.v1.GetAdmissionStatusResponse
+	(*GetUsageRequest)(nil),                    // 25: llm_service.v1.GetUsageRequest
+	(*UsageAggregate)(nil),                     // 26: llm_service.v1.UsageAggregate
+	(*GetUsageResponse)(nil),                   // 27: llm_service.v1.GetUsageResponse
+	(*EmbedRequest)(nil),                       // 28: llm_service.v1.EmbedRequest
+	(*Embedding)(nil),                          // 29: llm_service.v1.Embedding
+	(*EmbedResponse)(nil),                      // 30: llm_service.v1.EmbedResponse
+	(*RenderPromptTemplateRequest)(nil),        // 31: llm_service.v1.RenderPromptTemplateRequest
+	(*RenderPromptTemplateResponse)(nil),       // 32: llm_service.v1.RenderPromptTemplateResponse
+	(*CreatePromptTemplateRequest)(nil),        // 33: llm_service.v1.CreatePromptTemplateRequest
+	(*CreatePromptTemplateResponse)(nil),       // 34: llm_service.v1.CreatePromptTemplateResponse
+	(*BatchRequest)(nil),                       // 35: llm_service.v1.BatchRequest
+	(*SubmitBatchRequest)(nil),                 // 36: llm_service.v1.SubmitBatchRequest
+	(*Batch)(nil),                              // 37: llm_service.v1.Batch
+	(*SubmitBatchResponse)(nil),                // 38: llm_service.v1.SubmitBatchResponse
+	(*BatchItem)(nil),                          // 39: llm_service.v1.BatchItem
+	(*GetBatchRequest)(nil),                    // 40: llm_service.v1.GetBatchRequest
+	(*GetBatchResponse)(nil),                   // 41: llm_service.v1.GetBatchResponse
+	(*WatchBatchRequest)(nil),                  // 42: llm_service.v1.WatchBatchRequest
+	(*Conversation)(nil),                       // 43: llm_service.v1.Conversation
+	(*CreateConversationRequest)(nil),          // 44: llm_service.v1.CreateConversationRequest
+	(*CreateConversationResponse)(nil),         // 45: llm_service.v1.CreateConversationResponse
+	(*AppendConversationMessagesRequest)(nil),  // 46: llm_service.v1.AppendConversationMessagesRequest
+	(*AppendConversationMessagesResponse)(nil), // 47: llm_service.v1.AppendConversationMessagesResponse
+	(*GenerateConversationMessageRequest)(nil), // 48: llm_service.v1.GenerateConversationMessageRequest
+	(*ListConversationMessagesRequest)(nil),    // 49: llm_service.v1.ListConversationMessagesRequest
+	(*ListConversationMessagesResponse)(nil),   // 50: llm_service.v1.ListConversationMessagesResponse
+	(*DeleteConversationRequest)(nil),          // 51: llm_service.v1.DeleteConversationRequest
+	(*DeleteConversationResponse)(nil),         // 52: llm_service.v1.DeleteConversationResponse
+	nil,                                        // 53: llm_service.v1.ToolCall.ParametersEntry
+	nil,                                        // 54: llm_service.v1.ToolResult.ResultEntry
+	nil,                                        // 55: llm_service.v1.Schema.PropertiesEntry
+	(*timestamppb.Timestamp)(nil),              // 56: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                    // 57: google.protobuf.Struct
+	(*anypb.Any)(nil),                          // 58: google.protobuf.Any
+}
+var file_college_service_proto_llm_service_proto_depIdxs = []int32{
+	53, // 0: llm_service.v1.ToolCall.parameters:type_name -> llm_service.v1.ToolCall.ParametersEntry
+	54, // 1: llm_service.v1.ToolResult.result:type_name -> llm_service.v1.ToolResult.ResultEntry
+	0,  // 2: llm_service.v1.Message.role:type_name -> llm_service.v1.Role
+	8,  // 3: llm_service.v1.Message.tool_calls:type_name -> llm_service.v1.ToolCall
+	9,  // 4: llm_service.v1.Message.tool_result:type_name -> llm_service.v1.ToolResult
+	10, // 5: llm_service.v1.Message.attachments:type_name -> llm_service.v1.Attachment
+	7,  // 6: llm_service.v1.ToolParameter.type:type_name -> llm_service.v1.Type
+	14, // 7: llm_service.v1.ToolDefinition.parameters_schema:type_name -> llm_service.v1.Schema
+	7,  // 8: llm_service.v1.Schema.type:type_name -> llm_service.v1.Type
+	55, // 9: llm_service.v1.Schema.properties:type_name -> llm_service.v1.Schema.PropertiesEntry
+	14, // 10: llm_service.v1.Schema.items:type_name -> llm_service.v1.Schema
+	14, // 11: llm_service.v1.Schema.any_of:type_name -> llm_service.v1.Schema
+	1,  // 12: llm_service.v1.GenerationOptions.context_overflow_policy:type_name -> llm_service.v1.ContextOverflowPolicy
+	11, // 13: llm_service.v1.GenerateResponseRequest.chat_history:type_name -> llm_service.v1.Message
+	13, // 14: llm_service.v1.GenerateResponseRequest.tools:type_name -> llm_service.v1.ToolDefinition
+	14, // 15: llm_service.v1.GenerateResponseRequest.structured_output_schema:type_name -> llm_service.v1.Schema
+	15, // 16: llm_service.v1.GenerateResponseRequest.generation_options:type_name -> llm_service.v1.GenerationOptions
+	2,  // 17: llm_service.v1.Usage.cache_status:type_name -> llm_service.v1.CacheStatus
+	1,  // 18: llm_service.v1.ContextTrimming.policy:type_name -> llm_service.v1.ContextOverflowPolicy
+	18, // 19: llm_service.v1.ContextTrimming.messages:type_name -> llm_service.v1.TrimmedMessage
+	3,  // 20: llm_service.v1.SafetyFlag.category:type_name -> llm_service.v1.SafetyCategory
+	11, // 21: llm_service.v1.GenerateResponseResponse.message:type_name -> llm_service.v1.Message
+	17, // 22: llm_service.v1.GenerateResponseResponse.usage:type_name -> llm_service.v1.Usage
+	19, // 23: llm_service.v1.GenerateResponseResponse.context_trimming:type_name -> llm_service.v1.ContextTrimming
+	20, // 24: llm_service.v1.GenerateResponseResponse.safety_flags:type_name -> llm_service.v1.SafetyFlag
+	8,  // 25: llm_service.v1.GenerateResponseStreamResponse.tool_calls:type_name -> llm_service.v1.ToolCall
+	17, // 26: llm_service.v1.GenerateResponseStreamResponse.usage:type_name -> llm_service.v1.Usage
+	19, // 27: llm_service.v1.GenerateResponseStreamResponse.context_trimming:type_name -> llm_service.v1.ContextTrimming
+	20, // 28: llm_service.v1.GenerateResponseStreamResponse.safety_flags:type_name -> llm_service.v1.SafetyFlag
+	56, // 29: llm_service.v1.GetUsageRequest.since:type_name -> google.protobuf.Timestamp
+	56, // 30: llm_service.v1.GetUsageRequest.until:type_name -> google.protobuf.Timestamp
+	56, // 31: llm_service.v1.UsageAggregate.day:type_name -> google.protobuf.Timestamp
+	26, // 32: llm_service.v1.GetUsageResponse.aggregates:type_name -> llm_service.v1.UsageAggregate
+	29, // 33: llm_service.v1.EmbedResponse.embeddings:type_name -> llm_service.v1.Embedding
+	57, // 34: llm_service.v1.RenderPromptTemplateRequest.variables:type_name -> google.protobuf.Struct
+	16, // 35: llm_service.v1.BatchRequest.request:type_name -> llm_service.v1.GenerateResponseRequest
+	35, // 36: llm_service.v1.SubmitBatchRequest.requests:type_name -> llm_service.v1.BatchRequest
+	4,  // 37: llm_service.v1.Batch.status:type_name -> llm_service.v1.BatchStatus
+	56, // 38: llm_service.v1.Batch.created_at:type_name -> google.protobuf.Timestamp
+	56, // 39: llm_service.v1.Batch.completed_at:type_name -> google.protobuf.Timestamp
+	37, // 40: llm_service.v1.SubmitBatchResponse.batch:type_name -> llm_service.v1.Batch
+	5,  // 41: llm_service.v1.BatchItem.status:type_name -> llm_service.v1.BatchItemStatus
+	21, // 42: llm_service.v1.BatchItem.response:type_name -> llm_service.v1.GenerateResponseResponse
+	37, // 43: llm_service.v1.GetBatchResponse.batch:type_name -> llm_service.v1.Batch
+	39, // 44: llm_service.v1.GetBatchResponse.items:type_name -> llm_service.v1.BatchItem
+	6,  // 45: llm_service.v1.Conversation.history_policy:type_name -> llm_service.v1.ConversationHistoryPolicy
+	56, // 46: llm_service.v1.Conversation.created_at:type_name -> google.protobuf.Timestamp
+	56, // 47: llm_service.v1.Conversation.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 48: llm_service.v1.CreateConversationRequest.messages:type_name -> llm_service.v1.Message
+	6,  // 49: llm_service.v1.CreateConversationRequest.history_policy:type_name -> llm_service.v1.ConversationHistoryPolicy
+	43, // 50: llm_service.v1.CreateConversationResponse.conversation:type_name -> llm_service.v1.Conversation
+	11, // 51: llm_service.v1.AppendConversationMessagesRequest.messages:type_name -> llm_service.v1.Message
+	43, // 52: llm_service.v1.AppendConversationMessagesResponse.conversation:type_name -> llm_service.v1.Conversation
+	11, // 53: llm_service.v1.GenerateConversationMessageRequest.messages:type_name -> llm_service.v1.Message
+	13, // 54: llm_service.v1.GenerateConversationMessageRequest.tools:type_name -> llm_service.v1.ToolDefinition
+	14, // 55: llm_service.v1.GenerateConversationMessageRequest.structured_output_schema:type_name -> llm_service.v1.Schema
+	15, // 56: llm_service.v1.GenerateConversationMessageRequest.generation_options:type_name -> llm_service.v1.GenerationOptions
+	43, // 57: llm_service.v1.ListConversationMessagesResponse.conversation:type_name -> llm_service.v1.Conversation
+	11, // 58: llm_service.v1.ListConversationMessagesResponse.messages:type_name -> llm_service.v1.Message
+	58, // 59: llm_service.v1.ToolCall.ParametersEntry.value:type_name -> google.protobuf.Any
+	58, // 60: llm_service.v1.ToolResult.ResultEntry.value:type_name -> google.protobuf.Any
+	14, // 61: llm_service.v1.Schema.PropertiesEntry.value:type_name -> llm_service.v1.Schema
+	16, // 62: llm_service.v1.LLMService.GenerateResponse:input_type -> llm_service.v1.GenerateResponseRequest
+	16, // 63: llm_service.v1.LLMService.GenerateResponseStream:input_type -> llm_service.v1.GenerateResponseRequest
+	25, // 64: llm_service.v1.LLMService.GetUsage:input_type -> llm_service.v1.GetUsageRequest
+	28, // 65: llm_service.v1.LLMService.Embed:input_type -> llm_service.v1.EmbedRequest
+	23, // 66: llm_service.v1.LLMService.GetAdmissionStatus:input_type -> llm_service.v1.GetAdmissionStatusRequest
+	31, // 67: llm_service.v1.LLMService.RenderPromptTemplate:input_type -> llm_service.v1.RenderPromptTemplateRequest
+	33, // 68: llm_service.v1.LLMService.CreatePromptTemplate:input_type -> llm_service.v1.CreatePromptTemplateRequest
+	36, // 69: llm_service.v1.LLMService.SubmitBatch:input_type -> llm_service.v1.SubmitBatchRequest
+	40, // 70: llm_service.v1.LLMService.GetBatch:input_type -> llm_service.v1.GetBatchRequest
+	42, // 71: llm_service.v1.LLMService.WatchBatch:input_type -> llm_service.v1.WatchBatchRequest
+	44, // 72: llm_service.v1.LLMService.CreateConversation:input_type -> llm_service.v1.CreateConversationRequest
+	46, // 73: llm_service.v1.LLMService.AppendConversationMessages:input_type -> llm_service.v1.AppendConversationMessagesRequest
+	48, // 74: llm_service.v1.LLMService.GenerateConversationMessage:input_type -> llm_service.v1.GenerateConversationMessageRequest
+	48, // 75: llm_service.v1.LLMService.GenerateConversationMessageStream:input_type -> llm_service.v1.GenerateConversationMessageRequest
+	49, // 76: llm_service.v1.LLMService.ListConversationMessages:input_type -> llm_service.v1.ListConversationMessagesRequest
+	51, // 77: llm_service.v1.LLMService.DeleteConversation:input_type -> llm_service.v1.DeleteConversationRequest
+	21, // 78: llm_service.v1.LLMService.GenerateResponse:output_type -> llm_service.v1.GenerateResponseResponse
+	22, // 79: llm_service.v1.LLMService.GenerateResponseStream:output_type -> llm_service.v1.GenerateResponseStreamResponse
+	27, // 80: llm_service.v1.LLMService.GetUsage:output_type -> llm_service.v1.GetUsageResponse
+	30, // 81: llm_service.v1.LLMService.Embed:output_type -> llm_service.v1.EmbedResponse
+	24, // 82: llm_service.v1.LLMService.GetAdmissionStatus:output_type -> llm_service.v1.GetAdmissionStatusResponse
+	32, // 83: llm_service.v1.LLMService.RenderPromptTemplate:output_type -> llm_service.v1.RenderPromptTemplateResponse
+	34, // 84: llm_service.v1.LLMService.CreatePromptTemplate:output_type -> llm_service.v1.CreatePromptTemplateResponse
+	38, // 85: llm_service.v1.LLMService.SubmitBatch:output_type -> llm_service.v1.SubmitBatchResponse
+	41, // 86: llm_service.v1.LLMService.GetBatch:output_type -> llm_service.v1.GetBatchResponse
+	39, // 87: llm_service.v1.LLMService.WatchBatch:output_type -> llm_service.v1.BatchItem
+	45, // 88: llm_service.v1.LLMService.CreateConversation:output_type -> llm_service.v1.CreateConversationResponse
+	47, // 89: llm_service.v1.LLMService.AppendConversationMessages:output_type -> llm_service.v1.AppendConversationMessagesResponse
+	21, // 90: llm_service.v1.LLMService.GenerateConversationMessage:output_type -> llm_service.v1.GenerateResponseResponse
+	22, // 91: llm_service.v1.LLMService.GenerateConversationMessageStream:output_type -> llm_service.v1.GenerateResponseStreamResponse
+	50, // 92: llm_service.v1.LLMService.ListConversationMessages:output_type -> llm_service.v1.ListConversationMessagesResponse
+	52, // 93: llm_service.v1.LLMService.DeleteConversation:output_type -> llm_service.v1.DeleteConversationResponse
+	78, // [78:94] is the sub-list for method output_type
+	62, // [62:78] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
+}
+
+func init() { file_college_service_proto_llm_service_proto_init() }
+func file_college_service_proto_llm_service_proto_init() {
+	if File_college_service_proto_llm_service_proto != nil {
+		return
+	}
+	file_college_service_proto_llm_service_proto_msgTypes[3].OneofWrappers = []any{
+		(*Attachment_Data)(nil),
+		(*Attachment_Uri)(nil),
+	}
+	file_college_service_proto_llm_service_proto_msgTypes[7].OneofWrappers = []any{}
+	file_college_service_proto_llm_service_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_college_service_proto_llm_service_proto_rawDesc), len(file_college_service_proto_llm_service_proto_rawDesc)),
+			NumEnums:      7,
+			NumMessages:   49,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_college_service_proto_llm_service_proto_goTypes,
+		DependencyIndexes: file_college_service_proto_llm_service_proto_depIdxs,
+		EnumInfos:         file_college_service_proto_llm_service_proto_enumTypes,
+		MessageInfos:      file_college_service_proto_llm_service_proto_msgTypes,
+	}.Build()
+	File_college_service_proto_llm_service_proto = out.File
+	file_college_service_proto_llm_service_proto_goTypes = nil
+	file_college_service_proto_llm_service_proto_depIdxs = nil
+}
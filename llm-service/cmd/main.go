@@ -5,8 +5,9 @@ import (
 	"fmt"
 
 	"github.com/joho/godotenv"
-	"google.golang.org/genai"
 
+	"github.com/compendium-tech/compendium/common/pkg/pg"
+	"github.com/compendium-tech/compendium/common/pkg/redis"
 	"github.com/compendium-tech/compendium/llm-service/internal/app"
 	"github.com/compendium-tech/compendium/llm-service/internal/config"
 	"github.com/compendium-tech/compendium/llm-service/internal/service"
@@ -21,18 +22,47 @@ func main() {
 
 	cfg := config.LoadAppConfig()
 
-	geminiAPIClient, err := service.NewGeminiClient(ctx, &genai.ClientConfig{
-		APIKey:  cfg.GeminiApiKey,
-		Backend: genai.BackendGeminiAPI,
-	})
+	llmService, err := service.NewProvider(ctx, cfg.LLMProvider, cfg)
 	if err != nil {
-		fmt.Printf("Failed to initialize Gemini API client, cause: %s", err)
+		fmt.Printf("Failed to initialize %s LLM provider, cause: %s", cfg.LLMProvider, err)
+		return
+	}
+
+	var fallbackLLMService service.LLMService
+	if cfg.LLMFallbackProvider != "" {
+		fallbackLLMService, err = service.NewProvider(ctx, cfg.LLMFallbackProvider, cfg)
+		if err != nil {
+			fmt.Printf("Failed to initialize %s fallback LLM provider, cause: %s", cfg.LLMFallbackProvider, err)
+			return
+		}
+	}
+
+	redactionConfig, err := service.LoadRedactionConfig(cfg.RedactionConfigPath)
+	if err != nil {
+		fmt.Printf("Failed to load redaction config, cause: %s", err)
+		return
+	}
+
+	pgDB, err := pg.NewPgClient(ctx, cfg.PgHost, cfg.PgPort, cfg.PgUsername, cfg.PgPassword, cfg.PgDatabaseName)
+	if err != nil {
+		fmt.Printf("Failed to connect to PostgreSQL, cause: %s", err)
+		return
+	}
+
+	redisClient, err := redis.NewRedisClient(ctx, cfg.RedisHost, cfg.RedisPort)
+	if err != nil {
+		fmt.Printf("Failed to connect to Redis, cause: %s", err)
 		return
 	}
 
 	err = app.NewApp(app.Dependencies{
-		Config:     cfg,
-		LLMService: geminiAPIClient,
+		Config:      cfg,
+		PgDB:        pgDB,
+		RedisClient: redisClient,
+		LLMService:  llmService,
+
+		FallbackLLMService: fallbackLLMService,
+		RedactionConfig:    redactionConfig,
 	}).Run()
 	if err != nil {
 		fmt.Printf("Failed to start LLM service, cause: %v\n", err)
@@ -2,7 +2,10 @@ module github.com/compendium-tech/compendium/llm-service
 
 go 1.24.5
 
-require google.golang.org/genai v1.17.0
+require (
+	github.com/prometheus/client_golang v1.20.5
+	google.golang.org/genai v1.17.0
+)
 
 require (
 	cloud.google.com/go v0.116.0 // indirect
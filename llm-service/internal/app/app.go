@@ -1,6 +1,13 @@
 package app
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 
 	"github.com/sirupsen/logrus"
@@ -10,12 +17,19 @@ import (
 
 	"github.com/compendium-tech/compendium/llm-service/internal/config"
 	grpcv1 "github.com/compendium-tech/compendium/llm-service/internal/delivery/grpc/v1"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
 	"github.com/compendium-tech/compendium/llm-service/internal/service"
 )
 
 type Dependencies struct {
-	Config     *config.AppConfig
-	LLMService service.LLMService
+	Config      *config.AppConfig
+	PgDB        *sql.DB
+	RedisClient *redis.Client
+	LLMService  service.LLMService
+	// FallbackLLMService is used while LLMService is unavailable. It is nil if no fallback is configured.
+	FallbackLLMService service.LLMService
+	// RedactionConfig holds the PII redaction and moderation policies of the callers.
+	RedactionConfig service.RedactionConfig
 }
 
 func NewApp(deps Dependencies) netapp.GrpcApp {
@@ -25,8 +39,116 @@ func NewApp(deps Dependencies) netapp.GrpcApp {
 	})
 	logrus.SetReportCaller(true)
 
-	grpcServer := grpc.NewServer()
-	grpcv1.NewLLMServiceServer(deps.LLMService).Register(grpcServer)
+	usageRepository := repository.NewPgUsageRepository(deps.PgDB)
+	usageService := service.NewUsageService(usageRepository)
+	responseCacheRepository := repository.NewRedisResponseCacheRepository(deps.RedisClient, deps.Config.ResponseCacheTTL)
+
+	resilienceConfig := service.ResilienceConfig{
+		MaxRetries:       deps.Config.LLMMaxRetries,
+		InitialBackoff:   deps.Config.LLMRetryInitialBackoff,
+		MaxBackoff:       deps.Config.LLMRetryMaxBackoff,
+		CallTimeout:      deps.Config.LLMCallTimeout,
+		FailureThreshold: deps.Config.LLMCircuitBreakerThreshold,
+		OpenDuration:     deps.Config.LLMCircuitBreakerCooldown,
+	}
+
+	var fallbackLLMService service.LLMService
+	if deps.FallbackLLMService != nil {
+		fallbackLLMService = service.NewResilientLLMService(deps.Config.LLMFallbackProvider,
+			service.NewMetricsLLMService(deps.Config.LLMFallbackProvider, deps.FallbackLLMService), nil, resilienceConfig)
+	}
+
+	providerLLMService := service.NewResilientLLMService(deps.Config.LLMProvider,
+		service.NewMetricsLLMService(deps.Config.LLMProvider, deps.LLMService), fallbackLLMService, resilienceConfig)
+
+	// Cache hits don't reach the usage ledger, as they cost nothing. Only valid responses are cached,
+	// while every repair attempt is recorded.
+	llmService := service.NewValidatingLLMService(
+		service.NewUsageRecordingLLMService(providerLLMService, usageRepository),
+		deps.Config.StructuredOutputRepairAttempts)
+
+	// The chat history is shortened before it is validated, so the summaries are recorded too. The tokens
+	// are counted by the main provider, even though the fallback one may serve the request.
+	if tokenCounter, ok := deps.LLMService.(service.TokenCounter); ok {
+		llmService = service.NewContextWindowLLMService(llmService, tokenCounter, service.ContextWindowConfig{
+			MaxInputTokens: deps.Config.LLMMaxInputTokens,
+		})
+	} else {
+		logrus.Warnf("LLM provider %s can't count tokens, prompts won't be checked against the context window",
+			deps.Config.LLMProvider)
+	}
+
+	// The responses are cached with the placeholders of the redacted PII, so the cache holds no PII, and requests
+	// differing only in PII share the cached responses.
+	llmService = service.NewRedactingLLMService(
+		service.NewCachingLLMService(llmService, responseCacheRepository), deps.RedactionConfig)
+
+	admissionController := service.NewAdmissionController(service.AdmissionConfig{
+		MaxConcurrent:    deps.Config.MaxConcurrentRequests,
+		MaxQueued:        deps.Config.MaxQueuedRequests,
+		MaxQueuedPerFlow: deps.Config.MaxQueuedRequestsPerUser,
+	})
+
+	// The metrics interceptors come first, so the latency includes the time spent in the admission queue
+	// and the rejected calls are counted.
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(deps.Config.GrpcMaxReceiveMessageSize),
+		grpc.ChainUnaryInterceptor(
+			grpcv1.MetricsUnaryInterceptor(), grpcv1.AdmissionUnaryInterceptor(admissionController)),
+		grpc.ChainStreamInterceptor(
+			grpcv1.MetricsStreamInterceptor(), grpcv1.AdmissionStreamInterceptor(admissionController)),
+	)
+	promptTemplateService := service.NewPromptTemplateService(repository.NewPgPromptTemplateRepository(deps.PgDB))
+
+	// Provider jobs are created by the main provider, falling back to generating the items one by one.
+	batchJobRunner, _ := deps.LLMService.(service.BatchJobRunner)
+	batchService := service.NewBatchService(
+		llmService, batchJobRunner, repository.NewPgBatchRepository(deps.PgDB), usageRepository, service.BatchConfig{
+			MaxItems:        deps.Config.BatchMaxItems,
+			MaxConcurrency:  deps.Config.BatchMaxConcurrency,
+			ItemLease:       deps.Config.BatchItemLease,
+			JobMinItems:     deps.Config.BatchJobMinItems,
+			JobPollInterval: deps.Config.BatchJobPollInterval,
+			Redaction:       deps.RedactionConfig,
+		})
+
+	go batchService.Run(context.Background())
+
+	conversationService := service.NewConversationService(
+		llmService, repository.NewPgConversationRepository(deps.PgDB), service.ConversationConfig{
+			TTL:                     deps.Config.ConversationTTL,
+			DefaultMaxHistoryTokens: deps.Config.ConversationMaxHistoryTokens,
+		})
+
+	go conversationService.Run(context.Background())
+
+	grpcv1.NewLLMServiceServer(llmService, usageService, admissionController, promptTemplateService,
+		batchService, conversationService).Register(grpcServer)
+
+	// The fallback provider of the same name has the same configuration, so it isn't checked twice.
+	healthCheckedProviders := map[string]service.LLMService{deps.Config.LLMProvider: deps.LLMService}
+	if deps.FallbackLLMService != nil && deps.Config.LLMFallbackProvider != deps.Config.LLMProvider {
+		healthCheckedProviders[deps.Config.LLMFallbackProvider] = deps.FallbackLLMService
+	}
+
+	grpcv1.RegisterHealthServer(grpcServer,
+		service.NewProviderHealthMonitor(healthCheckedProviders, deps.Config.HealthCheckInterval))
+
+	if deps.Config.MetricsPort != 0 {
+		go serveMetrics(deps.Config.MetricsPort)
+	}
 
 	return netapp.NewGrpcApp(grpcServer)
 }
+
+// serveMetrics serves the Prometheus metrics on their own port, which isn't exposed through the gateway.
+func serveMetrics(port uint16) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	logrus.Infof("Serving metrics on :%d", port)
+
+	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux); err != nil {
+		logrus.Errorf("Failed to serve metrics: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 )
 
 const (
@@ -11,16 +12,165 @@ const (
 	EnvironmentProd string = "prod"
 )
 
+const (
+	defaultLLMProvider      = "gemini"
+	defaultResponseCacheTTL = 24 * time.Hour
+	// defaultStructuredOutputRepairAttempts is how many times the model is asked to fix invalid structured output.
+	defaultStructuredOutputRepairAttempts = 2
+
+	defaultLLMMaxRetries              = 3
+	defaultLLMRetryInitialBackoff     = 500 * time.Millisecond
+	defaultLLMRetryMaxBackoff         = 8 * time.Second
+	defaultLLMCallTimeout             = 60 * time.Second
+	defaultLLMCircuitBreakerThreshold = 5
+	defaultLLMCircuitBreakerCooldown  = 30 * time.Second
+
+	defaultMaxConcurrentRequests    = 16
+	defaultMaxQueuedRequests        = 256
+	defaultMaxQueuedRequestsPerUser = 8
+
+	// defaultGrpcMaxReceiveMessageSize fits attachments of up to 20 MB, which is the limit of inline data
+	// of the providers, leaving room for the rest of the request.
+	defaultGrpcMaxReceiveMessageSize = 32 << 20
+
+	defaultBatchMaxItems       = 1000
+	defaultBatchMaxConcurrency = 4
+	defaultBatchItemLease      = 10 * time.Minute
+	// defaultBatchJobMinItems keeps small batches, which are quickly generated one by one, away from
+	// the batch APIs of the providers, which may take up to a day.
+	defaultBatchJobMinItems     = 50
+	defaultBatchJobPollInterval = time.Minute
+
+	defaultConversationTTL              = 7 * 24 * time.Hour
+	defaultConversationMaxHistoryTokens = 16000
+
+	defaultMetricsPort         = 9090
+	defaultHealthCheckInterval = 30 * time.Second
+)
+
 type AppConfig struct {
-	Environment  string
-	GeminiApiKey string
-	GrpcPort     uint16
+	Environment string
+	LLMProvider string
+	// LLMFallbackProvider is used while the circuit breaker of LLMProvider is open. Empty means no fallback.
+	LLMFallbackProvider string
+	// LLMMaxRetries is how many times the provider calls failing with 429 or 5xx are retried.
+	LLMMaxRetries          int
+	LLMRetryInitialBackoff time.Duration
+	LLMRetryMaxBackoff     time.Duration
+	// LLMCallTimeout limits every attempt to call the provider.
+	LLMCallTimeout time.Duration
+	// The circuit breaker opens after LLMCircuitBreakerThreshold consecutive failures
+	// and lets a trial call through after LLMCircuitBreakerCooldown.
+	LLMCircuitBreakerThreshold int
+	LLMCircuitBreakerCooldown  time.Duration
+	// LLMMaxInputTokens caps the input token limits of all models. Zero means the limits of the models.
+	LLMMaxInputTokens int32
+	// MaxConcurrentRequests is how many requests to the provider are processed at the same time. Other requests
+	// wait in the queue of MaxQueuedRequests, of which a single user may take MaxQueuedRequestsPerUser.
+	MaxConcurrentRequests    int
+	MaxQueuedRequests        int
+	MaxQueuedRequestsPerUser int
+	GeminiApiKey             string
+	GeminiModel              string
+	OpenAIBaseURL            string
+	OpenAIApiKey             string
+	OpenAIModel              string
+	// Embedding models are used by the Embed RPC when the request doesn't specify a model.
+	GeminiEmbeddingModel string
+	OpenAIEmbeddingModel string
+	// FakeLLMResponsesPath points to a JSON file with canned responses for the fake provider.
+	FakeLLMResponsesPath string
+	GrpcPort             uint16
+	PgHost               string
+	PgPort               uint16
+	PgUsername           string
+	PgPassword           string
+	PgDatabaseName       string
+	RedisHost            string
+	RedisPort            uint16
+	// ResponseCacheTTL is how long generated responses are served from the cache.
+	ResponseCacheTTL time.Duration
+	// StructuredOutputRepairAttempts is how many times the model is asked to fix structured output
+	// not conforming to the schema before the request fails.
+	StructuredOutputRepairAttempts int
+	// GrpcMaxReceiveMessageSize limits the size of requests in bytes, which carry the attachments.
+	GrpcMaxReceiveMessageSize int
+	// Batches of at most BatchMaxItems requests are generated by every replica BatchMaxConcurrency items
+	// at a time. An item processed for longer than BatchItemLease is taken over by another worker.
+	BatchMaxItems       int
+	BatchMaxConcurrency int
+	BatchItemLease      time.Duration
+	// Batches with at least BatchJobMinItems items are sent to the batch API of the provider, whose jobs
+	// are polled every BatchJobPollInterval. Zero disables the batch APIs.
+	BatchJobMinItems     int
+	BatchJobPollInterval time.Duration
+	// RedactionConfigPath points to a JSON file with the PII redaction and moderation policies of the callers.
+	// Empty path means that all callers have PII redacted and prompts moderated.
+	RedactionConfigPath string
+	// Conversations expire ConversationTTL after their last message. Their histories are trimmed or summarized
+	// above ConversationMaxHistoryTokens, unless the conversations set their own limits.
+	ConversationTTL              time.Duration
+	ConversationMaxHistoryTokens int
+	// MetricsPort serves the Prometheus metrics over HTTP, separately from the gRPC port. Zero disables the metrics.
+	MetricsPort uint16
+	// HealthCheckInterval is how often the providers are checked to be reachable for the gRPC health service.
+	HealthCheckInterval time.Duration
 }
 
 func LoadAppConfig() *AppConfig {
 	appConfig := &AppConfig{
-		Environment:  EnvironmentProd,
-		GeminiApiKey: os.Getenv("GEMINI_API_KEY"),
+		Environment: EnvironmentProd,
+		LLMProvider: os.Getenv("LLM_PROVIDER"),
+
+		LLMFallbackProvider:        os.Getenv("LLM_FALLBACK_PROVIDER"),
+		LLMMaxRetries:              defaultLLMMaxRetries,
+		LLMRetryInitialBackoff:     defaultLLMRetryInitialBackoff,
+		LLMRetryMaxBackoff:         defaultLLMRetryMaxBackoff,
+		LLMCallTimeout:             defaultLLMCallTimeout,
+		LLMCircuitBreakerThreshold: defaultLLMCircuitBreakerThreshold,
+		LLMCircuitBreakerCooldown:  defaultLLMCircuitBreakerCooldown,
+
+		MaxConcurrentRequests:    defaultMaxConcurrentRequests,
+		MaxQueuedRequests:        defaultMaxQueuedRequests,
+		MaxQueuedRequestsPerUser: defaultMaxQueuedRequestsPerUser,
+
+		GeminiApiKey:  os.Getenv("GEMINI_API_KEY"),
+		GeminiModel:   os.Getenv("GEMINI_MODEL"),
+		OpenAIBaseURL: os.Getenv("OPENAI_BASE_URL"),
+		OpenAIApiKey:  os.Getenv("OPENAI_API_KEY"),
+		OpenAIModel:   os.Getenv("OPENAI_MODEL"),
+
+		GeminiEmbeddingModel: os.Getenv("GEMINI_EMBEDDING_MODEL"),
+		OpenAIEmbeddingModel: os.Getenv("OPENAI_EMBEDDING_MODEL"),
+
+		FakeLLMResponsesPath: os.Getenv("FAKE_LLM_RESPONSES_PATH"),
+		PgHost:               os.Getenv("POSTGRES_HOST"),
+		PgUsername:           os.Getenv("POSTGRES_USERNAME"),
+		PgPassword:           os.Getenv("POSTGRES_PASSWORD"),
+		PgDatabaseName:       os.Getenv("POSTGRES_DATABASE_NAME"),
+		RedisHost:            os.Getenv("REDIS_HOST"),
+		ResponseCacheTTL:     defaultResponseCacheTTL,
+
+		StructuredOutputRepairAttempts: defaultStructuredOutputRepairAttempts,
+		GrpcMaxReceiveMessageSize:      defaultGrpcMaxReceiveMessageSize,
+
+		BatchMaxItems:        defaultBatchMaxItems,
+		BatchMaxConcurrency:  defaultBatchMaxConcurrency,
+		BatchItemLease:       defaultBatchItemLease,
+		BatchJobMinItems:     defaultBatchJobMinItems,
+		BatchJobPollInterval: defaultBatchJobPollInterval,
+
+		RedactionConfigPath: os.Getenv("REDACTION_CONFIG_PATH"),
+
+		ConversationTTL:              defaultConversationTTL,
+		ConversationMaxHistoryTokens: defaultConversationMaxHistoryTokens,
+
+		MetricsPort:         defaultMetricsPort,
+		HealthCheckInterval: defaultHealthCheckInterval,
+	}
+
+	if appConfig.LLMProvider == "" {
+		appConfig.LLMProvider = defaultLLMProvider
 	}
 
 	env := os.Getenv("ENVIRONMENT")
@@ -40,5 +190,156 @@ func LoadAppConfig() *AppConfig {
 		}
 	}
 
+	if port := os.Getenv("POSTGRES_PORT"); port != "" {
+		var pgPort uint16
+		_, err := fmt.Sscan(port, &pgPort)
+
+		if err == nil {
+			appConfig.PgPort = pgPort
+		} else {
+			log.Printf("Failed to parse postgres port: %s", port)
+		}
+	}
+
+	if port := os.Getenv("REDIS_PORT"); port != "" {
+		var redisPort uint16
+		_, err := fmt.Sscan(port, &redisPort)
+
+		if err == nil {
+			appConfig.RedisPort = redisPort
+		} else {
+			log.Printf("Failed to parse redis port: %s", port)
+		}
+	}
+
+	if ttl := os.Getenv("RESPONSE_CACHE_TTL"); ttl != "" {
+		responseCacheTTL, err := time.ParseDuration(ttl)
+
+		if err == nil {
+			appConfig.ResponseCacheTTL = responseCacheTTL
+		} else {
+			log.Printf("Failed to parse response cache TTL: %s", ttl)
+		}
+	}
+
+	if attempts := os.Getenv("STRUCTURED_OUTPUT_REPAIR_ATTEMPTS"); attempts != "" {
+		var repairAttempts int
+		_, err := fmt.Sscan(attempts, &repairAttempts)
+
+		if err == nil && repairAttempts >= 0 {
+			appConfig.StructuredOutputRepairAttempts = repairAttempts
+		} else {
+			log.Printf("Failed to parse structured output repair attempts: %s", attempts)
+		}
+	}
+
+	if retries := os.Getenv("LLM_MAX_RETRIES"); retries != "" {
+		var maxRetries int
+		_, err := fmt.Sscan(retries, &maxRetries)
+
+		if err == nil && maxRetries >= 0 {
+			appConfig.LLMMaxRetries = maxRetries
+		} else {
+			log.Printf("Failed to parse LLM max retries: %s", retries)
+		}
+	}
+
+	if threshold := os.Getenv("LLM_CIRCUIT_BREAKER_THRESHOLD"); threshold != "" {
+		var breakerThreshold int
+		_, err := fmt.Sscan(threshold, &breakerThreshold)
+
+		if err == nil {
+			appConfig.LLMCircuitBreakerThreshold = breakerThreshold
+		} else {
+			log.Printf("Failed to parse LLM circuit breaker threshold: %s", threshold)
+		}
+	}
+
+	loadPositiveInt("GRPC_MAX_RECEIVE_MESSAGE_SIZE", "gRPC max receive message size", &appConfig.GrpcMaxReceiveMessageSize)
+	loadPositiveInt("MAX_CONCURRENT_REQUESTS", "max concurrent requests", &appConfig.MaxConcurrentRequests)
+	loadPositiveInt("MAX_QUEUED_REQUESTS", "max queued requests", &appConfig.MaxQueuedRequests)
+	loadPositiveInt("MAX_QUEUED_REQUESTS_PER_USER", "max queued requests per user", &appConfig.MaxQueuedRequestsPerUser)
+
+	if tokens := os.Getenv("LLM_MAX_INPUT_TOKENS"); tokens != "" {
+		var maxInputTokens int32
+		_, err := fmt.Sscan(tokens, &maxInputTokens)
+
+		if err == nil && maxInputTokens >= 0 {
+			appConfig.LLMMaxInputTokens = maxInputTokens
+		} else {
+			log.Printf("Failed to parse LLM max input tokens: %s", tokens)
+		}
+	}
+
+	loadDuration("LLM_RETRY_INITIAL_BACKOFF", "LLM retry initial backoff", &appConfig.LLMRetryInitialBackoff)
+	loadDuration("LLM_RETRY_MAX_BACKOFF", "LLM retry max backoff", &appConfig.LLMRetryMaxBackoff)
+	loadDuration("LLM_CALL_TIMEOUT", "LLM call timeout", &appConfig.LLMCallTimeout)
+	loadDuration("LLM_CIRCUIT_BREAKER_COOLDOWN", "LLM circuit breaker cooldown", &appConfig.LLMCircuitBreakerCooldown)
+
+	loadPositiveInt("BATCH_MAX_ITEMS", "batch max items", &appConfig.BatchMaxItems)
+	loadPositiveInt("BATCH_MAX_CONCURRENCY", "batch max concurrency", &appConfig.BatchMaxConcurrency)
+	loadDuration("BATCH_ITEM_LEASE", "batch item lease", &appConfig.BatchItemLease)
+	loadDuration("BATCH_JOB_POLL_INTERVAL", "batch job poll interval", &appConfig.BatchJobPollInterval)
+
+	if items := os.Getenv("BATCH_JOB_MIN_ITEMS"); items != "" {
+		var jobMinItems int
+		_, err := fmt.Sscan(items, &jobMinItems)
+
+		if err == nil && jobMinItems >= 0 {
+			appConfig.BatchJobMinItems = jobMinItems
+		} else {
+			log.Printf("Failed to parse batch job min items: %s", items)
+		}
+	}
+
+	loadDuration("CONVERSATION_TTL", "conversation TTL", &appConfig.ConversationTTL)
+	loadPositiveInt("CONVERSATION_MAX_HISTORY_TOKENS", "conversation max history tokens",
+		&appConfig.ConversationMaxHistoryTokens)
+
+	if port := os.Getenv("METRICS_PORT"); port != "" {
+		var metricsPort uint16
+		_, err := fmt.Sscan(port, &metricsPort)
+
+		if err == nil {
+			appConfig.MetricsPort = metricsPort
+		} else {
+			log.Printf("Failed to parse metrics port: %s", port)
+		}
+	}
+
+	loadDuration("HEALTH_CHECK_INTERVAL", "health check interval", &appConfig.HealthCheckInterval)
+
 	return appConfig
 }
+
+// loadDuration overrides the duration with the environment variable if it is set and valid.
+func loadDuration(key string, name string, duration *time.Duration) {
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err == nil {
+		*duration = parsed
+	} else {
+		log.Printf("Failed to parse %s: %s", name, value)
+	}
+}
+
+// loadPositiveInt overrides the number with the environment variable if it is set and positive.
+func loadPositiveInt(key string, name string, number *int) {
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+
+	var parsed int
+	_, err := fmt.Sscan(value, &parsed)
+
+	if err == nil && parsed > 0 {
+		*number = parsed
+	} else {
+		log.Printf("Failed to parse %s: %s", name, value)
+	}
+}
@@ -0,0 +1,24 @@
+package localcontext
+
+import (
+	"context"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+type _callerKey struct{}
+
+var callerKey = _callerKey{}
+
+func SetCaller(ctx *context.Context, caller domain.Caller) {
+	*ctx = context.WithValue(*ctx, callerKey, caller)
+}
+
+// GetCaller returns the caller of the current request, or an empty caller if it's unknown.
+func GetCaller(ctx context.Context) domain.Caller {
+	if caller, ok := ctx.Value(callerKey).(domain.Caller); ok {
+		return caller
+	}
+
+	return domain.Caller{}
+}
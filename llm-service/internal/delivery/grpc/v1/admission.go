@@ -0,0 +1,54 @@
+package grpcv1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/compendium-tech/compendium/llm-service/internal/proto/v1"
+	"github.com/compendium-tech/compendium/llm-service/internal/service"
+)
+
+// admittedMethods are the methods calling the model provider, which go through admission control.
+var admittedMethods = map[string]bool{
+	pb.LLMService_GenerateResponse_FullMethodName:                  true,
+	pb.LLMService_GenerateResponseStream_FullMethodName:            true,
+	pb.LLMService_Embed_FullMethodName:                             true,
+	pb.LLMService_GenerateConversationMessage_FullMethodName:       true,
+	pb.LLMService_GenerateConversationMessageStream_FullMethodName: true,
+}
+
+// AdmissionUnaryInterceptor makes the unary calls wait for their turn in the admission controller.
+func AdmissionUnaryInterceptor(admissionController service.AdmissionController) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !admittedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		release, err := admissionController.Admit(ctx, callerFromMetadata(ctx))
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
+
+// AdmissionStreamInterceptor makes the streaming calls wait for their turn in the admission controller.
+// The slot is held until the whole stream is sent.
+func AdmissionStreamInterceptor(admissionController service.AdmissionController) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !admittedMethods[info.FullMethod] {
+			return handler(srv, stream)
+		}
+
+		release, err := admissionController.Admit(stream.Context(), callerFromMetadata(stream.Context()))
+		if err != nil {
+			return toStatusError(err)
+		}
+		defer release()
+
+		return handler(srv, stream)
+	}
+}
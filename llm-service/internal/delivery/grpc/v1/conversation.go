@@ -0,0 +1,208 @@
+package grpcv1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pbhelp "github.com/compendium-tech/compendium/common/pkg/pb"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	pb "github.com/compendium-tech/compendium/llm-service/internal/proto/v1"
+)
+
+func (s LLMServiceServer) CreateConversation(
+	ctx context.Context, req *pb.CreateConversationRequest) (*pb.CreateConversationResponse, error) {
+	messages, _, _, err := parseGenerateResponseRequest(&pb.GenerateResponseRequest{ChatHistory: req.Messages})
+	if err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.conversationService.CreateConversation(withCaller(ctx),
+		conversationHistoryPolicyPBToConversationHistoryPolicy(req.HistoryPolicy), int(req.MaxHistoryTokens), messages)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreateConversationResponse{Conversation: conversationToConversationPB(*conversation)}, nil
+}
+
+func (s LLMServiceServer) AppendConversationMessages(
+	ctx context.Context, req *pb.AppendConversationMessagesRequest) (*pb.AppendConversationMessagesResponse, error) {
+	messages, _, _, err := parseGenerateResponseRequest(&pb.GenerateResponseRequest{ChatHistory: req.Messages})
+	if err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.conversationService.AppendMessages(withCaller(ctx), req.ConversationId, messages)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.AppendConversationMessagesResponse{Conversation: conversationToConversationPB(*conversation)}, nil
+}
+
+func (s LLMServiceServer) GenerateConversationMessage(
+	ctx context.Context, req *pb.GenerateConversationMessageRequest) (*pb.GenerateResponseResponse, error) {
+	messages, tools, schema, err := parseGenerateConversationMessageRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.conversationService.GenerateMessage(withCaller(ctx), req.ConversationId, messages, tools, schema,
+		generationOptionsPBToGenerationOptions(req.GenerationOptions))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return responseToGenerateResponseResponsePB(resp)
+}
+
+func (s LLMServiceServer) GenerateConversationMessageStream(
+	req *pb.GenerateConversationMessageRequest, stream grpc.ServerStreamingServer[pb.GenerateResponseStreamResponse]) error {
+	messages, tools, schema, err := parseGenerateConversationMessageRequest(req)
+	if err != nil {
+		return err
+	}
+
+	ctx := withCaller(stream.Context())
+	options := generationOptionsPBToGenerationOptions(req.GenerationOptions)
+
+	deltas := s.conversationService.GenerateMessageStream(ctx, req.ConversationId, messages, tools, schema, options)
+	for delta, err := range deltas {
+		if err != nil {
+			return toStatusError(err)
+		}
+
+		protoDelta, err := deltaToGenerateResponseStreamResponsePB(delta)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(protoDelta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s LLMServiceServer) ListConversationMessages(
+	ctx context.Context, req *pb.ListConversationMessagesRequest) (*pb.ListConversationMessagesResponse, error) {
+	conversation, messages, err := s.conversationService.GetMessages(withCaller(ctx), req.ConversationId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoMessages := make([]*pb.Message, len(messages))
+	for i, message := range messages {
+		protoMessages[i], err = messageToMessagePB(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.ListConversationMessagesResponse{
+		Conversation: conversationToConversationPB(*conversation),
+		Messages:     protoMessages,
+	}, nil
+}
+
+func (s LLMServiceServer) DeleteConversation(
+	ctx context.Context, req *pb.DeleteConversationRequest) (*pb.DeleteConversationResponse, error) {
+	if err := s.conversationService.DeleteConversation(withCaller(ctx), req.ConversationId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.DeleteConversationResponse{}, nil
+}
+
+func parseGenerateConversationMessageRequest(
+	req *pb.GenerateConversationMessageRequest) ([]domain.Message, []domain.ToolDefinition, *domain.Schema, error) {
+	return parseGenerateResponseRequest(&pb.GenerateResponseRequest{
+		ChatHistory:            req.Messages,
+		Tools:                  req.Tools,
+		StructuredOutputSchema: req.StructuredOutputSchema,
+	})
+}
+
+func conversationToConversationPB(conversation model.Conversation) *pb.Conversation {
+	return &pb.Conversation{
+		Id:                conversation.ID,
+		HistoryPolicy:     conversationHistoryPolicyToConversationHistoryPolicyPB(conversation.HistoryPolicy),
+		MaxHistoryTokens:  int32(conversation.MaxHistoryTokens),
+		MessageCount:      int32(conversation.MessageCount),
+		CompactedMessages: int32(conversation.CompactedMessages),
+		CreatedAt:         timestamppb.New(conversation.CreatedAt),
+		ExpiresAt:         timestamppb.New(conversation.ExpiresAt),
+	}
+}
+
+func conversationHistoryPolicyToConversationHistoryPolicyPB(
+	policy model.ConversationHistoryPolicy) pb.ConversationHistoryPolicy {
+	switch policy {
+	case model.ConversationHistoryTrim:
+		return pb.ConversationHistoryPolicy_CONVERSATION_HISTORY_TRIM
+	case model.ConversationHistorySummarize:
+		return pb.ConversationHistoryPolicy_CONVERSATION_HISTORY_SUMMARIZE
+	default:
+		return pb.ConversationHistoryPolicy_CONVERSATION_HISTORY_KEEP_ALL
+	}
+}
+
+func conversationHistoryPolicyPBToConversationHistoryPolicy(
+	policy pb.ConversationHistoryPolicy) model.ConversationHistoryPolicy {
+	switch policy {
+	case pb.ConversationHistoryPolicy_CONVERSATION_HISTORY_TRIM:
+		return model.ConversationHistoryTrim
+	case pb.ConversationHistoryPolicy_CONVERSATION_HISTORY_SUMMARIZE:
+		return model.ConversationHistorySummarize
+	default:
+		return model.ConversationHistoryKeepAll
+	}
+}
+
+func messageToMessagePB(message domain.Message) (*pb.Message, error) {
+	toolCalls, err := toolCallsToToolCallsPB(message.ToolCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	var toolResult *pb.ToolResult
+	if message.ToolResult != nil {
+		result := make(map[string]*anypb.Any)
+		for k, v := range message.ToolResult.Result {
+			result[k], err = pbhelp.AnyToAnyPB(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		toolResult = &pb.ToolResult{
+			ToolCallId: message.ToolResult.ToolCallID,
+			Name:       message.ToolResult.Name,
+			Result:     result,
+		}
+	}
+
+	attachments := make([]*pb.Attachment, len(message.Attachments))
+	for i, attachment := range message.Attachments {
+		attachments[i] = &pb.Attachment{MimeType: attachment.MIMEType}
+		if attachment.URI != "" {
+			attachments[i].Source = &pb.Attachment_Uri{Uri: attachment.URI}
+		} else {
+			attachments[i].Source = &pb.Attachment_Data{Data: attachment.Data}
+		}
+	}
+
+	return &pb.Message{
+		Role:        roleToRolePB(message.Role),
+		Text:        message.Text,
+		ToolCalls:   toolCalls,
+		ToolResult:  toolResult,
+		Attachments: attachments,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package grpcv1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/service"
+)
+
+const (
+	errorDomain = "llm-service"
+	// InvalidStructuredOutputReason is the ErrorInfo reason of the error returned when the model failed to
+	// generate structured output conforming to the schema. The violations are sent as BadRequest details.
+	InvalidStructuredOutputReason = "INVALID_STRUCTURED_OUTPUT"
+	// ProviderUnavailableReason means that the provider failed with a retryable error, so the caller
+	// may repeat the request later. The status code is ResourceExhausted if the provider is rate limiting
+	// and Unavailable otherwise.
+	ProviderUnavailableReason = "PROVIDER_UNAVAILABLE"
+	// ProviderRejectedReason means that the provider rejected the request, which shouldn't be repeated as is.
+	ProviderRejectedReason = "PROVIDER_REJECTED"
+	// AdmissionRejectedReason means that llm-service is overloaded with requests, or with the requests
+	// of the user, so the caller should back off.
+	AdmissionRejectedReason = "ADMISSION_REJECTED"
+	// PromptTemplateNotFoundReason means that the template or its requested version doesn't exist.
+	PromptTemplateNotFoundReason = "PROMPT_TEMPLATE_NOT_FOUND"
+	// InvalidPromptTemplateReason means that the template can't be parsed, or rendered with the given variables.
+	InvalidPromptTemplateReason = "INVALID_PROMPT_TEMPLATE"
+	// ContextWindowExceededReason means that the chat history doesn't fit into the context window of the model
+	// and the request didn't allow to shorten it.
+	ContextWindowExceededReason = "CONTEXT_WINDOW_EXCEEDED"
+	// UnsupportedAttachmentReason means that the provider can't send the attachments of the MIME type to the model.
+	UnsupportedAttachmentReason = "UNSUPPORTED_ATTACHMENT"
+	// BatchNotFoundReason means that the batch doesn't exist or belongs to another caller.
+	BatchNotFoundReason = "BATCH_NOT_FOUND"
+	// BatchTooLargeReason means that the batch has more requests than llm-service accepts at once.
+	BatchTooLargeReason = "BATCH_TOO_LARGE"
+	// ConversationNotFoundReason means that the conversation doesn't exist, has expired or belongs to another caller.
+	ConversationNotFoundReason = "CONVERSATION_NOT_FOUND"
+)
+
+// toStatusError converts the errors known to the clients into gRPC status errors with details.
+func toStatusError(err error) error {
+	var invalidStructuredOutputErr *service.InvalidStructuredOutputError
+	var providerErr *service.ProviderError
+	var admissionRejectedErr *service.AdmissionRejectedError
+	var promptTemplateNotFoundErr *service.PromptTemplateNotFoundError
+	var invalidPromptTemplateErr *service.InvalidPromptTemplateError
+	var contextWindowExceededErr *service.ContextWindowExceededError
+	var unsupportedAttachmentErr *service.UnsupportedAttachmentError
+	var batchNotFoundErr *service.BatchNotFoundError
+	var batchTooLargeErr *service.BatchTooLargeError
+	var conversationNotFoundErr *service.ConversationNotFoundError
+
+	// Context errors are checked first, as the provider errors caused by the caller giving up on the request
+	// aren't the fault of the provider.
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.As(err, &invalidStructuredOutputErr):
+		fieldViolations := make([]*errdetails.BadRequest_FieldViolation, len(invalidStructuredOutputErr.Violations))
+		for i, violation := range invalidStructuredOutputErr.Violations {
+			fieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Path,
+				Description: violation.Description,
+			}
+		}
+
+		return newStatusError(codes.Internal, err, InvalidStructuredOutputReason,
+			&errdetails.BadRequest{FieldViolations: fieldViolations})
+	case errors.As(err, &providerErr):
+		if providerErr.Retryable() {
+			code := codes.Unavailable
+			if providerErr.StatusCode == http.StatusTooManyRequests {
+				code = codes.ResourceExhausted
+			}
+
+			return newStatusError(code, err, ProviderUnavailableReason)
+		}
+
+		// The provider rejects requests with invalid credentials because of the misconfiguration of llm-service,
+		// which the caller can't fix.
+		code := codes.InvalidArgument
+		if providerErr.StatusCode == http.StatusUnauthorized || providerErr.StatusCode == http.StatusForbidden {
+			code = codes.Internal
+		}
+
+		return newStatusError(code, err, ProviderRejectedReason)
+	case errors.As(err, &admissionRejectedErr):
+		return newStatusError(codes.ResourceExhausted, err, AdmissionRejectedReason)
+	case errors.As(err, &promptTemplateNotFoundErr):
+		return newStatusError(codes.NotFound, err, PromptTemplateNotFoundReason)
+	case errors.As(err, &invalidPromptTemplateErr):
+		return newStatusError(codes.InvalidArgument, err, InvalidPromptTemplateReason)
+	case errors.As(err, &contextWindowExceededErr):
+		return newStatusError(codes.InvalidArgument, err, ContextWindowExceededReason)
+	case errors.As(err, &unsupportedAttachmentErr):
+		return newStatusError(codes.InvalidArgument, err, UnsupportedAttachmentReason)
+	case errors.As(err, &batchNotFoundErr):
+		return newStatusError(codes.NotFound, err, BatchNotFoundReason)
+	case errors.As(err, &batchTooLargeErr):
+		return newStatusError(codes.InvalidArgument, err, BatchTooLargeReason)
+	case errors.As(err, &conversationNotFoundErr):
+		return newStatusError(codes.NotFound, err, ConversationNotFoundReason)
+	default:
+		return err
+	}
+}
+
+func newStatusError(code codes.Code, err error, reason string, details ...protoadapt.MessageV1) error {
+	details = append([]protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}}, details...)
+
+	st, detailsErr := status.New(code, err.Error()).WithDetails(details...)
+	if detailsErr != nil {
+		return status.Error(code, err.Error())
+	}
+
+	return st.Err()
+}
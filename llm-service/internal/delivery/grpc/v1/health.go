@@ -0,0 +1,30 @@
+package grpcv1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "github.com/compendium-tech/compendium/llm-service/internal/proto/v1"
+	"github.com/compendium-tech/compendium/llm-service/internal/service"
+)
+
+// RegisterHealthServer registers the standard gRPC health service. The server as a whole is always serving, so
+// liveness probes don't restart the replicas while the providers are down, whereas LLMService is serving only
+// while any of the providers is reachable, which is what readiness probes should check.
+func RegisterHealthServer(server *grpc.Server, monitor service.ProviderHealthMonitor) {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.LLMService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	go monitor.Run(context.Background(), func(available bool) {
+		servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+		if available {
+			servingStatus = healthpb.HealthCheckResponse_SERVING
+		}
+
+		healthServer.SetServingStatus(pb.LLMService_ServiceDesc.ServiceName, servingStatus)
+	})
+}
@@ -2,25 +2,54 @@ package grpcv1
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pbhelp "github.com/compendium-tech/compendium/common/pkg/pb"
 
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
 	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
 	pb "github.com/compendium-tech/compendium/llm-service/internal/proto/v1"
 	"github.com/compendium-tech/compendium/llm-service/internal/service"
 )
 
+// Callers identify themselves, the end user and their subscription tier with these gRPC metadata keys.
+const (
+	callerMetadataKey = "x-caller"
+	userIDMetadataKey = "x-user-id"
+	tierMetadataKey   = "x-subscription-tier"
+)
+
 type LLMServiceServer struct {
 	pb.UnimplementedLLMServiceServer
-	llmService service.LLMService
+	llmService            service.LLMService
+	usageService          service.UsageService
+	admissionController   service.AdmissionController
+	promptTemplateService service.PromptTemplateService
+	batchService          service.BatchService
+	conversationService   service.ConversationService
 }
 
-func NewLLMServiceServer(llmService service.LLMService) LLMServiceServer {
-	return LLMServiceServer{llmService: llmService}
+func NewLLMServiceServer(
+	llmService service.LLMService, usageService service.UsageService,
+	admissionController service.AdmissionController, promptTemplateService service.PromptTemplateService,
+	batchService service.BatchService, conversationService service.ConversationService) LLMServiceServer {
+	return LLMServiceServer{
+		llmService:            llmService,
+		usageService:          usageService,
+		admissionController:   admissionController,
+		promptTemplateService: promptTemplateService,
+		batchService:          batchService,
+		conversationService:   conversationService,
+	}
 }
 
 func (s LLMServiceServer) Register(server *grpc.Server) {
@@ -29,6 +58,413 @@ func (s LLMServiceServer) Register(server *grpc.Server) {
 }
 
 func (s LLMServiceServer) GenerateResponse(ctx context.Context, req *pb.GenerateResponseRequest) (*pb.GenerateResponseResponse, error) {
+	chatHistory, tools, schema, err := parseGenerateResponseRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.llmService.GenerateResponse(
+		withCaller(ctx), chatHistory, tools, schema, generationOptionsPBToGenerationOptions(req.GenerationOptions))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return responseToGenerateResponseResponsePB(resp)
+}
+
+func (s LLMServiceServer) GenerateResponseStream(
+	req *pb.GenerateResponseRequest, stream grpc.ServerStreamingServer[pb.GenerateResponseStreamResponse]) error {
+	chatHistory, tools, schema, err := parseGenerateResponseRequest(req)
+	if err != nil {
+		return err
+	}
+
+	ctx := withCaller(stream.Context())
+	options := generationOptionsPBToGenerationOptions(req.GenerationOptions)
+
+	for delta, err := range s.llmService.GenerateResponseStream(ctx, chatHistory, tools, schema, options) {
+		if err != nil {
+			return toStatusError(err)
+		}
+
+		protoDelta, err := deltaToGenerateResponseStreamResponsePB(delta)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(protoDelta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s LLMServiceServer) GetUsage(ctx context.Context, req *pb.GetUsageRequest) (*pb.GetUsageResponse, error) {
+	// Missing bounds select the whole ledger up to now.
+	until := time.Now()
+	if req.Until != nil {
+		until = req.Until.AsTime()
+	}
+
+	aggregates, err := s.usageService.GetUsage(ctx, model.UsageFilter{
+		Since:  req.Since.AsTime(),
+		Until:  until,
+		Caller: req.Caller,
+		UserID: req.UserId,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoAggregates := make([]*pb.UsageAggregate, len(aggregates))
+	for i, aggregate := range aggregates {
+		protoAggregates[i] = &pb.UsageAggregate{
+			Day:              timestamppb.New(aggregate.Day),
+			Caller:           aggregate.Caller,
+			UserId:           aggregate.UserID,
+			Requests:         aggregate.Requests,
+			PromptTokens:     aggregate.PromptTokens,
+			CompletionTokens: aggregate.CompletionTokens,
+		}
+	}
+
+	return &pb.GetUsageResponse{Aggregates: protoAggregates}, nil
+}
+
+func (s LLMServiceServer) GetAdmissionStatus(
+	context.Context, *pb.GetAdmissionStatusRequest) (*pb.GetAdmissionStatusResponse, error) {
+	stats := s.admissionController.Stats()
+
+	return &pb.GetAdmissionStatusResponse{
+		InFlight:      int32(stats.InFlight),
+		Queued:        int32(stats.Queued),
+		MaxConcurrent: int32(stats.MaxConcurrent),
+		MaxQueued:     int32(stats.MaxQueued),
+	}, nil
+}
+
+func (s LLMServiceServer) RenderPromptTemplate(
+	ctx context.Context, req *pb.RenderPromptTemplateRequest) (*pb.RenderPromptTemplateResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "template name is required")
+	}
+
+	rendered, err := s.promptTemplateService.RenderPromptTemplate(ctx, req.Name, req.Version, req.Variables.AsMap())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.RenderPromptTemplateResponse{Text: rendered.Text, Version: rendered.Version}, nil
+}
+
+func (s LLMServiceServer) CreatePromptTemplate(
+	ctx context.Context, req *pb.CreatePromptTemplateRequest) (*pb.CreatePromptTemplateResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "template name is required")
+	}
+
+	version, err := s.promptTemplateService.CreatePromptTemplate(ctx, req.Name, req.Body)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreatePromptTemplateResponse{Version: version}, nil
+}
+
+func (s LLMServiceServer) Embed(ctx context.Context, req *pb.EmbedRequest) (*pb.EmbedResponse, error) {
+	if len(req.Texts) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one text is required")
+	}
+
+	embeddings, err := s.llmService.Embed(withCaller(ctx), req.Texts, req.Model)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoEmbeddings := make([]*pb.Embedding, len(embeddings.Vectors))
+	for i, vector := range embeddings.Vectors {
+		protoEmbeddings[i] = &pb.Embedding{Values: vector}
+	}
+
+	return &pb.EmbedResponse{
+		Embeddings: protoEmbeddings,
+		Model:      embeddings.Model,
+	}, nil
+}
+
+func (s LLMServiceServer) SubmitBatch(ctx context.Context, req *pb.SubmitBatchRequest) (*pb.SubmitBatchResponse, error) {
+	if len(req.Requests) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one request is required")
+	}
+
+	items := make([]model.BatchItem, len(req.Requests))
+	for i, batchRequest := range req.Requests {
+		if batchRequest.Request == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "request %d is empty", i)
+		}
+
+		chatHistory, tools, schema, err := parseGenerateResponseRequest(batchRequest.Request)
+		if err != nil {
+			return nil, status.Errorf(status.Code(err), "request %d: %s", i, status.Convert(err).Message())
+		}
+
+		items[i] = model.BatchItem{
+			CustomID: batchRequest.CustomId,
+			Request: domain.GenerationRequest{
+				ChatHistory:            chatHistory,
+				Tools:                  tools,
+				StructuredOutputSchema: schema,
+				Options:                generationOptionsPBToGenerationOptions(batchRequest.Request.GenerationOptions),
+			},
+		}
+	}
+
+	batch, err := s.batchService.SubmitBatch(withCaller(ctx), items)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.SubmitBatchResponse{Batch: batchToBatchPB(*batch)}, nil
+}
+
+func (s LLMServiceServer) GetBatch(ctx context.Context, req *pb.GetBatchRequest) (*pb.GetBatchResponse, error) {
+	batch, items, err := s.batchService.GetBatch(withCaller(ctx), req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoItems := make([]*pb.BatchItem, len(items))
+	for i, item := range items {
+		protoItems[i], err = batchItemToBatchItemPB(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.GetBatchResponse{Batch: batchToBatchPB(*batch), Items: protoItems}, nil
+}
+
+func (s LLMServiceServer) WatchBatch(req *pb.WatchBatchRequest, stream grpc.ServerStreamingServer[pb.BatchItem]) error {
+	for item, err := range s.batchService.WatchBatch(withCaller(stream.Context()), req.Id) {
+		if err != nil {
+			return toStatusError(err)
+		}
+
+		protoItem, err := batchItemToBatchItemPB(item)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(protoItem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withCaller stores the caller identified by the incoming gRPC metadata in the context.
+func withCaller(ctx context.Context) context.Context {
+	localcontext.SetCaller(&ctx, callerFromMetadata(ctx))
+	return ctx
+}
+
+func callerFromMetadata(ctx context.Context) domain.Caller {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return domain.Caller{
+		Name:   firstMetadataValue(md, callerMetadataKey),
+		UserID: firstMetadataValue(md, userIDMetadataKey),
+		Tier:   firstMetadataValue(md, tierMetadataKey),
+	}
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func responseToGenerateResponseResponsePB(resp *domain.Response) (*pb.GenerateResponseResponse, error) {
+	toolCalls, err := toolCallsToToolCallsPB(resp.Message.ToolCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GenerateResponseResponse{
+		Message: &pb.Message{
+			Role:      roleToRolePB(resp.Message.Role),
+			Text:      resp.Message.Text,
+			ToolCalls: toolCalls,
+		},
+		Usage:           usageToUsagePB(resp.Usage),
+		ContextTrimming: contextTrimmingToContextTrimmingPB(resp.ContextTrimming),
+		SafetyFlags:     safetyFlagsToSafetyFlagsPB(resp.SafetyFlags),
+	}, nil
+}
+
+func deltaToGenerateResponseStreamResponsePB(delta *domain.MessageDelta) (*pb.GenerateResponseStreamResponse, error) {
+	toolCalls, err := toolCallsToToolCallsPB(delta.ToolCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage *pb.Usage
+	if delta.Usage != nil {
+		usage = usageToUsagePB(*delta.Usage)
+	}
+
+	return &pb.GenerateResponseStreamResponse{
+		TextDelta:       delta.Text,
+		ToolCalls:       toolCalls,
+		Usage:           usage,
+		ContextTrimming: contextTrimmingToContextTrimmingPB(delta.ContextTrimming),
+		SafetyFlags:     safetyFlagsToSafetyFlagsPB(delta.SafetyFlags),
+	}, nil
+}
+
+func batchToBatchPB(batch model.Batch) *pb.Batch {
+	protoBatch := &pb.Batch{
+		Id:             batch.ID,
+		Status:         pb.BatchStatus_BATCH_PENDING,
+		TotalItems:     int32(batch.TotalItems),
+		SucceededItems: int32(batch.SucceededItems),
+		FailedItems:    int32(batch.FailedItems),
+		CreatedAt:      timestamppb.New(batch.CreatedAt),
+	}
+
+	if batch.CompletedAt != nil {
+		protoBatch.Status = pb.BatchStatus_BATCH_COMPLETED
+		protoBatch.CompletedAt = timestamppb.New(*batch.CompletedAt)
+	} else if batch.PendingItems < batch.TotalItems {
+		protoBatch.Status = pb.BatchStatus_BATCH_RUNNING
+	}
+
+	return protoBatch
+}
+
+func batchItemToBatchItemPB(item model.BatchItem) (*pb.BatchItem, error) {
+	protoItem := &pb.BatchItem{
+		Index:    int32(item.Index),
+		CustomId: item.CustomID,
+		Status:   batchItemStatusToBatchItemStatusPB(item.Status),
+		Error:    item.Error,
+	}
+
+	if item.Response != nil {
+		var err error
+
+		protoItem.Response, err = responseToGenerateResponseResponsePB(item.Response)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return protoItem, nil
+}
+
+func batchItemStatusToBatchItemStatusPB(itemStatus model.BatchItemStatus) pb.BatchItemStatus {
+	switch itemStatus {
+	case model.BatchItemRunning:
+		return pb.BatchItemStatus_BATCH_ITEM_RUNNING
+	case model.BatchItemSucceeded:
+		return pb.BatchItemStatus_BATCH_ITEM_SUCCEEDED
+	case model.BatchItemFailed:
+		return pb.BatchItemStatus_BATCH_ITEM_FAILED
+	default:
+		return pb.BatchItemStatus_BATCH_ITEM_PENDING
+	}
+}
+
+func usageToUsagePB(usage domain.Usage) *pb.Usage {
+	return &pb.Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Model:            usage.Model,
+		LatencyMs:        usage.Latency.Milliseconds(),
+		CacheStatus:      cacheStatusToCacheStatusPB(usage.CacheStatus),
+	}
+}
+
+func cacheStatusToCacheStatusPB(cacheStatus domain.CacheStatus) pb.CacheStatus {
+	switch cacheStatus {
+	case domain.CacheStatusMiss:
+		return pb.CacheStatus_CACHE_MISS
+	case domain.CacheStatusHit:
+		return pb.CacheStatus_CACHE_HIT
+	default:
+		return pb.CacheStatus_CACHE_BYPASS
+	}
+}
+
+func contextTrimmingToContextTrimmingPB(contextTrimming *domain.ContextTrimming) *pb.ContextTrimming {
+	if contextTrimming == nil {
+		return nil
+	}
+
+	messages := make([]*pb.TrimmedMessage, len(contextTrimming.Messages))
+	for i, message := range contextTrimming.Messages {
+		messages[i] = &pb.TrimmedMessage{
+			Index:          int32(message.Index),
+			OriginalTokens: message.OriginalTokens,
+			Tokens:         message.Tokens,
+		}
+	}
+
+	return &pb.ContextTrimming{
+		Policy:               contextOverflowPolicyToContextOverflowPolicyPB(contextTrimming.Policy),
+		OriginalPromptTokens: contextTrimming.OriginalPromptTokens,
+		PromptTokens:         contextTrimming.PromptTokens,
+		InputTokenLimit:      contextTrimming.InputTokenLimit,
+		Messages:             messages,
+	}
+}
+
+func safetyFlagsToSafetyFlagsPB(flags []domain.SafetyFlag) []*pb.SafetyFlag {
+	protoFlags := make([]*pb.SafetyFlag, len(flags))
+	for i, flag := range flags {
+		category := pb.SafetyCategory_SELF_HARM
+		if flag.Category == domain.SafetyCategoryAbuse {
+			category = pb.SafetyCategory_ABUSE
+		}
+
+		protoFlags[i] = &pb.SafetyFlag{
+			Category:     category,
+			MessageIndex: int32(flag.MessageIndex),
+			Phrase:       flag.Phrase,
+		}
+	}
+
+	return protoFlags
+}
+
+func contextOverflowPolicyToContextOverflowPolicyPB(policy domain.ContextOverflowPolicy) pb.ContextOverflowPolicy {
+	switch policy {
+	case domain.ContextOverflowTruncate:
+		return pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_TRUNCATE
+	case domain.ContextOverflowSummarize:
+		return pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_SUMMARIZE
+	default:
+		return pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_REJECT
+	}
+}
+
+func contextOverflowPolicyPBToContextOverflowPolicy(policy pb.ContextOverflowPolicy) domain.ContextOverflowPolicy {
+	switch policy {
+	case pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_TRUNCATE:
+		return domain.ContextOverflowTruncate
+	case pb.ContextOverflowPolicy_CONTEXT_OVERFLOW_SUMMARIZE:
+		return domain.ContextOverflowSummarize
+	default:
+		return domain.ContextOverflowReject
+	}
+}
+
+func parseGenerateResponseRequest(
+	req *pb.GenerateResponseRequest) ([]domain.Message, []domain.ToolDefinition, *domain.Schema, error) {
 	chatHistory := make([]domain.Message, len(req.ChatHistory))
 	for i, msg := range req.ChatHistory {
 		toolCalls := make([]domain.ToolCall, len(msg.ToolCalls))
@@ -39,7 +475,7 @@ func (s LLMServiceServer) GenerateResponse(ctx context.Context, req *pb.Generate
 			for k, v := range tc.Parameters {
 				params[k], err = pbhelp.AnyPBToAny(v)
 				if err != nil {
-					return nil, err
+					return nil, nil, nil, err
 				}
 			}
 
@@ -50,10 +486,36 @@ func (s LLMServiceServer) GenerateResponse(ctx context.Context, req *pb.Generate
 			}
 		}
 
+		var toolResult *domain.ToolResult
+		if msg.ToolResult != nil {
+			result := make(map[string]any)
+			for k, v := range msg.ToolResult.Result {
+				var err error
+
+				result[k], err = pbhelp.AnyPBToAny(v)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+			}
+
+			toolResult = &domain.ToolResult{
+				ToolCallID: msg.ToolResult.ToolCallId,
+				Name:       msg.ToolResult.Name,
+				Result:     result,
+			}
+		}
+
+		attachments, err := attachmentsPBToAttachments(msg)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
 		chatHistory[i] = domain.Message{
-			Role:      rolePBToRole(msg.Role),
-			Text:      msg.Text,
-			ToolCalls: toolCalls,
+			Role:        rolePBToRole(msg.Role),
+			Text:        msg.Text,
+			ToolCalls:   toolCalls,
+			ToolResult:  toolResult,
+			Attachments: attachments,
 		}
 	}
 
@@ -71,13 +533,61 @@ func (s LLMServiceServer) GenerateResponse(ctx context.Context, req *pb.Generate
 		schema = schemaPBToSchema(req.StructuredOutputSchema)
 	}
 
-	resp, err := s.llmService.GenerateResponse(ctx, chatHistory, tools, schema)
-	if err != nil {
-		return nil, err
+	return chatHistory, tools, schema, nil
+}
+
+func attachmentsPBToAttachments(msg *pb.Message) ([]domain.Attachment, error) {
+	if len(msg.Attachments) == 0 {
+		return nil, nil
+	}
+
+	if msg.Role != pb.Role_USER {
+		return nil, status.Error(codes.InvalidArgument, "attachments are only supported in user messages")
+	}
+
+	attachments := make([]domain.Attachment, len(msg.Attachments))
+	for i, attachment := range msg.Attachments {
+		if attachment.MimeType == "" {
+			return nil, status.Error(codes.InvalidArgument, "attachment MIME type is required")
+		}
+
+		if len(attachment.GetData()) == 0 && attachment.GetUri() == "" {
+			return nil, status.Error(codes.InvalidArgument, "attachment must have either data or URI")
+		}
+
+		attachments[i] = domain.Attachment{
+			MIMEType: attachment.MimeType,
+			Data:     attachment.GetData(),
+			URI:      attachment.GetUri(),
+		}
+	}
+
+	return attachments, nil
+}
+
+func generationOptionsPBToGenerationOptions(protoOptions *pb.GenerationOptions) domain.GenerationOptions {
+	if protoOptions == nil {
+		return domain.GenerationOptions{}
 	}
 
-	toolCalls := make([]*pb.ToolCall, len(resp.ToolCalls))
-	for i, tc := range resp.ToolCalls {
+	return domain.GenerationOptions{
+		Model:           protoOptions.Model,
+		Temperature:     protoOptions.Temperature,
+		TopP:            protoOptions.TopP,
+		MaxOutputTokens: protoOptions.MaxOutputTokens,
+		StopSequences:   protoOptions.StopSequences,
+		Seed:            protoOptions.Seed,
+		NoCache:         protoOptions.NoCache,
+
+		ContextOverflowPolicy: contextOverflowPolicyPBToContextOverflowPolicy(protoOptions.ContextOverflowPolicy),
+	}
+}
+
+func toolCallsToToolCallsPB(toolCalls []domain.ToolCall) ([]*pb.ToolCall, error) {
+	var err error
+
+	protoToolCalls := make([]*pb.ToolCall, len(toolCalls))
+	for i, tc := range toolCalls {
 		params := make(map[string]*anypb.Any)
 		for k, v := range tc.Parameters {
 			params[k], err = pbhelp.AnyToAnyPB(v)
@@ -86,20 +596,14 @@ func (s LLMServiceServer) GenerateResponse(ctx context.Context, req *pb.Generate
 			}
 		}
 
-		toolCalls[i] = &pb.ToolCall{
+		protoToolCalls[i] = &pb.ToolCall{
 			Id:         tc.ID,
 			Name:       tc.Name,
 			Parameters: params,
 		}
 	}
 
-	return &pb.GenerateResponseResponse{
-		Message: &pb.Message{
-			Role:      roleToRolePB(resp.Role),
-			Text:      resp.Text,
-			ToolCalls: toolCalls,
-		},
-	}, nil
+	return protoToolCalls, nil
 }
 
 func roleToRolePB(role domain.Role) pb.Role {
@@ -110,6 +614,8 @@ func roleToRolePB(role domain.Role) pb.Role {
 		return pb.Role_USER
 	case domain.RoleAssistant:
 		return pb.Role_ASSISTANT
+	case domain.RoleTool:
+		return pb.Role_TOOL
 	default:
 		return pb.Role_SYSTEM
 	}
@@ -123,6 +629,8 @@ func rolePBToRole(role pb.Role) domain.Role {
 		return domain.RoleUser
 	case pb.Role_ASSISTANT:
 		return domain.RoleAssistant
+	case pb.Role_TOOL:
+		return domain.RoleTool
 	default:
 		return domain.RoleSystem
 	}
@@ -143,13 +651,25 @@ func schemaPBToSchema(protoSchema *pb.Schema) *domain.Schema {
 		items = schemaPBToSchema(protoSchema.Items)
 	}
 
+	var anyOf []domain.Schema
+	for _, v := range protoSchema.AnyOf {
+		anyOf = append(anyOf, *schemaPBToSchema(v))
+	}
+
 	return &domain.Schema{
-		Type:        domain.Type(protoSchema.Type.Value),
-		Description: protoSchema.Description,
-		Properties:  properties,
-		Items:       items,
-		MaxItems:    &protoSchema.MaxItems,
-		MinItems:    &protoSchema.MinItems,
-		Required:    protoSchema.Required,
+		Type:             domain.Type(protoSchema.GetType().GetValue()),
+		Description:      protoSchema.Description,
+		Properties:       properties,
+		Items:            items,
+		MaxItems:         protoSchema.MaxItems,
+		MinItems:         protoSchema.MinItems,
+		Required:         protoSchema.Required,
+		Enum:             protoSchema.Enum,
+		Nullable:         protoSchema.Nullable,
+		Minimum:          protoSchema.Minimum,
+		Maximum:          protoSchema.Maximum,
+		Format:           protoSchema.Format,
+		PropertyOrdering: protoSchema.PropertyOrdering,
+		AnyOf:            anyOf,
 	}
 }
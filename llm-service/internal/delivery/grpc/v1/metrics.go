@@ -0,0 +1,56 @@
+package grpcv1
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llm_grpc_requests_total",
+		Help: "Handled RPCs by method and gRPC status code.",
+	}, []string{"method", "code"})
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "llm_grpc_request_duration_seconds",
+		Help: "Latency of the RPCs by method, including the time spent waiting for admission. Streams are " +
+			"measured until the last message is sent.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"method"})
+)
+
+// MetricsUnaryInterceptor measures the latency and counts the status codes of the unary calls.
+func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		startedAt := time.Now()
+
+		resp, err := handler(ctx, req)
+		recordRPC(info.FullMethod, startedAt, err)
+
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor measures the latency and counts the status codes of the streaming calls.
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		startedAt := time.Now()
+
+		err := handler(srv, stream)
+		recordRPC(info.FullMethod, startedAt, err)
+
+		return err
+	}
+}
+
+func recordRPC(fullMethod string, startedAt time.Time, err error) {
+	method := path.Base(fullMethod)
+
+	rpcDuration.WithLabelValues(method).Observe(time.Since(startedAt).Seconds())
+	rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+}
@@ -0,0 +1,10 @@
+package domain
+
+// Caller identifies the service calling llm-service and the end user on whose behalf the call is made.
+// The values are passed by the callers in gRPC metadata and are empty when not provided.
+type Caller struct {
+	Name   string
+	UserID string
+	// Tier is the subscription tier of the user, which prioritizes the requests of paying users.
+	Tier string
+}
@@ -1,9 +1,12 @@
 package domain
 
+import "time"
+
 const (
 	RoleSystem    Role = "system"
 	RoleUser      Role = "user"
 	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
 
 	TypeString  Type = "STRING"
 	TypeNumber  Type = "NUMBER"
@@ -11,15 +14,106 @@ const (
 	TypeBoolean Type = "BOOLEAN"
 	TypeArray   Type = "ARRAY"
 	TypeObject  Type = "OBJECT"
+
+	CacheStatusBypass CacheStatus = "bypass"
+	CacheStatusMiss   CacheStatus = "miss"
+	CacheStatusHit    CacheStatus = "hit"
+
+	ContextOverflowReject    ContextOverflowPolicy = "reject"
+	ContextOverflowTruncate  ContextOverflowPolicy = "truncate"
+	ContextOverflowSummarize ContextOverflowPolicy = "summarize"
+
+	SafetyCategorySelfHarm SafetyCategory = "self_harm"
+	SafetyCategoryAbuse    SafetyCategory = "abuse"
 )
 
 type Role string
 type Type string
+type CacheStatus string
+type ContextOverflowPolicy string
+type SafetyCategory string
 
 type Message struct {
 	Role      Role
 	Text      string
 	ToolCalls []ToolCall
+	// ToolResult is only set in messages with RoleTool.
+	ToolResult *ToolResult
+	// Attachments are only set in messages with RoleUser.
+	Attachments []Attachment
+}
+
+// Attachment is a document or an image sent to the model. Either Data or URI is set.
+type Attachment struct {
+	MIMEType string
+	Data     []byte
+	URI      string
+}
+
+// Response is a generated assistant message together with the resources spent on it.
+// ContextTrimming is only set if the chat history was shortened to fit into the context window.
+type Response struct {
+	Message         Message
+	Usage           Usage
+	ContextTrimming *ContextTrimming
+	SafetyFlags     []SafetyFlag
+}
+
+// MessageDelta is a part of an assistant message received while the response is still being generated.
+// Usage, ContextTrimming and SafetyFlags are only set in the last delta of the response.
+type MessageDelta struct {
+	Text            string
+	ToolCalls       []ToolCall
+	Usage           *Usage
+	ContextTrimming *ContextTrimming
+	SafetyFlags     []SafetyFlag
+}
+
+// Usage describes the resources spent on generating a single response.
+type Usage struct {
+	PromptTokens     int32
+	CompletionTokens int32
+	Model            string
+	Latency          time.Duration
+	CacheStatus      CacheStatus
+}
+
+// TokenCount is the size of the prompt for the model, which must not exceed its InputTokenLimit.
+type TokenCount struct {
+	PromptTokens    int32
+	InputTokenLimit int32
+	Model           string
+}
+
+// ContextTrimming describes how the chat history was shortened to fit into the context window of the model.
+type ContextTrimming struct {
+	Policy               ContextOverflowPolicy
+	OriginalPromptTokens int32
+	PromptTokens         int32
+	InputTokenLimit      int32
+	Messages             []TrimmedMessage
+}
+
+// TrimmedMessage is the message of the chat history at Index which was shortened. The token counts
+// of single messages are estimated.
+type TrimmedMessage struct {
+	Index          int
+	OriginalTokens int32
+	Tokens         int32
+}
+
+// SafetyFlag marks a disclosure of self-harm or abuse in the message of the chat history at MessageIndex,
+// which a counselor should be alerted about. Phrase is the matched phrase, not the surrounding text.
+type SafetyFlag struct {
+	Category     SafetyCategory
+	MessageIndex int
+	Phrase       string
+}
+
+// Embeddings are the vectors of the embedded texts, in the same order as the texts.
+type Embeddings struct {
+	Vectors [][]float32
+	Model   string
 }
 
 type ToolCall struct {
@@ -28,12 +122,49 @@ type ToolCall struct {
 	Parameters map[string]any
 }
 
+// ToolResult is the output of the tool called by the assistant, sent back to the model.
+type ToolResult struct {
+	ToolCallID string
+	Name       string
+	Result     map[string]any
+}
+
 type ToolDefinition struct {
 	Name             string
 	Description      string
 	ParametersSchema *Schema
 }
 
+// GenerationOptions tune the generation for a single request. Nil and empty fields
+// mean that the provider defaults should be used.
+type GenerationOptions struct {
+	Model           string
+	Temperature     *float32
+	TopP            *float32
+	MaxOutputTokens *int32
+	StopSequences   []string
+	Seed            *int32
+	NoCache         bool
+	// Empty ContextOverflowPolicy means ContextOverflowReject.
+	ContextOverflowPolicy ContextOverflowPolicy
+}
+
+// GenerationRequest is everything needed to generate a response, stored with the batches
+// which are processed asynchronously.
+type GenerationRequest struct {
+	ChatHistory            []Message
+	Tools                  []ToolDefinition
+	StructuredOutputSchema *Schema
+	Options                GenerationOptions
+}
+
+// SchemaViolation describes the part of the structured output at Path which doesn't conform to the schema.
+type SchemaViolation struct {
+	Path        string
+	Description string
+}
+
+// Schema describes structured output and tool parameters. Type is empty when the value is described by AnyOf.
 type Schema struct {
 	Type        Type
 	Description string
@@ -42,4 +173,15 @@ type Schema struct {
 	MaxItems    *int64
 	MinItems    *int64
 	Required    []string
+	// Enum lists the allowed values of a string.
+	Enum     []string
+	Nullable bool
+	// Minimum and Maximum are inclusive bounds of a number or an integer.
+	Minimum *float64
+	Maximum *float64
+	// Format of a string, for example "date-time".
+	Format string
+	// PropertyOrdering is the order in which the properties are generated.
+	PropertyOrdering []string
+	AnyOf            []Schema
 }
@@ -0,0 +1,8 @@
+package domain
+
+// RenderedPrompt is the text of the prompt template rendered with the variables of the caller.
+type RenderedPrompt struct {
+	Text    string
+	Name    string
+	Version int32
+}
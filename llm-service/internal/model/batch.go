@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemPending   BatchItemStatus = "pending"
+	BatchItemRunning   BatchItemStatus = "running"
+	BatchItemSucceeded BatchItemStatus = "succeeded"
+	BatchItemFailed    BatchItemStatus = "failed"
+)
+
+// Batch is a set of independent generation requests submitted by the caller on behalf of the user.
+// The counts of items are computed from their statuses.
+type Batch struct {
+	ID     string
+	Caller domain.Caller
+	// ProviderJobID is the ID of the batch job of the provider which generates the items of the batch.
+	// It is empty if the items are generated one by one.
+	ProviderJobID  string
+	TotalItems     int
+	PendingItems   int
+	RunningItems   int
+	SucceededItems int
+	FailedItems    int
+	CreatedAt      time.Time
+	// CompletedAt is only set once all items succeeded or failed.
+	CompletedAt *time.Time
+}
+
+// BatchItem is a request of the batch at Index together with its result. Response is only set
+// if the item succeeded and Error only if it failed.
+type BatchItem struct {
+	BatchID     string
+	Index       int
+	CustomID    string
+	Status      BatchItemStatus
+	Request     domain.GenerationRequest
+	Response    *domain.Response
+	Error       string
+	CompletedAt *time.Time
+}
+
+// ClaimedBatchItem is an item taken for processing together with the caller of its batch. Attempts counts
+// the claims of the item, including this one.
+type ClaimedBatchItem struct {
+	BatchItem
+	Caller   domain.Caller
+	Attempts int
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+type ConversationHistoryPolicy string
+
+const (
+	// ConversationHistoryKeepAll sends the whole history, subject to the context overflow policy of the request.
+	ConversationHistoryKeepAll ConversationHistoryPolicy = "keep_all"
+	// ConversationHistoryTrim stops sending the earliest messages once the history grows too long.
+	ConversationHistoryTrim ConversationHistoryPolicy = "trim"
+	// ConversationHistorySummarize replaces the earliest messages with their summary once the history
+	// grows too long.
+	ConversationHistorySummarize ConversationHistoryPolicy = "summarize"
+)
+
+// Conversation is a chat history stored in llm-service, so that the callers only send the new messages.
+// It expires at ExpiresAt, which is extended whenever messages are appended.
+type Conversation struct {
+	ID     string
+	Caller domain.Caller
+	// The history is trimmed or summarized according to HistoryPolicy once its estimated size
+	// exceeds MaxHistoryTokens.
+	HistoryPolicy    ConversationHistoryPolicy
+	MaxHistoryTokens int
+	MessageCount     int
+	// CompactedMessages is the number of messages following the leading system messages which are no longer
+	// sent to the model. They are replaced with Summary, unless the history is trimmed.
+	CompactedMessages int
+	Summary           string
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type PromptTemplate struct {
+	Name      string
+	Version   int32
+	Body      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type UsageRecord struct {
+	Caller           string
+	UserID           string
+	Model            string
+	PromptTokens     int32
+	CompletionTokens int32
+	Latency          time.Duration
+	CreatedAt        time.Time
+}
+
+type UsageAggregate struct {
+	Day              time.Time
+	Caller           string
+	UserID           string
+	Requests         int64
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+// UsageFilter selects usage records created in [Since, Until). Empty Caller and UserID match everything.
+type UsageFilter struct {
+	Since  time.Time
+	Until  time.Time
+	Caller string
+	UserID string
+}
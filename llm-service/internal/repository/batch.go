@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+// BatchRepository stores the batches of generation requests and the results of their items. Items are
+// processed by the workers of all replicas, which claim them for the duration of a lease.
+type BatchRepository interface {
+	CreateBatch(ctx context.Context, batch model.Batch, items []model.BatchItem) error
+	// GetBatch returns nil if there is no such batch.
+	GetBatch(ctx context.Context, id string) (*model.Batch, error)
+	// GetBatchItems returns the items starting from fromIndex, ordered by index, without their requests.
+	GetBatchItems(ctx context.Context, batchID string, fromIndex int) ([]model.BatchItem, error)
+	// GetBatchRequests returns the requests of all items of the batch, ordered by index.
+	GetBatchRequests(ctx context.Context, batchID string) ([]domain.GenerationRequest, error)
+	// ClaimBatchItems marks at most limit pending items as running, together with the running items
+	// claimed before leaseExpiredBefore, which the worker must have abandoned or left to be retried.
+	// The items of the batches processed by provider jobs are skipped.
+	ClaimBatchItems(ctx context.Context, limit int, leaseExpiredBefore time.Time) ([]model.ClaimedBatchItem, error)
+	// CompleteBatchItem stores the status and the result of the running item.
+	CompleteBatchItem(ctx context.Context, item model.BatchItem) error
+	// ClaimBatchJobs returns at most limit batches processed by provider jobs which were last polled
+	// before polledBefore, marking them as polled now.
+	ClaimBatchJobs(ctx context.Context, limit int, polledBefore time.Time) ([]model.Batch, error)
+	// CompleteBatchJob stores the results of the succeeded items of the finished provider job and makes
+	// the rest of the items pending, so that they are processed one by one.
+	CompleteBatchJob(ctx context.Context, batchID string, succeededItems []model.BatchItem) error
+}
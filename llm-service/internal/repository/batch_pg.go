@@ -0,0 +1,333 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+type pgBatchRepository struct {
+	db *sql.DB
+}
+
+func NewPgBatchRepository(db *sql.DB) BatchRepository {
+	return &pgBatchRepository{db: db}
+}
+
+func (r *pgBatchRepository) CreateBatch(ctx context.Context, batch model.Batch, items []model.BatchItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	// The provider job is first polled after the poll interval passes.
+	batchQuery := `
+		INSERT INTO llm_batches (id, caller, user_id, tier, provider_job_id, provider_job_polled_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)`
+
+	_, err = tx.ExecContext(ctx, batchQuery,
+		batch.ID, batch.Caller.Name, batch.Caller.UserID, batch.Caller.Tier, batch.ProviderJobID, batch.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert batch: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO llm_batch_items (batch_id, item_index, custom_id, status, request)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, item := range items {
+		request, err := json.Marshal(item.Request)
+		if err != nil {
+			return fmt.Errorf("failed to encode request of batch item %d: %w", item.Index, err)
+		}
+
+		_, err = tx.ExecContext(ctx, itemQuery, batch.ID, item.Index, item.CustomID, string(item.Status), request)
+		if err != nil {
+			return fmt.Errorf("failed to insert batch item %d: %w", item.Index, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pgBatchRepository) GetBatch(ctx context.Context, id string) (*model.Batch, error) {
+	query := `
+		SELECT b.id, b.caller, b.user_id, b.tier, b.provider_job_id, b.created_at,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE i.status = 'pending'),
+		       COUNT(*) FILTER (WHERE i.status = 'running'),
+		       COUNT(*) FILTER (WHERE i.status = 'succeeded'),
+		       COUNT(*) FILTER (WHERE i.status = 'failed'),
+		       MAX(i.completed_at)
+		FROM llm_batches b
+		JOIN llm_batch_items i ON i.batch_id = b.id
+		WHERE b.id = $1
+		GROUP BY b.id`
+
+	var batch model.Batch
+	var completedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&batch.ID, &batch.Caller.Name, &batch.Caller.UserID, &batch.Caller.Tier, &batch.ProviderJobID, &batch.CreatedAt,
+		&batch.TotalItems, &batch.PendingItems, &batch.RunningItems, &batch.SucceededItems, &batch.FailedItems,
+		&completedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch: %w", err)
+	}
+
+	if batch.PendingItems == 0 && batch.RunningItems == 0 && completedAt.Valid {
+		batch.CompletedAt = &completedAt.Time
+	}
+
+	return &batch, nil
+}
+
+func (r *pgBatchRepository) GetBatchItems(ctx context.Context, batchID string, fromIndex int) ([]model.BatchItem, error) {
+	query := `
+		SELECT batch_id, item_index, custom_id, status, response, error, completed_at
+		FROM llm_batch_items
+		WHERE batch_id = $1 AND item_index >= $2
+		ORDER BY item_index`
+
+	rows, err := r.db.QueryContext(ctx, query, batchID, fromIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch items: %w", err)
+	}
+
+	defer rows.Close()
+
+	var items []model.BatchItem
+	for rows.Next() {
+		var item model.BatchItem
+		var response []byte
+		var completedAt sql.NullTime
+
+		err := rows.Scan(&item.BatchID, &item.Index, &item.CustomID, &item.Status, &response, &item.Error, &completedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan batch item: %w", err)
+		}
+
+		if response != nil {
+			item.Response = &domain.Response{}
+			if err := json.Unmarshal(response, item.Response); err != nil {
+				return nil, fmt.Errorf("failed to decode response of batch item %d: %w", item.Index, err)
+			}
+		}
+
+		if completedAt.Valid {
+			item.CompletedAt = &completedAt.Time
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over batch items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *pgBatchRepository) GetBatchRequests(ctx context.Context, batchID string) ([]domain.GenerationRequest, error) {
+	query := `SELECT request FROM llm_batch_items WHERE batch_id = $1 ORDER BY item_index`
+
+	rows, err := r.db.QueryContext(ctx, query, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch requests: %w", err)
+	}
+
+	defer rows.Close()
+
+	var requests []domain.GenerationRequest
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan batch request: %w", err)
+		}
+
+		var request domain.GenerationRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, fmt.Errorf("failed to decode batch request: %w", err)
+		}
+
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over batch requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+func (r *pgBatchRepository) ClaimBatchItems(
+	ctx context.Context, limit int, leaseExpiredBefore time.Time) ([]model.ClaimedBatchItem, error) {
+	// SKIP LOCKED lets the workers of other replicas claim different items at the same time.
+	// The oldest batches go first.
+	query := `
+		UPDATE llm_batch_items i
+		SET status = 'running', claimed_at = NOW(), attempts = i.attempts + 1
+		FROM llm_batches b
+		WHERE b.id = i.batch_id AND (i.batch_id, i.item_index) IN (
+			SELECT ci.batch_id, ci.item_index
+			FROM llm_batch_items ci
+			JOIN llm_batches cb ON cb.id = ci.batch_id
+			WHERE cb.provider_job_id = ''
+			  AND (ci.status = 'pending' OR (ci.status = 'running' AND ci.claimed_at < $2))
+			ORDER BY cb.created_at, ci.item_index
+			LIMIT $1
+			FOR UPDATE OF ci SKIP LOCKED)
+		RETURNING i.batch_id, i.item_index, i.custom_id, i.request, i.attempts, b.caller, b.user_id, b.tier`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, leaseExpiredBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim batch items: %w", err)
+	}
+
+	defer rows.Close()
+
+	var items []model.ClaimedBatchItem
+	for rows.Next() {
+		item := model.ClaimedBatchItem{BatchItem: model.BatchItem{Status: model.BatchItemRunning}}
+		var request []byte
+
+		err := rows.Scan(&item.BatchID, &item.Index, &item.CustomID, &request, &item.Attempts,
+			&item.Caller.Name, &item.Caller.UserID, &item.Caller.Tier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan claimed batch item: %w", err)
+		}
+
+		if err := json.Unmarshal(request, &item.Request); err != nil {
+			return nil, fmt.Errorf("failed to decode request of batch item %d: %w", item.Index, err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over claimed batch items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *pgBatchRepository) CompleteBatchItem(ctx context.Context, item model.BatchItem) error {
+	return completeBatchItem(ctx, r.db, item)
+}
+
+func (r *pgBatchRepository) ClaimBatchJobs(ctx context.Context, limit int, polledBefore time.Time) ([]model.Batch, error) {
+	query := `
+		UPDATE llm_batches b
+		SET provider_job_polled_at = NOW()
+		WHERE b.id IN (
+			SELECT id
+			FROM llm_batches
+			WHERE provider_job_id <> '' AND provider_job_polled_at < $2
+			ORDER BY provider_job_polled_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING b.id, b.caller, b.user_id, b.tier, b.provider_job_id, b.created_at,
+		          (SELECT COUNT(*) FROM llm_batch_items i WHERE i.batch_id = b.id)`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, polledBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim batch jobs: %w", err)
+	}
+
+	defer rows.Close()
+
+	var batches []model.Batch
+	for rows.Next() {
+		var batch model.Batch
+		err := rows.Scan(&batch.ID, &batch.Caller.Name, &batch.Caller.UserID, &batch.Caller.Tier,
+			&batch.ProviderJobID, &batch.CreatedAt, &batch.TotalItems)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan batch: %w", err)
+		}
+
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+func (r *pgBatchRepository) CompleteBatchJob(ctx context.Context, batchID string, succeededItems []model.BatchItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	for _, item := range succeededItems {
+		if err := completeBatchItem(ctx, tx, item); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE llm_batch_items
+		SET status = 'pending', claimed_at = NULL
+		WHERE batch_id = $1 AND status = 'running'`, batchID)
+	if err != nil {
+		return fmt.Errorf("failed to release batch items: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE llm_batches SET provider_job_id = '' WHERE id = $1`, batchID)
+	if err != nil {
+		return fmt.Errorf("failed to update batch: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func completeBatchItem(ctx context.Context, db execer, item model.BatchItem) error {
+	var response []byte
+	if item.Response != nil {
+		var err error
+
+		response, err = json.Marshal(item.Response)
+		if err != nil {
+			return fmt.Errorf("failed to encode response of batch item %d: %w", item.Index, err)
+		}
+	}
+
+	query := `
+		UPDATE llm_batch_items
+		SET status = $3, response = $4, error = $5, completed_at = NOW()
+		WHERE batch_id = $1 AND item_index = $2 AND status = 'running'`
+
+	_, err := db.ExecContext(ctx, query, item.BatchID, item.Index, string(item.Status), response, item.Error)
+	if err != nil {
+		return fmt.Errorf("failed to update batch item %d: %w", item.Index, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+// ConversationRepository stores the conversations and their messages. The expired conversations
+// are treated as missing until they are deleted.
+type ConversationRepository interface {
+	CreateConversation(ctx context.Context, conversation model.Conversation, messages []domain.Message) error
+	// GetConversation returns nil if there is no such conversation or it has expired.
+	GetConversation(ctx context.Context, id string) (*model.Conversation, error)
+	// GetConversationMessages returns all messages of the conversation, ordered by index.
+	GetConversationMessages(ctx context.Context, id string) ([]domain.Message, error)
+	// AppendConversationMessages appends the messages to the conversation and extends it until expiresAt.
+	// It returns the updated conversation, or nil if there is no such conversation or it has expired.
+	AppendConversationMessages(
+		ctx context.Context, id string, messages []domain.Message, expiresAt time.Time) (*model.Conversation, error)
+	// CompactConversation stores the number of compacted messages and their summary, unless more messages
+	// were already compacted by a concurrent request.
+	CompactConversation(ctx context.Context, id string, compactedMessages int, summary string) error
+	DeleteConversation(ctx context.Context, id string) error
+	// DeleteExpiredConversations deletes the conversations which expired before expiredBefore and returns
+	// how many were deleted.
+	DeleteExpiredConversations(ctx context.Context, expiredBefore time.Time) (int64, error)
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+type pgConversationRepository struct {
+	db *sql.DB
+}
+
+func NewPgConversationRepository(db *sql.DB) ConversationRepository {
+	return &pgConversationRepository{db: db}
+}
+
+func (r *pgConversationRepository) CreateConversation(
+	ctx context.Context, conversation model.Conversation, messages []domain.Message) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO llm_conversations (id, caller, user_id, tier, history_policy, max_history_tokens,
+		                               message_count, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = tx.ExecContext(ctx, query, conversation.ID, conversation.Caller.Name, conversation.Caller.UserID,
+		conversation.Caller.Tier, string(conversation.HistoryPolicy), conversation.MaxHistoryTokens,
+		len(messages), conversation.CreatedAt, conversation.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert conversation: %w", err)
+	}
+
+	if err := insertConversationMessages(ctx, tx, conversation.ID, 0, messages); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pgConversationRepository) GetConversation(ctx context.Context, id string) (*model.Conversation, error) {
+	query := `
+		SELECT id, caller, user_id, tier, history_policy, max_history_tokens, message_count,
+		       compacted_messages, summary, created_at, expires_at
+		FROM llm_conversations
+		WHERE id = $1 AND expires_at > NOW()`
+
+	conversation, err := scanConversation(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+func (r *pgConversationRepository) GetConversationMessages(ctx context.Context, id string) ([]domain.Message, error) {
+	query := `SELECT message FROM llm_conversation_messages WHERE conversation_id = $1 ORDER BY message_index`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conversation messages: %w", err)
+	}
+
+	defer rows.Close()
+
+	var messages []domain.Message
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan conversation message: %w", err)
+		}
+
+		var message domain.Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			return nil, fmt.Errorf("failed to decode conversation message: %w", err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over conversation messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r *pgConversationRepository) AppendConversationMessages(
+	ctx context.Context, id string, messages []domain.Message, expiresAt time.Time) (*model.Conversation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	// The row stays locked until the transaction ends, so the concurrent requests append their messages
+	// one after another.
+	query := `
+		UPDATE llm_conversations
+		SET message_count = message_count + $2, expires_at = $3
+		WHERE id = $1 AND expires_at > NOW()
+		RETURNING id, caller, user_id, tier, history_policy, max_history_tokens, message_count,
+		          compacted_messages, summary, created_at, expires_at`
+
+	conversation, err := scanConversation(tx.QueryRowContext(ctx, query, id, len(messages), expiresAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update conversation: %w", err)
+	}
+
+	firstIndex := conversation.MessageCount - len(messages)
+	if err := insertConversationMessages(ctx, tx, id, firstIndex, messages); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return conversation, nil
+}
+
+func (r *pgConversationRepository) CompactConversation(
+	ctx context.Context, id string, compactedMessages int, summary string) error {
+	query := `
+		UPDATE llm_conversations
+		SET compacted_messages = $2, summary = $3
+		WHERE id = $1 AND compacted_messages < $2`
+
+	if _, err := r.db.ExecContext(ctx, query, id, compactedMessages, summary); err != nil {
+		return fmt.Errorf("failed to compact conversation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pgConversationRepository) DeleteConversation(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM llm_conversations WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete conversation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pgConversationRepository) DeleteExpiredConversations(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM llm_conversations WHERE expires_at < $1`, expiredBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired conversations: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted conversations: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func insertConversationMessages(
+	ctx context.Context, db execer, conversationID string, firstIndex int, messages []domain.Message) error {
+	query := `
+		INSERT INTO llm_conversation_messages (conversation_id, message_index, message)
+		VALUES ($1, $2, $3)`
+
+	for i, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to encode conversation message %d: %w", firstIndex+i, err)
+		}
+
+		if _, err := db.ExecContext(ctx, query, conversationID, firstIndex+i, data); err != nil {
+			return fmt.Errorf("failed to insert conversation message %d: %w", firstIndex+i, err)
+		}
+	}
+
+	return nil
+}
+
+func scanConversation(row *sql.Row) (*model.Conversation, error) {
+	var conversation model.Conversation
+	err := row.Scan(&conversation.ID, &conversation.Caller.Name, &conversation.Caller.UserID, &conversation.Caller.Tier,
+		&conversation.HistoryPolicy, &conversation.MaxHistoryTokens, &conversation.MessageCount,
+		&conversation.CompactedMessages, &conversation.Summary, &conversation.CreatedAt, &conversation.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
@@ -0,0 +1,1565 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBatchRepository creates a new instance of MockBatchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBatchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBatchRepository {
+	mock := &MockBatchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBatchRepository is an autogenerated mock type for the BatchRepository type
+type MockBatchRepository struct {
+	mock.Mock
+}
+
+type MockBatchRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBatchRepository) EXPECT() *MockBatchRepository_Expecter {
+	return &MockBatchRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimBatchItems provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) ClaimBatchItems(ctx context.Context, limit int, leaseExpiredBefore time.Time) ([]model.ClaimedBatchItem, error) {
+	ret := _mock.Called(ctx, limit, leaseExpiredBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimBatchItems")
+	}
+
+	var r0 []model.ClaimedBatchItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]model.ClaimedBatchItem, error)); ok {
+		return returnFunc(ctx, limit, leaseExpiredBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) []model.ClaimedBatchItem); ok {
+		r0 = returnFunc(ctx, limit, leaseExpiredBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ClaimedBatchItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, limit, leaseExpiredBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_ClaimBatchItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimBatchItems'
+type MockBatchRepository_ClaimBatchItems_Call struct {
+	*mock.Call
+}
+
+// ClaimBatchItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - leaseExpiredBefore time.Time
+func (_e *MockBatchRepository_Expecter) ClaimBatchItems(ctx interface{}, limit interface{}, leaseExpiredBefore interface{}) *MockBatchRepository_ClaimBatchItems_Call {
+	return &MockBatchRepository_ClaimBatchItems_Call{Call: _e.mock.On("ClaimBatchItems", ctx, limit, leaseExpiredBefore)}
+}
+
+func (_c *MockBatchRepository_ClaimBatchItems_Call) Run(run func(ctx context.Context, limit int, leaseExpiredBefore time.Time)) *MockBatchRepository_ClaimBatchItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_ClaimBatchItems_Call) Return(claimedBatchItems []model.ClaimedBatchItem, err error) *MockBatchRepository_ClaimBatchItems_Call {
+	_c.Call.Return(claimedBatchItems, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_ClaimBatchItems_Call) RunAndReturn(run func(ctx context.Context, limit int, leaseExpiredBefore time.Time) ([]model.ClaimedBatchItem, error)) *MockBatchRepository_ClaimBatchItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimBatchJobs provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) ClaimBatchJobs(ctx context.Context, limit int, polledBefore time.Time) ([]model.Batch, error) {
+	ret := _mock.Called(ctx, limit, polledBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimBatchJobs")
+	}
+
+	var r0 []model.Batch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]model.Batch, error)); ok {
+		return returnFunc(ctx, limit, polledBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) []model.Batch); ok {
+		r0 = returnFunc(ctx, limit, polledBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Batch)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, limit, polledBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_ClaimBatchJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimBatchJobs'
+type MockBatchRepository_ClaimBatchJobs_Call struct {
+	*mock.Call
+}
+
+// ClaimBatchJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - polledBefore time.Time
+func (_e *MockBatchRepository_Expecter) ClaimBatchJobs(ctx interface{}, limit interface{}, polledBefore interface{}) *MockBatchRepository_ClaimBatchJobs_Call {
+	return &MockBatchRepository_ClaimBatchJobs_Call{Call: _e.mock.On("ClaimBatchJobs", ctx, limit, polledBefore)}
+}
+
+func (_c *MockBatchRepository_ClaimBatchJobs_Call) Run(run func(ctx context.Context, limit int, polledBefore time.Time)) *MockBatchRepository_ClaimBatchJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_ClaimBatchJobs_Call) Return(batchs []model.Batch, err error) *MockBatchRepository_ClaimBatchJobs_Call {
+	_c.Call.Return(batchs, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_ClaimBatchJobs_Call) RunAndReturn(run func(ctx context.Context, limit int, polledBefore time.Time) ([]model.Batch, error)) *MockBatchRepository_ClaimBatchJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteBatchItem provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) CompleteBatchItem(ctx context.Context, item model.BatchItem) error {
+	ret := _mock.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteBatchItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BatchItem) error); ok {
+		r0 = returnFunc(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchRepository_CompleteBatchItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteBatchItem'
+type MockBatchRepository_CompleteBatchItem_Call struct {
+	*mock.Call
+}
+
+// CompleteBatchItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item model.BatchItem
+func (_e *MockBatchRepository_Expecter) CompleteBatchItem(ctx interface{}, item interface{}) *MockBatchRepository_CompleteBatchItem_Call {
+	return &MockBatchRepository_CompleteBatchItem_Call{Call: _e.mock.On("CompleteBatchItem", ctx, item)}
+}
+
+func (_c *MockBatchRepository_CompleteBatchItem_Call) Run(run func(ctx context.Context, item model.BatchItem)) *MockBatchRepository_CompleteBatchItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.BatchItem
+		if args[1] != nil {
+			arg1 = args[1].(model.BatchItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_CompleteBatchItem_Call) Return(err error) *MockBatchRepository_CompleteBatchItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBatchRepository_CompleteBatchItem_Call) RunAndReturn(run func(ctx context.Context, item model.BatchItem) error) *MockBatchRepository_CompleteBatchItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteBatchJob provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) CompleteBatchJob(ctx context.Context, batchID string, succeededItems []model.BatchItem) error {
+	ret := _mock.Called(ctx, batchID, succeededItems)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteBatchJob")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []model.BatchItem) error); ok {
+		r0 = returnFunc(ctx, batchID, succeededItems)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchRepository_CompleteBatchJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteBatchJob'
+type MockBatchRepository_CompleteBatchJob_Call struct {
+	*mock.Call
+}
+
+// CompleteBatchJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+//   - succeededItems []model.BatchItem
+func (_e *MockBatchRepository_Expecter) CompleteBatchJob(ctx interface{}, batchID interface{}, succeededItems interface{}) *MockBatchRepository_CompleteBatchJob_Call {
+	return &MockBatchRepository_CompleteBatchJob_Call{Call: _e.mock.On("CompleteBatchJob", ctx, batchID, succeededItems)}
+}
+
+func (_c *MockBatchRepository_CompleteBatchJob_Call) Run(run func(ctx context.Context, batchID string, succeededItems []model.BatchItem)) *MockBatchRepository_CompleteBatchJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []model.BatchItem
+		if args[2] != nil {
+			arg2 = args[2].([]model.BatchItem)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_CompleteBatchJob_Call) Return(err error) *MockBatchRepository_CompleteBatchJob_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBatchRepository_CompleteBatchJob_Call) RunAndReturn(run func(ctx context.Context, batchID string, succeededItems []model.BatchItem) error) *MockBatchRepository_CompleteBatchJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) CreateBatch(ctx context.Context, batch model.Batch, items []model.BatchItem) error {
+	ret := _mock.Called(ctx, batch, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Batch, []model.BatchItem) error); ok {
+		r0 = returnFunc(ctx, batch, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockBatchRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch model.Batch
+//   - items []model.BatchItem
+func (_e *MockBatchRepository_Expecter) CreateBatch(ctx interface{}, batch interface{}, items interface{}) *MockBatchRepository_CreateBatch_Call {
+	return &MockBatchRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, batch, items)}
+}
+
+func (_c *MockBatchRepository_CreateBatch_Call) Run(run func(ctx context.Context, batch model.Batch, items []model.BatchItem)) *MockBatchRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Batch
+		if args[1] != nil {
+			arg1 = args[1].(model.Batch)
+		}
+		var arg2 []model.BatchItem
+		if args[2] != nil {
+			arg2 = args[2].([]model.BatchItem)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_CreateBatch_Call) Return(err error) *MockBatchRepository_CreateBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBatchRepository_CreateBatch_Call) RunAndReturn(run func(ctx context.Context, batch model.Batch, items []model.BatchItem) error) *MockBatchRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatch provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) GetBatch(ctx context.Context, id string) (*model.Batch, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatch")
+	}
+
+	var r0 *model.Batch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Batch, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Batch); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Batch)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_GetBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatch'
+type MockBatchRepository_GetBatch_Call struct {
+	*mock.Call
+}
+
+// GetBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBatchRepository_Expecter) GetBatch(ctx interface{}, id interface{}) *MockBatchRepository_GetBatch_Call {
+	return &MockBatchRepository_GetBatch_Call{Call: _e.mock.On("GetBatch", ctx, id)}
+}
+
+func (_c *MockBatchRepository_GetBatch_Call) Run(run func(ctx context.Context, id string)) *MockBatchRepository_GetBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatch_Call) Return(batch *model.Batch, err error) *MockBatchRepository_GetBatch_Call {
+	_c.Call.Return(batch, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatch_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Batch, error)) *MockBatchRepository_GetBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchItems provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) GetBatchItems(ctx context.Context, batchID string, fromIndex int) ([]model.BatchItem, error) {
+	ret := _mock.Called(ctx, batchID, fromIndex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchItems")
+	}
+
+	var r0 []model.BatchItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model.BatchItem, error)); ok {
+		return returnFunc(ctx, batchID, fromIndex)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model.BatchItem); ok {
+		r0 = returnFunc(ctx, batchID, fromIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.BatchItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, batchID, fromIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_GetBatchItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchItems'
+type MockBatchRepository_GetBatchItems_Call struct {
+	*mock.Call
+}
+
+// GetBatchItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+//   - fromIndex int
+func (_e *MockBatchRepository_Expecter) GetBatchItems(ctx interface{}, batchID interface{}, fromIndex interface{}) *MockBatchRepository_GetBatchItems_Call {
+	return &MockBatchRepository_GetBatchItems_Call{Call: _e.mock.On("GetBatchItems", ctx, batchID, fromIndex)}
+}
+
+func (_c *MockBatchRepository_GetBatchItems_Call) Run(run func(ctx context.Context, batchID string, fromIndex int)) *MockBatchRepository_GetBatchItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatchItems_Call) Return(batchItems []model.BatchItem, err error) *MockBatchRepository_GetBatchItems_Call {
+	_c.Call.Return(batchItems, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatchItems_Call) RunAndReturn(run func(ctx context.Context, batchID string, fromIndex int) ([]model.BatchItem, error)) *MockBatchRepository_GetBatchItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchRequests provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) GetBatchRequests(ctx context.Context, batchID string) ([]domain.GenerationRequest, error) {
+	ret := _mock.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchRequests")
+	}
+
+	var r0 []domain.GenerationRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.GenerationRequest, error)); ok {
+		return returnFunc(ctx, batchID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.GenerationRequest); ok {
+		r0 = returnFunc(ctx, batchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.GenerationRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, batchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_GetBatchRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchRequests'
+type MockBatchRepository_GetBatchRequests_Call struct {
+	*mock.Call
+}
+
+// GetBatchRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+func (_e *MockBatchRepository_Expecter) GetBatchRequests(ctx interface{}, batchID interface{}) *MockBatchRepository_GetBatchRequests_Call {
+	return &MockBatchRepository_GetBatchRequests_Call{Call: _e.mock.On("GetBatchRequests", ctx, batchID)}
+}
+
+func (_c *MockBatchRepository_GetBatchRequests_Call) Run(run func(ctx context.Context, batchID string)) *MockBatchRepository_GetBatchRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatchRequests_Call) Return(generationRequests []domain.GenerationRequest, err error) *MockBatchRepository_GetBatchRequests_Call {
+	_c.Call.Return(generationRequests, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatchRequests_Call) RunAndReturn(run func(ctx context.Context, batchID string) ([]domain.GenerationRequest, error)) *MockBatchRepository_GetBatchRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockConversationRepository creates a new instance of MockConversationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockConversationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockConversationRepository {
+	mock := &MockConversationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockConversationRepository is an autogenerated mock type for the ConversationRepository type
+type MockConversationRepository struct {
+	mock.Mock
+}
+
+type MockConversationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockConversationRepository) EXPECT() *MockConversationRepository_Expecter {
+	return &MockConversationRepository_Expecter{mock: &_m.Mock}
+}
+
+// AppendConversationMessages provides a mock function for the type MockConversationRepository
+func (_mock *MockConversationRepository) AppendConversationMessages(ctx context.Context, id string, messages []domain.Message, expiresAt time.Time) (*model.Conversation, error) {
+	ret := _mock.Called(ctx, id, messages, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendConversationMessages")
+	}
+
+	var r0 *model.Conversation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.Message, time.Time) (*model.Conversation, error)); ok {
+		return returnFunc(ctx, id, messages, expiresAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.Message, time.Time) *model.Conversation); ok {
+		r0 = returnFunc(ctx, id, messages, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Conversation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []domain.Message, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, messages, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockConversationRepository_AppendConversationMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendConversationMessages'
+type MockConversationRepository_AppendConversationMessages_Call struct {
+	*mock.Call
+}
+
+// AppendConversationMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - messages []domain.Message
+//   - expiresAt time.Time
+func (_e *MockConversationRepository_Expecter) AppendConversationMessages(ctx interface{}, id interface{}, messages interface{}, expiresAt interface{}) *MockConversationRepository_AppendConversationMessages_Call {
+	return &MockConversationRepository_AppendConversationMessages_Call{Call: _e.mock.On("AppendConversationMessages", ctx, id, messages, expiresAt)}
+}
+
+func (_c *MockConversationRepository_AppendConversationMessages_Call) Run(run func(ctx context.Context, id string, messages []domain.Message, expiresAt time.Time)) *MockConversationRepository_AppendConversationMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []domain.Message
+		if args[2] != nil {
+			arg2 = args[2].([]domain.Message)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConversationRepository_AppendConversationMessages_Call) Return(conversation *model.Conversation, err error) *MockConversationRepository_AppendConversationMessages_Call {
+	_c.Call.Return(conversation, err)
+	return _c
+}
+
+func (_c *MockConversationRepository_AppendConversationMessages_Call) RunAndReturn(run func(ctx context.Context, id string, messages []domain.Message, expiresAt time.Time) (*model.Conversation, error)) *MockConversationRepository_AppendConversationMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompactConversation provides a mock function for the type MockConversationRepository
+func (_mock *MockConversationRepository) CompactConversation(ctx context.Context, id string, compactedMessages int, summary string) error {
+	ret := _mock.Called(ctx, id, compactedMessages, summary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompactConversation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string) error); ok {
+		r0 = returnFunc(ctx, id, compactedMessages, summary)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockConversationRepository_CompactConversation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompactConversation'
+type MockConversationRepository_CompactConversation_Call struct {
+	*mock.Call
+}
+
+// CompactConversation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - compactedMessages int
+//   - summary string
+func (_e *MockConversationRepository_Expecter) CompactConversation(ctx interface{}, id interface{}, compactedMessages interface{}, summary interface{}) *MockConversationRepository_CompactConversation_Call {
+	return &MockConversationRepository_CompactConversation_Call{Call: _e.mock.On("CompactConversation", ctx, id, compactedMessages, summary)}
+}
+
+func (_c *MockConversationRepository_CompactConversation_Call) Run(run func(ctx context.Context, id string, compactedMessages int, summary string)) *MockConversationRepository_CompactConversation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConversationRepository_CompactConversation_Call) Return(err error) *MockConversationRepository_CompactConversation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockConversationRepository_CompactConversation_Call) RunAndReturn(run func(ctx context.Context, id string, compactedMessages int, summary string) error) *MockConversationRepository_CompactConversation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateConversation provides a mock function for the type MockConversationRepository
+func (_mock *MockConversationRepository) CreateConversation(ctx context.Context, conversation model.Conversation, messages []domain.Message) error {
+	ret := _mock.Called(ctx, conversation, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateConversation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Conversation, []domain.Message) error); ok {
+		r0 = returnFunc(ctx, conversation, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockConversationRepository_CreateConversation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConversation'
+type MockConversationRepository_CreateConversation_Call struct {
+	*mock.Call
+}
+
+// CreateConversation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - conversation model.Conversation
+//   - messages []domain.Message
+func (_e *MockConversationRepository_Expecter) CreateConversation(ctx interface{}, conversation interface{}, messages interface{}) *MockConversationRepository_CreateConversation_Call {
+	return &MockConversationRepository_CreateConversation_Call{Call: _e.mock.On("CreateConversation", ctx, conversation, messages)}
+}
+
+func (_c *MockConversationRepository_CreateConversation_Call) Run(run func(ctx context.Context, conversation model.Conversation, messages []domain.Message)) *MockConversationRepository_CreateConversation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Conversation
+		if args[1] != nil {
+			arg1 = args[1].(model.Conversation)
+		}
+		var arg2 []domain.Message
+		if args[2] != nil {
+			arg2 = args[2].([]domain.Message)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConversationRepository_CreateConversation_Call) Return(err error) *MockConversationRepository_CreateConversation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockConversationRepository_CreateConversation_Call) RunAndReturn(run func(ctx context.Context, conversation model.Conversation, messages []domain.Message) error) *MockConversationRepository_CreateConversation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConversation provides a mock function for the type MockConversationRepository
+func (_mock *MockConversationRepository) DeleteConversation(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConversation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockConversationRepository_DeleteConversation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConversation'
+type MockConversationRepository_DeleteConversation_Call struct {
+	*mock.Call
+}
+
+// DeleteConversation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockConversationRepository_Expecter) DeleteConversation(ctx interface{}, id interface{}) *MockConversationRepository_DeleteConversation_Call {
+	return &MockConversationRepository_DeleteConversation_Call{Call: _e.mock.On("DeleteConversation", ctx, id)}
+}
+
+func (_c *MockConversationRepository_DeleteConversation_Call) Run(run func(ctx context.Context, id string)) *MockConversationRepository_DeleteConversation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConversationRepository_DeleteConversation_Call) Return(err error) *MockConversationRepository_DeleteConversation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockConversationRepository_DeleteConversation_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockConversationRepository_DeleteConversation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredConversations provides a mock function for the type MockConversationRepository
+func (_mock *MockConversationRepository) DeleteExpiredConversations(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	ret := _mock.Called(ctx, expiredBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredConversations")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, expiredBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, expiredBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, expiredBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockConversationRepository_DeleteExpiredConversations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredConversations'
+type MockConversationRepository_DeleteExpiredConversations_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredConversations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - expiredBefore time.Time
+func (_e *MockConversationRepository_Expecter) DeleteExpiredConversations(ctx interface{}, expiredBefore interface{}) *MockConversationRepository_DeleteExpiredConversations_Call {
+	return &MockConversationRepository_DeleteExpiredConversations_Call{Call: _e.mock.On("DeleteExpiredConversations", ctx, expiredBefore)}
+}
+
+func (_c *MockConversationRepository_DeleteExpiredConversations_Call) Run(run func(ctx context.Context, expiredBefore time.Time)) *MockConversationRepository_DeleteExpiredConversations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConversationRepository_DeleteExpiredConversations_Call) Return(n int64, err error) *MockConversationRepository_DeleteExpiredConversations_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockConversationRepository_DeleteExpiredConversations_Call) RunAndReturn(run func(ctx context.Context, expiredBefore time.Time) (int64, error)) *MockConversationRepository_DeleteExpiredConversations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConversation provides a mock function for the type MockConversationRepository
+func (_mock *MockConversationRepository) GetConversation(ctx context.Context, id string) (*model.Conversation, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConversation")
+	}
+
+	var r0 *model.Conversation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Conversation, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Conversation); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Conversation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockConversationRepository_GetConversation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConversation'
+type MockConversationRepository_GetConversation_Call struct {
+	*mock.Call
+}
+
+// GetConversation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockConversationRepository_Expecter) GetConversation(ctx interface{}, id interface{}) *MockConversationRepository_GetConversation_Call {
+	return &MockConversationRepository_GetConversation_Call{Call: _e.mock.On("GetConversation", ctx, id)}
+}
+
+func (_c *MockConversationRepository_GetConversation_Call) Run(run func(ctx context.Context, id string)) *MockConversationRepository_GetConversation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConversationRepository_GetConversation_Call) Return(conversation *model.Conversation, err error) *MockConversationRepository_GetConversation_Call {
+	_c.Call.Return(conversation, err)
+	return _c
+}
+
+func (_c *MockConversationRepository_GetConversation_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Conversation, error)) *MockConversationRepository_GetConversation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConversationMessages provides a mock function for the type MockConversationRepository
+func (_mock *MockConversationRepository) GetConversationMessages(ctx context.Context, id string) ([]domain.Message, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConversationMessages")
+	}
+
+	var r0 []domain.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.Message, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.Message); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Message)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockConversationRepository_GetConversationMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConversationMessages'
+type MockConversationRepository_GetConversationMessages_Call struct {
+	*mock.Call
+}
+
+// GetConversationMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockConversationRepository_Expecter) GetConversationMessages(ctx interface{}, id interface{}) *MockConversationRepository_GetConversationMessages_Call {
+	return &MockConversationRepository_GetConversationMessages_Call{Call: _e.mock.On("GetConversationMessages", ctx, id)}
+}
+
+func (_c *MockConversationRepository_GetConversationMessages_Call) Run(run func(ctx context.Context, id string)) *MockConversationRepository_GetConversationMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConversationRepository_GetConversationMessages_Call) Return(messages []domain.Message, err error) *MockConversationRepository_GetConversationMessages_Call {
+	_c.Call.Return(messages, err)
+	return _c
+}
+
+func (_c *MockConversationRepository_GetConversationMessages_Call) RunAndReturn(run func(ctx context.Context, id string) ([]domain.Message, error)) *MockConversationRepository_GetConversationMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPromptTemplateRepository creates a new instance of MockPromptTemplateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPromptTemplateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPromptTemplateRepository {
+	mock := &MockPromptTemplateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPromptTemplateRepository is an autogenerated mock type for the PromptTemplateRepository type
+type MockPromptTemplateRepository struct {
+	mock.Mock
+}
+
+type MockPromptTemplateRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPromptTemplateRepository) EXPECT() *MockPromptTemplateRepository_Expecter {
+	return &MockPromptTemplateRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreatePromptTemplate provides a mock function for the type MockPromptTemplateRepository
+func (_mock *MockPromptTemplateRepository) CreatePromptTemplate(ctx context.Context, name string, body string) (*model.PromptTemplate, error) {
+	ret := _mock.Called(ctx, name, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePromptTemplate")
+	}
+
+	var r0 *model.PromptTemplate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.PromptTemplate, error)); ok {
+		return returnFunc(ctx, name, body)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.PromptTemplate); ok {
+		r0 = returnFunc(ctx, name, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PromptTemplate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPromptTemplateRepository_CreatePromptTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePromptTemplate'
+type MockPromptTemplateRepository_CreatePromptTemplate_Call struct {
+	*mock.Call
+}
+
+// CreatePromptTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - body string
+func (_e *MockPromptTemplateRepository_Expecter) CreatePromptTemplate(ctx interface{}, name interface{}, body interface{}) *MockPromptTemplateRepository_CreatePromptTemplate_Call {
+	return &MockPromptTemplateRepository_CreatePromptTemplate_Call{Call: _e.mock.On("CreatePromptTemplate", ctx, name, body)}
+}
+
+func (_c *MockPromptTemplateRepository_CreatePromptTemplate_Call) Run(run func(ctx context.Context, name string, body string)) *MockPromptTemplateRepository_CreatePromptTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromptTemplateRepository_CreatePromptTemplate_Call) Return(promptTemplate *model.PromptTemplate, err error) *MockPromptTemplateRepository_CreatePromptTemplate_Call {
+	_c.Call.Return(promptTemplate, err)
+	return _c
+}
+
+func (_c *MockPromptTemplateRepository_CreatePromptTemplate_Call) RunAndReturn(run func(ctx context.Context, name string, body string) (*model.PromptTemplate, error)) *MockPromptTemplateRepository_CreatePromptTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPromptTemplate provides a mock function for the type MockPromptTemplateRepository
+func (_mock *MockPromptTemplateRepository) GetPromptTemplate(ctx context.Context, name string, version int32) (*model.PromptTemplate, error) {
+	ret := _mock.Called(ctx, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromptTemplate")
+	}
+
+	var r0 *model.PromptTemplate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int32) (*model.PromptTemplate, error)); ok {
+		return returnFunc(ctx, name, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int32) *model.PromptTemplate); ok {
+		r0 = returnFunc(ctx, name, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PromptTemplate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int32) error); ok {
+		r1 = returnFunc(ctx, name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPromptTemplateRepository_GetPromptTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPromptTemplate'
+type MockPromptTemplateRepository_GetPromptTemplate_Call struct {
+	*mock.Call
+}
+
+// GetPromptTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - version int32
+func (_e *MockPromptTemplateRepository_Expecter) GetPromptTemplate(ctx interface{}, name interface{}, version interface{}) *MockPromptTemplateRepository_GetPromptTemplate_Call {
+	return &MockPromptTemplateRepository_GetPromptTemplate_Call{Call: _e.mock.On("GetPromptTemplate", ctx, name, version)}
+}
+
+func (_c *MockPromptTemplateRepository_GetPromptTemplate_Call) Run(run func(ctx context.Context, name string, version int32)) *MockPromptTemplateRepository_GetPromptTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromptTemplateRepository_GetPromptTemplate_Call) Return(promptTemplate *model.PromptTemplate, err error) *MockPromptTemplateRepository_GetPromptTemplate_Call {
+	_c.Call.Return(promptTemplate, err)
+	return _c
+}
+
+func (_c *MockPromptTemplateRepository_GetPromptTemplate_Call) RunAndReturn(run func(ctx context.Context, name string, version int32) (*model.PromptTemplate, error)) *MockPromptTemplateRepository_GetPromptTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockResponseCacheRepository creates a new instance of MockResponseCacheRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResponseCacheRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResponseCacheRepository {
+	mock := &MockResponseCacheRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockResponseCacheRepository is an autogenerated mock type for the ResponseCacheRepository type
+type MockResponseCacheRepository struct {
+	mock.Mock
+}
+
+type MockResponseCacheRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResponseCacheRepository) EXPECT() *MockResponseCacheRepository_Expecter {
+	return &MockResponseCacheRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetResponse provides a mock function for the type MockResponseCacheRepository
+func (_mock *MockResponseCacheRepository) GetResponse(ctx context.Context, key string) (*domain.Response, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResponse")
+	}
+
+	var r0 *domain.Response
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Response, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Response); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResponseCacheRepository_GetResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResponse'
+type MockResponseCacheRepository_GetResponse_Call struct {
+	*mock.Call
+}
+
+// GetResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockResponseCacheRepository_Expecter) GetResponse(ctx interface{}, key interface{}) *MockResponseCacheRepository_GetResponse_Call {
+	return &MockResponseCacheRepository_GetResponse_Call{Call: _e.mock.On("GetResponse", ctx, key)}
+}
+
+func (_c *MockResponseCacheRepository_GetResponse_Call) Run(run func(ctx context.Context, key string)) *MockResponseCacheRepository_GetResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResponseCacheRepository_GetResponse_Call) Return(response *domain.Response, err error) *MockResponseCacheRepository_GetResponse_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *MockResponseCacheRepository_GetResponse_Call) RunAndReturn(run func(ctx context.Context, key string) (*domain.Response, error)) *MockResponseCacheRepository_GetResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetResponse provides a mock function for the type MockResponseCacheRepository
+func (_mock *MockResponseCacheRepository) SetResponse(ctx context.Context, key string, response domain.Response) error {
+	ret := _mock.Called(ctx, key, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetResponse")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Response) error); ok {
+		r0 = returnFunc(ctx, key, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockResponseCacheRepository_SetResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetResponse'
+type MockResponseCacheRepository_SetResponse_Call struct {
+	*mock.Call
+}
+
+// SetResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - response domain.Response
+func (_e *MockResponseCacheRepository_Expecter) SetResponse(ctx interface{}, key interface{}, response interface{}) *MockResponseCacheRepository_SetResponse_Call {
+	return &MockResponseCacheRepository_SetResponse_Call{Call: _e.mock.On("SetResponse", ctx, key, response)}
+}
+
+func (_c *MockResponseCacheRepository_SetResponse_Call) Run(run func(ctx context.Context, key string, response domain.Response)) *MockResponseCacheRepository_SetResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.Response
+		if args[2] != nil {
+			arg2 = args[2].(domain.Response)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResponseCacheRepository_SetResponse_Call) Return(err error) *MockResponseCacheRepository_SetResponse_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockResponseCacheRepository_SetResponse_Call) RunAndReturn(run func(ctx context.Context, key string, response domain.Response) error) *MockResponseCacheRepository_SetResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUsageRepository creates a new instance of MockUsageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsageRepository {
+	mock := &MockUsageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUsageRepository is an autogenerated mock type for the UsageRepository type
+type MockUsageRepository struct {
+	mock.Mock
+}
+
+type MockUsageRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsageRepository) EXPECT() *MockUsageRepository_Expecter {
+	return &MockUsageRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateUsageRecord provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) CreateUsageRecord(ctx context.Context, record model.UsageRecord) error {
+	ret := _mock.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUsageRecord")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.UsageRecord) error); ok {
+		r0 = returnFunc(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsageRepository_CreateUsageRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUsageRecord'
+type MockUsageRepository_CreateUsageRecord_Call struct {
+	*mock.Call
+}
+
+// CreateUsageRecord is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record model.UsageRecord
+func (_e *MockUsageRepository_Expecter) CreateUsageRecord(ctx interface{}, record interface{}) *MockUsageRepository_CreateUsageRecord_Call {
+	return &MockUsageRepository_CreateUsageRecord_Call{Call: _e.mock.On("CreateUsageRecord", ctx, record)}
+}
+
+func (_c *MockUsageRepository_CreateUsageRecord_Call) Run(run func(ctx context.Context, record model.UsageRecord)) *MockUsageRepository_CreateUsageRecord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.UsageRecord
+		if args[1] != nil {
+			arg1 = args[1].(model.UsageRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_CreateUsageRecord_Call) Return(err error) *MockUsageRepository_CreateUsageRecord_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsageRepository_CreateUsageRecord_Call) RunAndReturn(run func(ctx context.Context, record model.UsageRecord) error) *MockUsageRepository_CreateUsageRecord_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsageAggregates provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) GetUsageAggregates(ctx context.Context, filter model.UsageFilter) ([]model.UsageAggregate, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsageAggregates")
+	}
+
+	var r0 []model.UsageAggregate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.UsageFilter) ([]model.UsageAggregate, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.UsageFilter) []model.UsageAggregate); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UsageAggregate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.UsageFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsageRepository_GetUsageAggregates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsageAggregates'
+type MockUsageRepository_GetUsageAggregates_Call struct {
+	*mock.Call
+}
+
+// GetUsageAggregates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.UsageFilter
+func (_e *MockUsageRepository_Expecter) GetUsageAggregates(ctx interface{}, filter interface{}) *MockUsageRepository_GetUsageAggregates_Call {
+	return &MockUsageRepository_GetUsageAggregates_Call{Call: _e.mock.On("GetUsageAggregates", ctx, filter)}
+}
+
+func (_c *MockUsageRepository_GetUsageAggregates_Call) Run(run func(ctx context.Context, filter model.UsageFilter)) *MockUsageRepository_GetUsageAggregates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.UsageFilter
+		if args[1] != nil {
+			arg1 = args[1].(model.UsageFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_GetUsageAggregates_Call) Return(usageAggregates []model.UsageAggregate, err error) *MockUsageRepository_GetUsageAggregates_Call {
+	_c.Call.Return(usageAggregates, err)
+	return _c
+}
+
+func (_c *MockUsageRepository_GetUsageAggregates_Call) RunAndReturn(run func(ctx context.Context, filter model.UsageFilter) ([]model.UsageAggregate, error)) *MockUsageRepository_GetUsageAggregates_Call {
+	_c.Call.Return(run)
+	return _c
+}
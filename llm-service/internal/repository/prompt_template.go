@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+// PromptTemplateRepository stores the versions of prompt templates. Versions are immutable and numbered from 1.
+type PromptTemplateRepository interface {
+	// GetPromptTemplate returns the version of the template, or its latest version if version is zero.
+	// It returns nil if there is no such template.
+	GetPromptTemplate(ctx context.Context, name string, version int32) (*model.PromptTemplate, error)
+	// CreatePromptTemplate stores the body as the next version of the template.
+	CreatePromptTemplate(ctx context.Context, name string, body string) (*model.PromptTemplate, error)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+type pgPromptTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewPgPromptTemplateRepository(db *sql.DB) PromptTemplateRepository {
+	return &pgPromptTemplateRepository{db: db}
+}
+
+func (r *pgPromptTemplateRepository) GetPromptTemplate(
+	ctx context.Context, name string, version int32) (*model.PromptTemplate, error) {
+	query := `
+		SELECT name, version, body, created_at
+		FROM prompt_templates
+		WHERE name = $1 AND ($2 = 0 OR version = $2)
+		ORDER BY version DESC
+		LIMIT 1`
+
+	var template model.PromptTemplate
+	err := r.db.QueryRowContext(ctx, query, name, version).Scan(
+		&template.Name, &template.Version, &template.Body, &template.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt template: %w", err)
+	}
+
+	return &template, nil
+}
+
+func (r *pgPromptTemplateRepository) CreatePromptTemplate(
+	ctx context.Context, name string, body string) (*model.PromptTemplate, error) {
+	// The primary key rejects the version if another one was created concurrently.
+	query := `
+		INSERT INTO prompt_templates (name, version, body)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2
+		FROM prompt_templates
+		WHERE name = $1
+		RETURNING name, version, body, created_at`
+
+	var template model.PromptTemplate
+	err := r.db.QueryRowContext(ctx, query, name, body).Scan(
+		&template.Name, &template.Version, &template.Body, &template.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert prompt template: %w", err)
+	}
+
+	return &template, nil
+}
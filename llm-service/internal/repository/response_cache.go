@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// ResponseCacheRepository stores generated responses by the hash of the request.
+type ResponseCacheRepository interface {
+	// GetResponse returns nil if there's no cached response for the key.
+	GetResponse(ctx context.Context, key string) (*domain.Response, error)
+	SetResponse(ctx context.Context, key string, response domain.Response) error
+}
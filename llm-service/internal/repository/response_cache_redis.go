@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+const responseCacheKeyPrefix = "llm_response:"
+
+type redisResponseCacheRepository struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisResponseCacheRepository(client *redis.Client, ttl time.Duration) ResponseCacheRepository {
+	return &redisResponseCacheRepository{client: client, ttl: ttl}
+}
+
+func (r *redisResponseCacheRepository) GetResponse(ctx context.Context, key string) (*domain.Response, error) {
+	data, err := r.client.Get(ctx, r.createResponseKey(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get cached response: %w", err)
+	}
+
+	var response domain.Response
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode cached response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (r *redisResponseCacheRepository) SetResponse(ctx context.Context, key string, response domain.Response) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	if err := r.client.Set(ctx, r.createResponseKey(key), data, r.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache response: %w", err)
+	}
+
+	return nil
+}
+
+func (r *redisResponseCacheRepository) createResponseKey(key string) string {
+	return fmt.Sprintf("%s%s", responseCacheKeyPrefix, key)
+}
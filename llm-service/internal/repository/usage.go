@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+// UsageRepository stores the ledger of LLM calls made on behalf of callers and their users.
+type UsageRepository interface {
+	CreateUsageRecord(ctx context.Context, record model.UsageRecord) error
+	// GetUsageAggregates returns the usage grouped by day, caller and user, ordered by day.
+	GetUsageAggregates(ctx context.Context, filter model.UsageFilter) ([]model.UsageAggregate, error)
+}
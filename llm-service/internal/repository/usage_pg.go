@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+)
+
+type pgUsageRepository struct {
+	db *sql.DB
+}
+
+func NewPgUsageRepository(db *sql.DB) UsageRepository {
+	return &pgUsageRepository{db: db}
+}
+
+func (r *pgUsageRepository) CreateUsageRecord(ctx context.Context, record model.UsageRecord) error {
+	query := `
+		INSERT INTO llm_usage (caller, user_id, model, prompt_tokens, completion_tokens, latency_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.Caller, record.UserID, record.Model,
+		record.PromptTokens, record.CompletionTokens,
+		record.Latency.Milliseconds(), record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert usage record: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pgUsageRepository) GetUsageAggregates(
+	ctx context.Context, filter model.UsageFilter) ([]model.UsageAggregate, error) {
+	query := `
+		SELECT date_trunc('day', created_at, 'UTC') AS day, caller, user_id,
+		       COUNT(*), SUM(prompt_tokens), SUM(completion_tokens)
+		FROM llm_usage
+		WHERE created_at >= $1 AND created_at < $2
+		  AND ($3 = '' OR caller = $3)
+		  AND ($4 = '' OR user_id = $4)
+		GROUP BY day, caller, user_id
+		ORDER BY day, caller, user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.Since, filter.Until, filter.Caller, filter.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usage aggregates: %w", err)
+	}
+
+	defer rows.Close()
+
+	var aggregates []model.UsageAggregate
+	for rows.Next() {
+		var aggregate model.UsageAggregate
+		err := rows.Scan(
+			&aggregate.Day, &aggregate.Caller, &aggregate.UserID,
+			&aggregate.Requests, &aggregate.PromptTokens, &aggregate.CompletionTokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan usage aggregate: %w", err)
+		}
+
+		aggregates = append(aggregates, aggregate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over usage aggregates: %w", err)
+	}
+
+	return aggregates, nil
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// Priorities of the subscription tiers. Requests of users without a subscription have the lowest priority.
+var tierPriorities = map[string]int{
+	"student":   1,
+	"team":      2,
+	"community": 3,
+}
+
+const maxPriority = 3
+
+type AdmissionConfig struct {
+	// MaxConcurrent is how many requests are processed at the same time.
+	MaxConcurrent int
+	// MaxQueued is how many requests may wait for their turn, MaxQueuedPerFlow limits it for a single
+	// caller and user, so that nobody can take the whole queue.
+	MaxQueued        int
+	MaxQueuedPerFlow int
+}
+
+type AdmissionStats struct {
+	InFlight      int
+	Queued        int
+	MaxConcurrent int
+	MaxQueued     int
+}
+
+// AdmissionRejectedError is returned when the request can't be queued or wouldn't be processed before its deadline.
+type AdmissionRejectedError struct {
+	Reason string
+}
+
+func (e *AdmissionRejectedError) Error() string {
+	return fmt.Sprintf("request wasn't admitted: %s", e.Reason)
+}
+
+// AdmissionController limits how many requests are processed at the same time. Waiting requests are grouped
+// into flows by caller and user, and flows take turns, so that a user firing many requests doesn't delay
+// the requests of other users. Flows of users with higher subscription tiers are always served first.
+type AdmissionController interface {
+	// Admit blocks until the request may be processed and returns the function to call when it completes.
+	Admit(ctx context.Context, caller domain.Caller) (release func(), err error)
+	Stats() AdmissionStats
+}
+
+type admissionController struct {
+	config AdmissionConfig
+
+	mu       sync.Mutex
+	inFlight int
+	queued   int
+	// flows are the waiting requests by flow key, and rings are the keys of the flows with waiting requests
+	// by priority in the order in which they are served.
+	flows map[string][]*admissionWaiter
+	rings [maxPriority + 1][]string
+	// averageDuration estimates how long a request is processed, which is used to reject requests
+	// that would wait past their deadline.
+	averageDuration time.Duration
+}
+
+type admissionWaiter struct {
+	ready chan struct{}
+}
+
+func NewAdmissionController(config AdmissionConfig) AdmissionController {
+	config.MaxConcurrent = max(config.MaxConcurrent, 1)
+
+	return &admissionController{
+		config: config,
+		flows:  make(map[string][]*admissionWaiter),
+	}
+}
+
+func (c *admissionController) Admit(ctx context.Context, caller domain.Caller) (func(), error) {
+	c.mu.Lock()
+
+	if c.inFlight < c.config.MaxConcurrent && c.queued == 0 {
+		c.inFlight++
+		c.mu.Unlock()
+
+		return c.releaseFunc(), nil
+	}
+
+	key := flowKey(caller)
+	priority := tierPriorities[caller.Tier]
+
+	if c.queued >= c.config.MaxQueued {
+		c.mu.Unlock()
+		return nil, &AdmissionRejectedError{Reason: "queue is full"}
+	}
+
+	if len(c.flows[key]) >= c.config.MaxQueuedPerFlow {
+		c.mu.Unlock()
+		return nil, &AdmissionRejectedError{Reason: "too many queued requests of the user"}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < c.estimateWait(priority) {
+		c.mu.Unlock()
+		return nil, &AdmissionRejectedError{Reason: "request wouldn't be processed before its deadline"}
+	}
+
+	waiter := &admissionWaiter{ready: make(chan struct{})}
+	if len(c.flows[key]) == 0 {
+		c.rings[priority] = append(c.rings[priority], key)
+	}
+
+	c.flows[key] = append(c.flows[key], waiter)
+	c.queued++
+	c.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return c.releaseFunc(), nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		select {
+		case <-waiter.ready:
+			// The slot was granted concurrently with the cancellation, so it is passed on.
+			c.inFlight--
+			c.dispatch()
+		default:
+			c.removeWaiter(key, priority, waiter)
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+func (c *admissionController) Stats() AdmissionStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return AdmissionStats{
+		InFlight:      c.inFlight,
+		Queued:        c.queued,
+		MaxConcurrent: c.config.MaxConcurrent,
+		MaxQueued:     c.config.MaxQueued,
+	}
+}
+
+func (c *admissionController) releaseFunc() func() {
+	startedAt := time.Now()
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			c.recordDuration(time.Since(startedAt))
+			c.inFlight--
+			c.dispatch()
+		})
+	}
+}
+
+// dispatch grants free slots to the waiting requests, taking the flows of the highest priority in turns.
+func (c *admissionController) dispatch() {
+	for c.inFlight < c.config.MaxConcurrent && c.queued > 0 {
+		for priority := maxPriority; priority >= 0; priority-- {
+			ring := c.rings[priority]
+			if len(ring) == 0 {
+				continue
+			}
+
+			key := ring[0]
+			waiters := c.flows[key]
+
+			waiter := waiters[0]
+			c.flows[key] = waiters[1:]
+			c.rings[priority] = ring[1:]
+
+			if len(c.flows[key]) > 0 {
+				c.rings[priority] = append(c.rings[priority], key)
+			} else {
+				delete(c.flows, key)
+			}
+
+			c.queued--
+			c.inFlight++
+			close(waiter.ready)
+
+			break
+		}
+	}
+}
+
+func (c *admissionController) removeWaiter(key string, priority int, waiter *admissionWaiter) {
+	waiters := c.flows[key]
+	for i, w := range waiters {
+		if w == waiter {
+			c.flows[key] = append(waiters[:i:i], waiters[i+1:]...)
+			c.queued--
+			break
+		}
+	}
+
+	if len(c.flows[key]) > 0 {
+		return
+	}
+
+	delete(c.flows, key)
+
+	ring := c.rings[priority]
+	for i, k := range ring {
+		if k == key {
+			c.rings[priority] = append(ring[:i:i], ring[i+1:]...)
+			break
+		}
+	}
+}
+
+// estimateWait estimates how long a new request of the given priority would wait, assuming that
+// the requests queued with the same or higher priority are served before it.
+func (c *admissionController) estimateWait(priority int) time.Duration {
+	var ahead int
+	for p := priority; p <= maxPriority; p++ {
+		for _, key := range c.rings[p] {
+			ahead += len(c.flows[key])
+		}
+	}
+
+	return c.averageDuration * time.Duration(ahead/c.config.MaxConcurrent+1)
+}
+
+// recordDuration updates the exponential moving average of the processing duration.
+func (c *admissionController) recordDuration(duration time.Duration) {
+	if c.averageDuration == 0 {
+		c.averageDuration = duration
+		return
+	}
+
+	c.averageDuration = (c.averageDuration*9 + duration) / 10
+}
+
+// flowKey includes the tier, so that every flow belongs to exactly one priority.
+func flowKey(caller domain.Caller) string {
+	return caller.Name + "/" + caller.UserID + "/" + caller.Tier
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// admitInBackground starts waiting for admission and reports the caller to the channel once admitted.
+func admitInBackground(
+	t *testing.T, admissionController AdmissionController, caller domain.Caller, admitted chan<- domain.Caller) {
+	t.Helper()
+
+	go func() {
+		release, err := admissionController.Admit(context.Background(), caller)
+		if err != nil {
+			return
+		}
+
+		admitted <- caller
+		release()
+	}()
+}
+
+func waitForQueued(t *testing.T, admissionController AdmissionController, queued int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return admissionController.Stats().Queued == queued
+	}, time.Second, time.Millisecond)
+}
+
+func TestAdmissionControllerServesFlowsInTurns(t *testing.T) {
+	admissionController := NewAdmissionController(AdmissionConfig{MaxConcurrent: 1, MaxQueued: 10, MaxQueuedPerFlow: 10})
+
+	release, err := admissionController.Admit(context.Background(), domain.Caller{})
+	require.NoError(t, err)
+
+	greedy := domain.Caller{Name: "application-service", UserID: "greedy"}
+	other := domain.Caller{Name: "application-service", UserID: "other"}
+	community := domain.Caller{Name: "application-service", UserID: "school", Tier: "community"}
+
+	// The admitted callers are reported one by one, as every one of them has to be received before
+	// the next one is admitted.
+	admitted := make(chan domain.Caller)
+	for i, caller := range []domain.Caller{greedy, greedy, greedy, other, community} {
+		admitInBackground(t, admissionController, caller, admitted)
+		waitForQueued(t, admissionController, i+1)
+	}
+
+	release()
+
+	var order []string
+	for range 5 {
+		order = append(order, (<-admitted).UserID)
+	}
+
+	assert.Equal(t, []string{"school", "greedy", "other", "greedy", "greedy"}, order)
+}
+
+func TestAdmissionControllerRejectsRequestsWhenQueueIsFull(t *testing.T) {
+	admissionController := NewAdmissionController(AdmissionConfig{MaxConcurrent: 1, MaxQueued: 2, MaxQueuedPerFlow: 1})
+
+	release, err := admissionController.Admit(context.Background(), domain.Caller{})
+	require.NoError(t, err)
+	defer release()
+
+	admitted := make(chan domain.Caller, 2)
+	admitInBackground(t, admissionController, domain.Caller{UserID: "first"}, admitted)
+	waitForQueued(t, admissionController, 1)
+
+	var rejectedErr *AdmissionRejectedError
+
+	_, err = admissionController.Admit(context.Background(), domain.Caller{UserID: "first"})
+	require.True(t, errors.As(err, &rejectedErr), "the flow can't take more of the queue")
+
+	admitInBackground(t, admissionController, domain.Caller{UserID: "second"}, admitted)
+	waitForQueued(t, admissionController, 2)
+
+	_, err = admissionController.Admit(context.Background(), domain.Caller{UserID: "third"})
+	require.True(t, errors.As(err, &rejectedErr), "the queue is full")
+}
+
+func TestAdmissionControllerRejectsRequestsMissingDeadline(t *testing.T) {
+	admissionController := NewAdmissionController(AdmissionConfig{MaxConcurrent: 1, MaxQueued: 10, MaxQueuedPerFlow: 10})
+
+	// The first request takes long enough for the estimated wait to exceed the deadline.
+	release, err := admissionController.Admit(context.Background(), domain.Caller{})
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	release()
+
+	release, err = admissionController.Admit(context.Background(), domain.Caller{})
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	_, err = admissionController.Admit(ctx, domain.Caller{})
+
+	var rejectedErr *AdmissionRejectedError
+	require.True(t, errors.As(err, &rejectedErr))
+	assert.Zero(t, admissionController.Stats().Queued)
+}
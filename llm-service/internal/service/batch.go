@@ -0,0 +1,457 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+const (
+	// batchPollInterval is how often the worker looks for new items when it is idle, as the items may
+	// be submitted to other replicas.
+	batchPollInterval = 2 * time.Second
+	// batchWatchInterval is how often the watchers of a batch check for finished items.
+	batchWatchInterval = time.Second
+	// batchJobsPerPoll limits how many provider jobs are polled at once.
+	batchJobsPerPoll = 16
+	// batchItemMaxAttempts is how many times an item failing with retryable provider errors is generated.
+	batchItemMaxAttempts = 3
+)
+
+// BatchJobRunner is implemented by the providers with batch APIs, which generate many responses
+// asynchronously at a lower price, but may take up to a day.
+type BatchJobRunner interface {
+	// CreateBatchJob submits the requests, which must use the same model, and returns the ID of the job.
+	CreateBatchJob(ctx context.Context, requests []domain.GenerationRequest) (string, error)
+	// GetBatchJobResults returns the results in the order of the requests of the job, or nil if the job
+	// isn't finished yet. The requests which weren't generated have errors as results.
+	GetBatchJobResults(ctx context.Context, id string, requests int) ([]BatchJobResult, error)
+	CancelBatchJob(ctx context.Context, id string) error
+}
+
+// BatchJobResult is the response generated by the provider job, or the error it failed with.
+type BatchJobResult struct {
+	Response *domain.Response
+	Err      error
+}
+
+type BatchConfig struct {
+	// MaxItems limits the number of requests in a batch.
+	MaxItems int
+	// MaxConcurrency is how many items every replica generates at the same time.
+	MaxConcurrency int
+	// ItemLease is how long an item may be processed before it is taken over by another worker.
+	ItemLease time.Duration
+	// Batches with at least JobMinItems items, all using the same model, are sent to the batch API
+	// of the provider. Zero disables provider jobs.
+	JobMinItems     int
+	JobPollInterval time.Duration
+	// Redaction holds the policies of the callers. Provider jobs bypass the redaction of the prompts,
+	// so the batches of the callers whose prompts are redacted are generated one by one.
+	Redaction RedactionConfig
+}
+
+// BatchNotFoundError is returned when the batch doesn't exist or was submitted by another caller.
+type BatchNotFoundError struct {
+	ID string
+}
+
+func (e *BatchNotFoundError) Error() string {
+	return fmt.Sprintf("batch %s doesn't exist", e.ID)
+}
+
+// BatchTooLargeError is returned when the batch has more items than allowed.
+type BatchTooLargeError struct {
+	Items    int
+	MaxItems int
+}
+
+func (e *BatchTooLargeError) Error() string {
+	return fmt.Sprintf("batch has %d items, at most %d are allowed", e.Items, e.MaxItems)
+}
+
+// BatchService generates the responses to batches of independent requests in the background. The batches
+// are persisted, so that the callers can poll them or watch their items finish, and the processing
+// survives restarts.
+type BatchService interface {
+	// SubmitBatch stores the items, of which only CustomID and Request are used, as a batch of the caller
+	// from the context.
+	SubmitBatch(ctx context.Context, items []model.BatchItem) (*model.Batch, error)
+	GetBatch(ctx context.Context, id string) (*model.Batch, []model.BatchItem, error)
+	// WatchBatch yields the items of the batch as they succeed or fail, until all of them are finished.
+	WatchBatch(ctx context.Context, id string) iter.Seq2[model.BatchItem, error]
+	// Run processes the items of the batches until the context is cancelled.
+	Run(ctx context.Context)
+}
+
+type batchService struct {
+	llmService      LLMService
+	batchJobRunner  BatchJobRunner
+	batchRepository repository.BatchRepository
+	usageRepository repository.UsageRepository
+	config          BatchConfig
+	// wake interrupts the idle worker when an item is submitted or finished.
+	wake chan struct{}
+}
+
+// NewBatchService creates a BatchService generating the items one by one with llmService, or with
+// provider jobs if batchJobRunner isn't nil.
+func NewBatchService(
+	llmService LLMService, batchJobRunner BatchJobRunner, batchRepository repository.BatchRepository,
+	usageRepository repository.UsageRepository, config BatchConfig) BatchService {
+	config.MaxConcurrency = max(config.MaxConcurrency, 1)
+
+	return &batchService{
+		llmService:      llmService,
+		batchJobRunner:  batchJobRunner,
+		batchRepository: batchRepository,
+		usageRepository: usageRepository,
+		config:          config,
+		wake:            make(chan struct{}, 1),
+	}
+}
+
+func (s *batchService) SubmitBatch(ctx context.Context, items []model.BatchItem) (*model.Batch, error) {
+	if len(items) > s.config.MaxItems {
+		return nil, &BatchTooLargeError{Items: len(items), MaxItems: s.config.MaxItems}
+	}
+
+	batch := model.Batch{
+		ID:           uuid.NewString(),
+		Caller:       localcontext.GetCaller(ctx),
+		TotalItems:   len(items),
+		PendingItems: len(items),
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	batch.ProviderJobID = s.createBatchJob(ctx, items)
+
+	status := model.BatchItemPending
+	if batch.ProviderJobID != "" {
+		status = model.BatchItemRunning
+		batch.PendingItems, batch.RunningItems = 0, len(items)
+	}
+
+	for i := range items {
+		items[i].BatchID = batch.ID
+		items[i].Index = i
+		items[i].Status = status
+	}
+
+	if err := s.batchRepository.CreateBatch(ctx, batch, items); err != nil {
+		if batch.ProviderJobID != "" {
+			if cancelErr := s.batchJobRunner.CancelBatchJob(ctx, batch.ProviderJobID); cancelErr != nil {
+				log.L(ctx).Errorf("Failed to cancel batch job %s of unsaved batch: %v", batch.ProviderJobID, cancelErr)
+			}
+		}
+
+		return nil, err
+	}
+
+	log.L(ctx).Infof("Batch %s with %d items submitted by caller %s", batch.ID, len(items), batch.Caller.Name)
+	s.wakeWorker()
+
+	return &batch, nil
+}
+
+// createBatchJob submits the items to the batch API of the provider if the batch is large enough, returning
+// the ID of the job. The items are generated one by one if the job can't be created.
+func (s *batchService) createBatchJob(ctx context.Context, items []model.BatchItem) string {
+	if s.batchJobRunner == nil || s.config.JobMinItems == 0 || len(items) < s.config.JobMinItems {
+		return ""
+	}
+
+	if s.config.Redaction.Policy(localcontext.GetCaller(ctx).Name).redacts() {
+		return ""
+	}
+
+	requests := make([]domain.GenerationRequest, len(items))
+	for i, item := range items {
+		if item.Request.Options.Model != items[0].Request.Options.Model {
+			return ""
+		}
+
+		requests[i] = item.Request
+	}
+
+	jobID, err := s.batchJobRunner.CreateBatchJob(ctx, requests)
+	if err != nil {
+		log.L(ctx).Warnf("Failed to create batch job, items will be generated one by one: %v", err)
+		return ""
+	}
+
+	return jobID
+}
+
+func (s *batchService) GetBatch(ctx context.Context, id string) (*model.Batch, []model.BatchItem, error) {
+	batch, err := s.getCallerBatch(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, err := s.batchRepository.GetBatchItems(ctx, id, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return batch, items, nil
+}
+
+func (s *batchService) WatchBatch(ctx context.Context, id string) iter.Seq2[model.BatchItem, error] {
+	return func(yield func(model.BatchItem, error) bool) {
+		batch, err := s.getCallerBatch(ctx, id)
+		if err != nil {
+			yield(model.BatchItem{}, err)
+			return
+		}
+
+		// Items are mostly finished in the order of their indexes, so only the items after the first
+		// unfinished one are fetched again.
+		fromIndex := 0
+		yielded := make(map[int]bool)
+
+		for {
+			items, err := s.batchRepository.GetBatchItems(ctx, id, fromIndex)
+			if err != nil {
+				yield(model.BatchItem{}, err)
+				return
+			}
+
+			for _, item := range items {
+				if yielded[item.Index] || (item.Status != model.BatchItemSucceeded && item.Status != model.BatchItemFailed) {
+					continue
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+
+				yielded[item.Index] = true
+			}
+
+			for yielded[fromIndex] {
+				delete(yielded, fromIndex)
+				fromIndex++
+			}
+
+			if fromIndex >= batch.TotalItems {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				yield(model.BatchItem{}, ctx.Err())
+				return
+			case <-time.After(batchWatchInterval):
+			}
+		}
+	}
+}
+
+func (s *batchService) getCallerBatch(ctx context.Context, id string) (*model.Batch, error) {
+	if uuid.Validate(id) != nil {
+		return nil, &BatchNotFoundError{ID: id}
+	}
+
+	batch, err := s.batchRepository.GetBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch == nil || batch.Caller.Name != localcontext.GetCaller(ctx).Name {
+		return nil, &BatchNotFoundError{ID: id}
+	}
+
+	return batch, nil
+}
+
+func (s *batchService) Run(ctx context.Context) {
+	// The semaphore holds a slot for every item being generated.
+	semaphore := make(chan struct{}, s.config.MaxConcurrency)
+
+	ticker := time.NewTicker(batchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.processItems(ctx, semaphore)
+
+		if s.batchJobRunner != nil {
+			s.pollBatchJobs(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *batchService) wakeWorker() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// processItems claims as many items as there are free slots and generates them in the background.
+func (s *batchService) processItems(ctx context.Context, semaphore chan struct{}) {
+	free := cap(semaphore) - len(semaphore)
+	if free == 0 {
+		return
+	}
+
+	items, err := s.batchRepository.ClaimBatchItems(ctx, free, time.Now().Add(-s.config.ItemLease))
+	if err != nil {
+		log.L(ctx).Errorf("Failed to claim batch items: %v", err)
+		return
+	}
+
+	for _, item := range items {
+		semaphore <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-semaphore
+				s.wakeWorker()
+			}()
+
+			s.processItem(ctx, item)
+		}()
+	}
+}
+
+func (s *batchService) processItem(ctx context.Context, item model.ClaimedBatchItem) {
+	localcontext.SetCaller(&ctx, item.Caller)
+
+	request := item.Request
+	response, err := s.llmService.GenerateResponse(
+		ctx, request.ChatHistory, request.Tools, request.StructuredOutputSchema, request.Options)
+
+	// The worker is stopping, so the item is taken over by another worker once its lease expires.
+	if ctx.Err() != nil {
+		return
+	}
+
+	// The provider is unavailable after the retries of the call, so the item is retried once its lease
+	// expires, giving the provider time to recover.
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) && providerErr.Retryable() && item.Attempts < batchItemMaxAttempts {
+		log.L(ctx).Warnf("Item %d of batch %s failed in attempt %d, retrying later: %v",
+			item.Index, item.BatchID, item.Attempts, err)
+		return
+	}
+
+	result := item.BatchItem
+	if err != nil {
+		result.Status = model.BatchItemFailed
+		result.Error = err.Error()
+	} else {
+		result.Status = model.BatchItemSucceeded
+		result.Response = response
+	}
+
+	if err := s.batchRepository.CompleteBatchItem(ctx, result); err != nil {
+		log.L(ctx).Errorf("Failed to complete item %d of batch %s: %v", item.Index, item.BatchID, err)
+	}
+}
+
+// pollBatchJobs stores the results of the finished provider jobs. The responses are validated, as the jobs
+// bypass the validation of structured output, and the items which failed or are invalid are generated
+// one by one, which repairs invalid output.
+func (s *batchService) pollBatchJobs(ctx context.Context) {
+	batches, err := s.batchRepository.ClaimBatchJobs(ctx, batchJobsPerPoll, time.Now().Add(-s.config.JobPollInterval))
+	if err != nil {
+		log.L(ctx).Errorf("Failed to claim batch jobs: %v", err)
+		return
+	}
+
+	for _, batch := range batches {
+		if err := s.completeBatchJob(ctx, batch); err != nil {
+			log.L(ctx).Errorf("Failed to complete job %s of batch %s: %v", batch.ProviderJobID, batch.ID, err)
+		}
+	}
+}
+
+func (s *batchService) completeBatchJob(ctx context.Context, batch model.Batch) error {
+	localcontext.SetCaller(&ctx, batch.Caller)
+
+	results, err := s.batchJobRunner.GetBatchJobResults(ctx, batch.ProviderJobID, batch.TotalItems)
+	if err != nil || results == nil {
+		return err
+	}
+
+	requests, err := s.batchRepository.GetBatchRequests(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(requests) != len(results) {
+		return fmt.Errorf("job has %d results for %d requests", len(results), len(requests))
+	}
+
+	var succeededItems []model.BatchItem
+	for i, result := range results {
+		if result.Err != nil {
+			log.L(ctx).Warnf("Item %d of batch %s failed in job %s: %v", i, batch.ID, batch.ProviderJobID, result.Err)
+			continue
+		}
+
+		if schema := requests[i].StructuredOutputSchema; schema != nil {
+			if violations := ValidateStructuredOutput(schema, result.Response.Message.Text); len(violations) > 0 {
+				continue
+			}
+		}
+
+		if s.config.Redaction.Policy(batch.Caller.Name).Moderation {
+			result.Response.SafetyFlags = moderateChatHistory(ctx, requests[i].ChatHistory)
+		}
+
+		succeededItems = append(succeededItems, model.BatchItem{
+			BatchID:  batch.ID,
+			Index:    i,
+			Status:   model.BatchItemSucceeded,
+			Response: result.Response,
+		})
+
+		s.recordUsage(ctx, batch.Caller, result.Response.Usage)
+	}
+
+	if err := s.batchRepository.CompleteBatchJob(ctx, batch.ID, succeededItems); err != nil {
+		return err
+	}
+
+	log.L(ctx).Infof("Job %s of batch %s finished, %d of %d items succeeded",
+		batch.ProviderJobID, batch.ID, len(succeededItems), len(results))
+	s.wakeWorker()
+
+	return nil
+}
+
+// recordUsage records the usage of the responses generated by provider jobs, which bypass
+// the usage recording LLMService.
+func (s *batchService) recordUsage(ctx context.Context, caller domain.Caller, usage domain.Usage) {
+	err := s.usageRepository.CreateUsageRecord(ctx, model.UsageRecord{
+		Caller:           caller.Name,
+		UserID:           caller.UserID,
+		Model:            usage.Model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		CreatedAt:        time.Now().UTC(),
+	})
+	if err != nil {
+		log.L(ctx).Errorf("Failed to record LLM usage of caller %s and user %s: %v", caller.Name, caller.UserID, err)
+	}
+}
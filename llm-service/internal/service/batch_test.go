@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+// stubBatchJobRunner creates jobs with the given ID or error and returns the given results.
+type stubBatchJobRunner struct {
+	jobID     string
+	createErr error
+	requests  []domain.GenerationRequest
+	results   []BatchJobResult
+}
+
+func (r *stubBatchJobRunner) CreateBatchJob(_ context.Context, requests []domain.GenerationRequest) (string, error) {
+	r.requests = requests
+	return r.jobID, r.createErr
+}
+
+func (r *stubBatchJobRunner) GetBatchJobResults(context.Context, string, int) ([]BatchJobResult, error) {
+	return r.results, nil
+}
+
+func (r *stubBatchJobRunner) CancelBatchJob(context.Context, string) error {
+	return nil
+}
+
+var testBatchConfig = BatchConfig{MaxItems: 10, MaxConcurrency: 2, JobMinItems: 2}
+
+func batchTestContext() context.Context {
+	ctx := context.Background()
+	localcontext.SetCaller(&ctx, domain.Caller{Name: "application-service", UserID: "counselor", Tier: "team"})
+
+	return ctx
+}
+
+func testBatchItems(n int) []model.BatchItem {
+	items := make([]model.BatchItem, n)
+	for i := range items {
+		items[i] = model.BatchItem{
+			CustomID: "application",
+			Request: domain.GenerationRequest{
+				ChatHistory: []domain.Message{{Role: domain.RoleUser, Text: "Evaluate the application."}},
+			},
+		}
+	}
+
+	return items
+}
+
+func TestBatchServiceSubmitsItemsToBeGeneratedOneByOne(t *testing.T) {
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().CreateBatch(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, batch model.Batch, items []model.BatchItem) error {
+			assert.Empty(t, batch.ProviderJobID)
+			assert.Equal(t, "counselor", batch.Caller.UserID)
+
+			for i, item := range items {
+				assert.Equal(t, batch.ID, item.BatchID)
+				assert.Equal(t, i, item.Index)
+				assert.Equal(t, model.BatchItemPending, item.Status)
+			}
+
+			return nil
+		})
+
+	batchService := NewBatchService(NewFakeClient(nil), nil, batchRepository, nil, testBatchConfig)
+
+	batch, err := batchService.SubmitBatch(batchTestContext(), testBatchItems(3))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, batch.TotalItems)
+	assert.Equal(t, 3, batch.PendingItems)
+}
+
+func TestBatchServiceSubmitsLargeBatchToProviderJob(t *testing.T) {
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().CreateBatch(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, batch model.Batch, items []model.BatchItem) error {
+			assert.Equal(t, "batches/1", batch.ProviderJobID)
+			assert.Equal(t, model.BatchItemRunning, items[0].Status)
+
+			return nil
+		})
+
+	batchJobRunner := &stubBatchJobRunner{jobID: "batches/1"}
+	batchService := NewBatchService(NewFakeClient(nil), batchJobRunner, batchRepository, nil, testBatchConfig)
+
+	batch, err := batchService.SubmitBatch(batchTestContext(), testBatchItems(2))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, batch.RunningItems)
+	assert.Len(t, batchJobRunner.requests, 2)
+}
+
+func TestBatchServiceGeneratesItemsOneByOneIfProviderJobFails(t *testing.T) {
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(batch model.Batch) bool {
+		return batch.ProviderJobID == ""
+	}), mock.Anything).Return(nil)
+
+	batchJobRunner := &stubBatchJobRunner{createErr: providerError(http.StatusNotFound)}
+	batchService := NewBatchService(NewFakeClient(nil), batchJobRunner, batchRepository, nil, testBatchConfig)
+
+	_, err := batchService.SubmitBatch(batchTestContext(), testBatchItems(2))
+	require.NoError(t, err)
+}
+
+func TestBatchServiceGeneratesItemsOneByOneIfPromptsAreRedacted(t *testing.T) {
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(batch model.Batch) bool {
+		return batch.ProviderJobID == ""
+	}), mock.Anything).Return(nil)
+
+	config := testBatchConfig
+	config.Redaction = DefaultRedactionConfig()
+
+	batchJobRunner := &stubBatchJobRunner{jobID: "batches/1"}
+	batchService := NewBatchService(NewFakeClient(nil), batchJobRunner, batchRepository, nil, config)
+
+	_, err := batchService.SubmitBatch(batchTestContext(), testBatchItems(2))
+	require.NoError(t, err)
+
+	assert.Nil(t, batchJobRunner.requests)
+}
+
+func TestBatchServiceRejectsTooLargeBatch(t *testing.T) {
+	batchService := NewBatchService(NewFakeClient(nil), nil, repository.NewMockBatchRepository(t), nil, testBatchConfig)
+
+	_, err := batchService.SubmitBatch(batchTestContext(), testBatchItems(11))
+
+	var tooLargeErr *BatchTooLargeError
+	require.True(t, errors.As(err, &tooLargeErr))
+	assert.Equal(t, 10, tooLargeErr.MaxItems)
+}
+
+func TestBatchServiceStoresGeneratedItem(t *testing.T) {
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().CompleteBatchItem(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, item model.BatchItem) error {
+			assert.Equal(t, model.BatchItemSucceeded, item.Status)
+			assert.Contains(t, item.Response.Message.Text, "Fake response to prompt")
+
+			return nil
+		})
+
+	batchService := NewBatchService(NewFakeClient(nil), nil, batchRepository, nil, testBatchConfig).(*batchService)
+	batchService.processItem(context.Background(), model.ClaimedBatchItem{BatchItem: testBatchItems(1)[0], Attempts: 1})
+}
+
+func TestBatchServiceRetriesItemWhileProviderIsUnavailable(t *testing.T) {
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().CompleteBatchItem(mock.Anything, mock.MatchedBy(func(item model.BatchItem) bool {
+		return item.Status == model.BatchItemFailed && item.Error != ""
+	})).Return(nil).Once()
+
+	llmService := newFailingLLMService(providerError(http.StatusServiceUnavailable),
+		providerError(http.StatusServiceUnavailable), providerError(http.StatusServiceUnavailable))
+	batchService := NewBatchService(llmService, nil, batchRepository, nil, testBatchConfig).(*batchService)
+
+	// The item is left to be retried once its lease expires, until the last attempt fails.
+	for attempt := 1; attempt <= batchItemMaxAttempts; attempt++ {
+		batchService.processItem(context.Background(),
+			model.ClaimedBatchItem{BatchItem: testBatchItems(1)[0], Attempts: attempt})
+	}
+}
+
+func TestBatchServiceStoresValidResultsOfProviderJob(t *testing.T) {
+	schema := &domain.Schema{Type: domain.TypeObject, Required: []string{"score"},
+		Properties: map[string]domain.Schema{"score": {Type: domain.TypeInteger}}}
+
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().GetBatchRequests(mock.Anything, "batch").Return([]domain.GenerationRequest{
+		{StructuredOutputSchema: schema}, {StructuredOutputSchema: schema}, {StructuredOutputSchema: schema},
+	}, nil)
+	batchRepository.EXPECT().CompleteBatchJob(mock.Anything, "batch", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, succeededItems []model.BatchItem) error {
+			// The failed and the invalid items are generated one by one.
+			require.Len(t, succeededItems, 1)
+			assert.Equal(t, 0, succeededItems[0].Index)
+
+			return nil
+		})
+
+	usageRepository := repository.NewMockUsageRepository(t)
+	usageRepository.EXPECT().CreateUsageRecord(mock.Anything, mock.MatchedBy(func(record model.UsageRecord) bool {
+		return record.UserID == "counselor" && record.PromptTokens == 100
+	})).Return(nil).Once()
+
+	batchJobRunner := &stubBatchJobRunner{results: []BatchJobResult{
+		{Response: &domain.Response{Message: domain.Message{Text: `{"score": 8}`}, Usage: domain.Usage{PromptTokens: 100}}},
+		{Response: &domain.Response{Message: domain.Message{Text: `{"score": "high"}`}}},
+		{Err: errors.New("request expired")},
+	}}
+
+	batchService := NewBatchService(NewFakeClient(nil), batchJobRunner, batchRepository, usageRepository,
+		testBatchConfig).(*batchService)
+
+	err := batchService.completeBatchJob(context.Background(), model.Batch{
+		ID: "batch", ProviderJobID: "batches/1", TotalItems: 3, Caller: domain.Caller{UserID: "counselor"}})
+	require.NoError(t, err)
+}
+
+func TestBatchServiceWatchesItemsUntilBatchIsCompleted(t *testing.T) {
+	const id = "8f4e2c52-2a4b-4f7e-9a0c-6f1f9d1b2c3d"
+
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().GetBatch(mock.Anything, id).
+		Return(&model.Batch{ID: id, Caller: domain.Caller{Name: "application-service"}, TotalItems: 2}, nil)
+	batchRepository.EXPECT().GetBatchItems(mock.Anything, id, 0).Return([]model.BatchItem{
+		{Index: 0, Status: model.BatchItemRunning},
+		{Index: 1, Status: model.BatchItemFailed, Error: "failed"},
+	}, nil).Once()
+	batchRepository.EXPECT().GetBatchItems(mock.Anything, id, 0).Return([]model.BatchItem{
+		{Index: 0, Status: model.BatchItemSucceeded},
+		{Index: 1, Status: model.BatchItemFailed, Error: "failed"},
+	}, nil).Once()
+
+	batchService := NewBatchService(NewFakeClient(nil), nil, batchRepository, nil, testBatchConfig)
+
+	var indexes []int
+	for item, err := range batchService.WatchBatch(batchTestContext(), id) {
+		require.NoError(t, err)
+		indexes = append(indexes, item.Index)
+	}
+
+	assert.Equal(t, []int{1, 0}, indexes)
+}
+
+func TestBatchServiceHidesBatchesOfOtherCallers(t *testing.T) {
+	const id = "8f4e2c52-2a4b-4f7e-9a0c-6f1f9d1b2c3d"
+
+	batchRepository := repository.NewMockBatchRepository(t)
+	batchRepository.EXPECT().GetBatch(mock.Anything, id).
+		Return(&model.Batch{ID: id, Caller: domain.Caller{Name: "college-service"}}, nil)
+
+	batchService := NewBatchService(NewFakeClient(nil), nil, batchRepository, nil, testBatchConfig)
+
+	var notFoundErr *BatchNotFoundError
+
+	_, _, err := batchService.GetBatch(batchTestContext(), id)
+	require.True(t, errors.As(err, &notFoundErr))
+
+	_, _, err = batchService.GetBatch(batchTestContext(), "not-a-uuid")
+	require.True(t, errors.As(err, &notFoundErr))
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"iter"
+	"time"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+// cachingLLMService serves identical requests from the cache instead of calling the wrapped LLMService.
+// Requests with non-zero temperature aren't cached, as their callers expect different responses.
+type cachingLLMService struct {
+	LLMService
+	responseCacheRepository repository.ResponseCacheRepository
+}
+
+func NewCachingLLMService(llmService LLMService, responseCacheRepository repository.ResponseCacheRepository) LLMService {
+	return &cachingLLMService{
+		LLMService:              llmService,
+		responseCacheRepository: responseCacheRepository,
+	}
+}
+
+func (s *cachingLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	if !isCacheable(options) {
+		response, err := s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Usage.CacheStatus = domain.CacheStatusBypass
+		return response, nil
+	}
+
+	startedAt := time.Now()
+	key := responseCacheKey(chatHistory, tools, structuredOutputSchema, options)
+
+	if cached := s.getCachedResponse(ctx, key); cached != nil {
+		cached.Usage = cachedUsage(cached.Usage, time.Since(startedAt))
+		return cached, nil
+	}
+
+	response, err := s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Usage.CacheStatus = domain.CacheStatusMiss
+	s.cacheResponse(ctx, key, *response)
+
+	return response, nil
+}
+
+func (s *cachingLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	stream := s.LLMService.GenerateResponseStream(ctx, chatHistory, tools, structuredOutputSchema, options)
+
+	if !isCacheable(options) {
+		return withCacheStatus(stream, domain.CacheStatusBypass)
+	}
+
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		startedAt := time.Now()
+		key := responseCacheKey(chatHistory, tools, structuredOutputSchema, options)
+
+		if cached := s.getCachedResponse(ctx, key); cached != nil {
+			usage := cachedUsage(cached.Usage, time.Since(startedAt))
+
+			if !yield(&domain.MessageDelta{Text: cached.Message.Text, ToolCalls: cached.Message.ToolCalls}, nil) {
+				return
+			}
+
+			yield(&domain.MessageDelta{Usage: &usage, ContextTrimming: cached.ContextTrimming}, nil)
+			return
+		}
+
+		// The response is cached only if it was received completely.
+		response := domain.Response{Message: domain.Message{Role: domain.RoleAssistant}}
+
+		for delta, err := range stream {
+			if err == nil {
+				response.Message.Text += delta.Text
+				response.Message.ToolCalls = append(response.Message.ToolCalls, delta.ToolCalls...)
+
+				if delta.Usage != nil {
+					delta.Usage.CacheStatus = domain.CacheStatusMiss
+					response.Usage = *delta.Usage
+					response.ContextTrimming = delta.ContextTrimming
+
+					s.cacheResponse(ctx, key, response)
+				}
+			}
+
+			if !yield(delta, err) {
+				return
+			}
+		}
+	}
+}
+
+// getCachedResponse treats cache failures as misses, as the cache is only an optimization.
+func (s *cachingLLMService) getCachedResponse(ctx context.Context, key string) *domain.Response {
+	response, err := s.responseCacheRepository.GetResponse(ctx, key)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to get cached LLM response: %v", err)
+		return nil
+	}
+
+	return response
+}
+
+func (s *cachingLLMService) cacheResponse(ctx context.Context, key string, response domain.Response) {
+	if err := s.responseCacheRepository.SetResponse(ctx, key, response); err != nil {
+		log.L(ctx).Errorf("Failed to cache LLM response: %v", err)
+	}
+}
+
+func isCacheable(options domain.GenerationOptions) bool {
+	return !options.NoCache && (options.Temperature == nil || *options.Temperature == 0)
+}
+
+// cachedUsage reports no tokens for the cached response, as nothing was spent on it.
+func cachedUsage(usage domain.Usage, latency time.Duration) domain.Usage {
+	return domain.Usage{
+		Model:       usage.Model,
+		Latency:     latency,
+		CacheStatus: domain.CacheStatusHit,
+	}
+}
+
+func withCacheStatus(
+	stream iter.Seq2[*domain.MessageDelta, error], cacheStatus domain.CacheStatus) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		for delta, err := range stream {
+			if err == nil && delta.Usage != nil {
+				delta.Usage.CacheStatus = cacheStatus
+			}
+
+			if !yield(delta, err) {
+				return
+			}
+		}
+	}
+}
+
+// responseCacheKey returns the hash identifying the request. Maps are encoded with sorted keys,
+// so the encoding is deterministic.
+func responseCacheKey(
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) string {
+	encoded, _ := json.Marshal(struct {
+		ChatHistory            []domain.Message
+		Tools                  []domain.ToolDefinition
+		StructuredOutputSchema *domain.Schema
+		Options                domain.GenerationOptions
+	}{chatHistory, tools, structuredOutputSchema, options})
+	hash := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+func TestCachingLLMServiceCachesDeterministicResponses(t *testing.T) {
+	ctx := context.Background()
+	chatHistory := []domain.Message{{Role: domain.RoleUser, Text: "Evaluate my essay"}}
+
+	var cached *domain.Response
+
+	responseCacheRepository := repository.NewMockResponseCacheRepository(t)
+	responseCacheRepository.EXPECT().GetResponse(mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string) (*domain.Response, error) {
+			if cached == nil {
+				return nil, nil
+			}
+
+			response := *cached
+			return &response, nil
+		})
+	responseCacheRepository.EXPECT().SetResponse(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, response domain.Response) error {
+			cached = &response
+			return nil
+		}).Once()
+
+	llmService := NewCachingLLMService(NewFakeClient(nil), responseCacheRepository)
+
+	miss, err := llmService.GenerateResponse(ctx, chatHistory, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusMiss, miss.Usage.CacheStatus)
+	assert.NotZero(t, miss.Usage.PromptTokens)
+
+	hit, err := llmService.GenerateResponse(ctx, chatHistory, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusHit, hit.Usage.CacheStatus)
+	assert.Zero(t, hit.Usage.PromptTokens)
+	assert.Equal(t, miss.Message, hit.Message)
+
+	var streamed string
+	var usage *domain.Usage
+	for delta, err := range llmService.GenerateResponseStream(ctx, chatHistory, nil, nil, domain.GenerationOptions{}) {
+		require.NoError(t, err)
+		streamed += delta.Text
+
+		if delta.Usage != nil {
+			usage = delta.Usage
+		}
+	}
+
+	assert.Equal(t, miss.Message.Text, streamed)
+	require.NotNil(t, usage)
+	assert.Equal(t, domain.CacheStatusHit, usage.CacheStatus)
+}
+
+func TestCachingLLMServiceBypassesCache(t *testing.T) {
+	ctx := context.Background()
+	chatHistory := []domain.Message{{Role: domain.RoleUser, Text: "Rewrite my essay"}}
+	temperature := float32(0.8)
+
+	// The mock fails the test if the cache is accessed.
+	llmService := NewCachingLLMService(NewFakeClient(nil), repository.NewMockResponseCacheRepository(t))
+
+	for _, options := range []domain.GenerationOptions{{Temperature: &temperature}, {NoCache: true}} {
+		response, err := llmService.GenerateResponse(ctx, chatHistory, nil, nil, options)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CacheStatusBypass, response.Usage.CacheStatus)
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+const (
+	// contextWindowMarginDivisor leaves a part of the input token limit unused when the chat history is shortened,
+	// as the token counts of the shortened messages are only estimated.
+	contextWindowMarginDivisor = 20
+	// maxTrimAttempts is how many times the shortened chat history is counted again and shortened further
+	// if the estimates turned out to be too low.
+	maxTrimAttempts = 3
+	// maxSummaryDepth is how many times the summaries of the chunks may be summarized again
+	// before they are truncated.
+	maxSummaryDepth = 3
+	// minSummaryTokens keeps the summaries of the chunks meaningful when the text is split into many chunks.
+	minSummaryTokens = 64
+
+	truncationMarker = "\n[...]"
+)
+
+// ContextWindowExceededError is returned when the chat history doesn't fit into the context window of the model
+// and the request doesn't allow to shorten it, or it can't be shortened enough.
+type ContextWindowExceededError struct {
+	Model           string
+	PromptTokens    int32
+	InputTokenLimit int32
+}
+
+func (e *ContextWindowExceededError) Error() string {
+	return fmt.Sprintf("prompt of %d tokens exceeds the input token limit of %d tokens of model %s",
+		e.PromptTokens, e.InputTokenLimit, e.Model)
+}
+
+type ContextWindowConfig struct {
+	// MaxInputTokens caps the input token limits of all models, which keeps the cost of requests down.
+	// Zero means the limits of the models.
+	MaxInputTokens int32
+}
+
+// contextWindowLLMService counts the tokens of the chat history before it is sent to the wrapped LLMService
+// and shortens the history which doesn't fit into the context window of the model according to the policy
+// of the request. The longest messages are shortened first, down to the same length.
+type contextWindowLLMService struct {
+	LLMService
+	tokenCounter TokenCounter
+	config       ContextWindowConfig
+}
+
+func NewContextWindowLLMService(
+	llmService LLMService, tokenCounter TokenCounter, config ContextWindowConfig) LLMService {
+	return &contextWindowLLMService{
+		LLMService:   llmService,
+		tokenCounter: tokenCounter,
+		config:       config,
+	}
+}
+
+// fittedChatHistory is the chat history which fits into the context window. Trimming is nil if the history
+// wasn't shortened, usage describes the tokens spent on the summaries.
+type fittedChatHistory struct {
+	chatHistory []domain.Message
+	trimming    *domain.ContextTrimming
+	usage       domain.Usage
+}
+
+func (s *contextWindowLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	fitted, err := s.fitContextWindow(ctx, chatHistory, options)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.LLMService.GenerateResponse(ctx, fitted.chatHistory, tools, structuredOutputSchema, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if fitted.trimming != nil {
+		response.Usage = addUsage(fitted.usage, response.Usage)
+		response.ContextTrimming = fitted.trimming
+	}
+
+	return response, nil
+}
+
+func (s *contextWindowLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		fitted, err := s.fitContextWindow(ctx, chatHistory, options)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		stream := s.LLMService.GenerateResponseStream(ctx, fitted.chatHistory, tools, structuredOutputSchema, options)
+		for delta, err := range stream {
+			if err == nil && delta.Usage != nil && fitted.trimming != nil {
+				usage := addUsage(fitted.usage, *delta.Usage)
+				delta.Usage = &usage
+				delta.ContextTrimming = fitted.trimming
+			}
+
+			if !yield(delta, err) {
+				return
+			}
+		}
+	}
+}
+
+func (s *contextWindowLLMService) fitContextWindow(
+	ctx context.Context, chatHistory []domain.Message, options domain.GenerationOptions) (*fittedChatHistory, error) {
+	count, err := s.countTokens(ctx, chatHistory, options.Model)
+	if err != nil {
+		// Counting is only a precaution, as the provider rejects the prompts which are too long anyway.
+		log.L(ctx).Warnf("Failed to count prompt tokens, sending the prompt as is: %v", err)
+		return &fittedChatHistory{chatHistory: chatHistory}, nil
+	}
+
+	if count.PromptTokens <= count.InputTokenLimit {
+		return &fittedChatHistory{chatHistory: chatHistory}, nil
+	}
+
+	if options.ContextOverflowPolicy != domain.ContextOverflowTruncate &&
+		options.ContextOverflowPolicy != domain.ContextOverflowSummarize {
+		return nil, &ContextWindowExceededError{
+			Model:           count.Model,
+			PromptTokens:    count.PromptTokens,
+			InputTokenLimit: count.InputTokenLimit,
+		}
+	}
+
+	log.L(ctx).Infof("Prompt of %d tokens exceeds the input token limit of %d tokens of model %s, shortening it (%s)",
+		count.PromptTokens, count.InputTokenLimit, count.Model, options.ContextOverflowPolicy)
+
+	fitted := &fittedChatHistory{
+		chatHistory: slices.Clone(chatHistory),
+		trimming: &domain.ContextTrimming{
+			Policy:               options.ContextOverflowPolicy,
+			OriginalPromptTokens: count.PromptTokens,
+			InputTokenLimit:      count.InputTokenLimit,
+		},
+	}
+
+	trimmedMessages := make(map[int]*domain.TrimmedMessage)
+
+	for range maxTrimAttempts {
+		estimator := newTokenEstimator(fitted.chatHistory, count.PromptTokens)
+		targetTokens := count.InputTokenLimit - count.InputTokenLimit/contextWindowMarginDivisor
+
+		textTokens := make([]int32, len(fitted.chatHistory))
+		for i, msg := range fitted.chatHistory {
+			textTokens[i] = estimator.tokens(msg.Text)
+		}
+
+		maxTextTokens := waterLevel(textTokens, count.PromptTokens-targetTokens)
+
+		for i, msg := range fitted.chatHistory {
+			if textTokens[i] <= maxTextTokens {
+				continue
+			}
+
+			text, usage, err := s.shortenText(ctx, msg.Text, maxTextTokens, count.InputTokenLimit, estimator, options)
+			if err != nil {
+				return nil, err
+			}
+
+			fitted.chatHistory[i].Text = text
+			fitted.usage = addUsage(fitted.usage, usage)
+
+			trimmedMessage, ok := trimmedMessages[i]
+			if !ok {
+				trimmedMessage = &domain.TrimmedMessage{Index: i, OriginalTokens: textTokens[i]}
+				trimmedMessages[i] = trimmedMessage
+			}
+
+			trimmedMessage.Tokens = estimator.tokens(text)
+		}
+
+		count, err = s.countTokens(ctx, fitted.chatHistory, options.Model)
+		if err != nil {
+			return nil, err
+		}
+
+		if count.PromptTokens <= count.InputTokenLimit {
+			fitted.trimming.PromptTokens = count.PromptTokens
+
+			for _, i := range slices.Sorted(maps.Keys(trimmedMessages)) {
+				fitted.trimming.Messages = append(fitted.trimming.Messages, *trimmedMessages[i])
+			}
+
+			return fitted, nil
+		}
+	}
+
+	return nil, &ContextWindowExceededError{
+		Model:           count.Model,
+		PromptTokens:    count.PromptTokens,
+		InputTokenLimit: count.InputTokenLimit,
+	}
+}
+
+// countTokens applies the cap of the config to the input token limit of the model.
+func (s *contextWindowLLMService) countTokens(
+	ctx context.Context, chatHistory []domain.Message, model string) (*domain.TokenCount, error) {
+	count, err := s.tokenCounter.CountTokens(ctx, chatHistory, model)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.config.MaxInputTokens > 0 && (count.InputTokenLimit == 0 || count.InputTokenLimit > s.config.MaxInputTokens) {
+		count.InputTokenLimit = s.config.MaxInputTokens
+	}
+
+	return count, nil
+}
+
+// shortenText shortens the text to maxTokens by truncating or summarizing it. The summaries are generated
+// in chunks of at most half of the input token limit, leaving the rest to the instructions and the summary.
+func (s *contextWindowLLMService) shortenText(
+	ctx context.Context, text string, maxTokens int32, inputTokenLimit int32,
+	estimator tokenEstimator, options domain.GenerationOptions) (string, domain.Usage, error) {
+	if options.ContextOverflowPolicy != domain.ContextOverflowSummarize {
+		return truncateText(text, estimator.runes(maxTokens)), domain.Usage{}, nil
+	}
+
+	return s.summarizeText(ctx, text, maxTokens, estimator.runes(inputTokenLimit/2), estimator, options, 1)
+}
+
+// summarizeText splits the text into chunks, summarizes every chunk and joins the summaries. If the joined
+// summaries are still too long, they are summarized again, and truncated when maxSummaryDepth is reached.
+func (s *contextWindowLLMService) summarizeText(
+	ctx context.Context, text string, maxTokens int32, maxChunkRunes int,
+	estimator tokenEstimator, options domain.GenerationOptions, depth int) (string, domain.Usage, error) {
+	chunks := splitIntoChunks(text, maxChunkRunes)
+	maxChunkSummaryTokens := max(maxTokens/int32(len(chunks)), minSummaryTokens)
+
+	var usage domain.Usage
+
+	summaries := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		response, err := s.LLMService.GenerateResponse(ctx, summaryChatHistory(chunk, maxChunkSummaryTokens), nil, nil,
+			domain.GenerationOptions{Model: options.Model, MaxOutputTokens: &maxChunkSummaryTokens})
+		if err != nil {
+			return "", domain.Usage{}, fmt.Errorf("failed to summarize chat history: %w", err)
+		}
+
+		usage = addUsage(usage, response.Usage)
+		summaries[i] = response.Message.Text
+	}
+
+	summary := strings.Join(summaries, "\n\n")
+	if estimator.tokens(summary) <= maxTokens {
+		return summary, usage, nil
+	}
+
+	if depth >= maxSummaryDepth {
+		return truncateText(summary, estimator.runes(maxTokens)), usage, nil
+	}
+
+	summary, reduceUsage, err := s.summarizeText(ctx, summary, maxTokens, maxChunkRunes, estimator, options, depth+1)
+	if err != nil {
+		return "", domain.Usage{}, err
+	}
+
+	return summary, addUsage(usage, reduceUsage), nil
+}
+
+func summaryChatHistory(text string, maxTokens int32) []domain.Message {
+	// Words are about 3/4 of tokens in English text.
+	maxWords := maxTokens * 3 / 4
+
+	return []domain.Message{
+		{
+			Role: domain.RoleSystem,
+			Text: fmt.Sprintf(`Summarize the text sent by the user in at most %d words. The summary replaces the text
+in a longer prompt, so keep the names, numbers, dates and other specific facts, and keep instructions as instructions.
+Respond with the summary only.`, maxWords),
+		},
+		{Role: domain.RoleUser, Text: text},
+	}
+}
+
+// waterLevel returns the largest number of tokens, such that cutting all texts longer than it to it
+// removes at least excessTokens.
+func waterLevel(textTokens []int32, excessTokens int32) int32 {
+	sorted := slices.Clone(textTokens)
+	slices.SortFunc(sorted, func(a, b int32) int { return int(b - a) })
+
+	var sum int32
+	for i, tokens := range sorted {
+		sum += tokens
+
+		next := int32(0)
+		if i+1 < len(sorted) {
+			next = sorted[i+1]
+		}
+
+		// Cutting the i+1 longest texts to the level removes sum - (i+1)*level tokens.
+		level := (sum - excessTokens) / int32(i+1)
+		if level >= next {
+			return max(level, 0)
+		}
+	}
+
+	return 0
+}
+
+// truncateText keeps the beginning of the text of at most maxRunes characters.
+func truncateText(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+
+	return string(runes[:max(maxRunes-len(truncationMarker), 0)]) + truncationMarker
+}
+
+// splitIntoChunks splits the text into chunks of at most maxRunes characters at paragraph boundaries,
+// splitting the paragraphs which are too long on their own.
+func splitIntoChunks(text string, maxRunes int) []string {
+	maxRunes = max(maxRunes, 1)
+
+	var chunks []string
+	var chunk []rune
+
+	for _, paragraph := range strings.SplitAfter(text, "\n\n") {
+		runes := []rune(paragraph)
+
+		if len(chunk) > 0 && len(chunk)+len(runes) > maxRunes {
+			chunks = append(chunks, string(chunk))
+			chunk = nil
+		}
+
+		for len(runes) > maxRunes {
+			chunks = append(chunks, string(runes[:maxRunes]))
+			runes = runes[maxRunes:]
+		}
+
+		chunk = append(chunk, runes...)
+	}
+
+	if len(chunk) > 0 || len(chunks) == 0 {
+		chunks = append(chunks, string(chunk))
+	}
+
+	return chunks
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// recordingLLMService records the chat histories sent to the fake client.
+type recordingLLMService struct {
+	LLMService
+	chatHistories [][]domain.Message
+}
+
+func (s *recordingLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	s.chatHistories = append(s.chatHistories, chatHistory)
+	return s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+}
+
+func newContextWindowTestService(maxInputTokens int32) (LLMService, *recordingLLMService) {
+	fakeClient := NewFakeClient(nil)
+	provider := &recordingLLMService{LLMService: fakeClient}
+
+	return NewContextWindowLLMService(provider, fakeClient.(TokenCounter),
+		ContextWindowConfig{MaxInputTokens: maxInputTokens}), provider
+}
+
+// longChatHistory has a short system prompt and a user message of 1000 words, as the fake client counts words.
+func longChatHistory() []domain.Message {
+	return []domain.Message{
+		{Role: domain.RoleSystem, Text: "You are an expert college admissions consultant."},
+		{Role: domain.RoleUser, Text: strings.Repeat("essay ", 500) + strings.Repeat("activity\n\n", 500)},
+	}
+}
+
+func TestContextWindowLLMServiceRejectsLongPrompt(t *testing.T) {
+	llmService, provider := newContextWindowTestService(200)
+
+	_, err := llmService.GenerateResponse(context.Background(), longChatHistory(), nil, nil, domain.GenerationOptions{})
+
+	var exceededErr *ContextWindowExceededError
+	require.True(t, errors.As(err, &exceededErr))
+	assert.Equal(t, int32(1007), exceededErr.PromptTokens)
+	assert.Equal(t, int32(200), exceededErr.InputTokenLimit)
+	assert.Empty(t, provider.chatHistories)
+}
+
+func TestContextWindowLLMServiceKeepsPromptThatFits(t *testing.T) {
+	llmService, provider := newContextWindowTestService(2000)
+
+	response, err := llmService.GenerateResponse(context.Background(), longChatHistory(), nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Nil(t, response.ContextTrimming)
+	assert.Equal(t, longChatHistory(), provider.chatHistories[0])
+}
+
+func TestContextWindowLLMServiceTruncatesLongestMessages(t *testing.T) {
+	llmService, provider := newContextWindowTestService(200)
+
+	response, err := llmService.GenerateResponse(context.Background(), longChatHistory(), nil, nil,
+		domain.GenerationOptions{ContextOverflowPolicy: domain.ContextOverflowTruncate})
+	require.NoError(t, err)
+
+	trimming := response.ContextTrimming
+	require.NotNil(t, trimming)
+	assert.Equal(t, domain.ContextOverflowTruncate, trimming.Policy)
+	assert.Equal(t, int32(1007), trimming.OriginalPromptTokens)
+	assert.LessOrEqual(t, trimming.PromptTokens, int32(200))
+	require.Len(t, trimming.Messages, 1)
+	assert.Equal(t, 1, trimming.Messages[0].Index)
+	assert.Less(t, trimming.Messages[0].Tokens, trimming.Messages[0].OriginalTokens)
+
+	// The system prompt is short, so only the user message is truncated.
+	sent := provider.chatHistories[0]
+	assert.Equal(t, longChatHistory()[0], sent[0])
+	assert.True(t, strings.HasPrefix(sent[1].Text, "essay essay"))
+	assert.True(t, strings.HasSuffix(sent[1].Text, truncationMarker))
+}
+
+func TestContextWindowLLMServiceSummarizesLongestMessages(t *testing.T) {
+	llmService, provider := newContextWindowTestService(200)
+
+	response, err := llmService.GenerateResponse(context.Background(), longChatHistory(), nil, nil,
+		domain.GenerationOptions{ContextOverflowPolicy: domain.ContextOverflowSummarize})
+	require.NoError(t, err)
+
+	require.NotNil(t, response.ContextTrimming)
+	assert.Equal(t, domain.ContextOverflowSummarize, response.ContextTrimming.Policy)
+	require.Len(t, response.ContextTrimming.Messages, 1)
+
+	// The message is split into chunks which are summarized one by one before the response is generated.
+	summaryCalls := len(provider.chatHistories) - 1
+	require.Greater(t, summaryCalls, 1)
+
+	sent := provider.chatHistories[summaryCalls]
+	assert.Equal(t, longChatHistory()[0], sent[0])
+	assert.Equal(t, summaryCalls, strings.Count(sent[1].Text, "Fake response to prompt"))
+
+	// The usage includes the tokens spent on the summaries.
+	assert.Greater(t, response.Usage.PromptTokens, int32(200))
+}
+
+func TestContextWindowLLMServiceReportsTrimmingInStream(t *testing.T) {
+	llmService, _ := newContextWindowTestService(200)
+
+	var trimming *domain.ContextTrimming
+	for delta, err := range llmService.GenerateResponseStream(context.Background(), longChatHistory(), nil, nil,
+		domain.GenerationOptions{ContextOverflowPolicy: domain.ContextOverflowTruncate}) {
+		require.NoError(t, err)
+
+		if delta.Usage != nil {
+			trimming = delta.ContextTrimming
+		}
+	}
+
+	require.NotNil(t, trimming)
+	assert.Equal(t, domain.ContextOverflowTruncate, trimming.Policy)
+}
+
+func TestWaterLevel(t *testing.T) {
+	// Cutting 100 and 50 to 40 removes 70 tokens.
+	assert.Equal(t, int32(40), waterLevel([]int32{10, 100, 50}, 70))
+	assert.Equal(t, int32(0), waterLevel([]int32{10, 20}, 100))
+}
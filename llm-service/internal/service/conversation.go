@@ -0,0 +1,408 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+const (
+	// conversationCleanupInterval is how often the expired conversations are deleted.
+	conversationCleanupInterval = 10 * time.Minute
+	// conversationCompactionDivisor leaves room for the next messages when the history is compacted,
+	// so that it isn't compacted again on every turn.
+	conversationCompactionDivisor = 2
+	// conversationSummaryDivisor limits the summary of the history to a part of the maximum history size.
+	conversationSummaryDivisor = 4
+
+	conversationSummaryPrefix = "Summary of the earlier part of the conversation:\n\n"
+)
+
+// ConversationNotFoundError is returned when the conversation doesn't exist, has expired or was created
+// by another caller.
+type ConversationNotFoundError struct {
+	ID string
+}
+
+func (e *ConversationNotFoundError) Error() string {
+	return fmt.Sprintf("conversation %s doesn't exist", e.ID)
+}
+
+type ConversationConfig struct {
+	// TTL is how long a conversation is kept after its last message.
+	TTL time.Duration
+	// DefaultMaxHistoryTokens is used by the conversations which don't set their own limit.
+	DefaultMaxHistoryTokens int
+}
+
+// ConversationService keeps the chat histories of the callers, so that they only send the new messages.
+type ConversationService interface {
+	// CreateConversation creates a conversation of the caller from the context with the initial messages,
+	// usually the system prompt. Empty historyPolicy means ConversationHistoryKeepAll, and zero
+	// maxHistoryTokens means the default.
+	CreateConversation(
+		ctx context.Context, historyPolicy model.ConversationHistoryPolicy, maxHistoryTokens int,
+		messages []domain.Message) (*model.Conversation, error)
+	AppendMessages(ctx context.Context, id string, messages []domain.Message) (*model.Conversation, error)
+	// GenerateMessage generates the next message of the conversation following the new messages.
+	// The new messages and the generated one are appended to the conversation only if the generation succeeds.
+	GenerateMessage(
+		ctx context.Context, id string, messages []domain.Message, tools []domain.ToolDefinition,
+		structuredOutputSchema *domain.Schema, options domain.GenerationOptions) (*domain.Response, error)
+	// GenerateMessageStream is GenerateMessage which streams the generated message. The messages are appended
+	// before the last delta is yielded.
+	GenerateMessageStream(
+		ctx context.Context, id string, messages []domain.Message, tools []domain.ToolDefinition,
+		structuredOutputSchema *domain.Schema, options domain.GenerationOptions) iter.Seq2[*domain.MessageDelta, error]
+	// GetMessages returns all messages of the conversation, including the trimmed and summarized ones.
+	GetMessages(ctx context.Context, id string) (*model.Conversation, []domain.Message, error)
+	DeleteConversation(ctx context.Context, id string) error
+	// Run deletes the expired conversations until the context is cancelled.
+	Run(ctx context.Context)
+}
+
+type conversationService struct {
+	llmService             LLMService
+	conversationRepository repository.ConversationRepository
+	config                 ConversationConfig
+}
+
+func NewConversationService(
+	llmService LLMService, conversationRepository repository.ConversationRepository,
+	config ConversationConfig) ConversationService {
+	return &conversationService{
+		llmService:             llmService,
+		conversationRepository: conversationRepository,
+		config:                 config,
+	}
+}
+
+func (s *conversationService) CreateConversation(
+	ctx context.Context, historyPolicy model.ConversationHistoryPolicy, maxHistoryTokens int,
+	messages []domain.Message) (*model.Conversation, error) {
+	if historyPolicy == "" {
+		historyPolicy = model.ConversationHistoryKeepAll
+	}
+
+	if maxHistoryTokens == 0 {
+		maxHistoryTokens = s.config.DefaultMaxHistoryTokens
+	}
+
+	now := time.Now().UTC()
+	conversation := model.Conversation{
+		ID:               uuid.NewString(),
+		Caller:           localcontext.GetCaller(ctx),
+		HistoryPolicy:    historyPolicy,
+		MaxHistoryTokens: maxHistoryTokens,
+		MessageCount:     len(messages),
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(s.config.TTL),
+	}
+
+	if err := s.conversationRepository.CreateConversation(ctx, conversation, messages); err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func (s *conversationService) AppendMessages(
+	ctx context.Context, id string, messages []domain.Message) (*model.Conversation, error) {
+	if _, err := s.getCallerConversation(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.appendMessages(ctx, id, messages)
+}
+
+func (s *conversationService) appendMessages(
+	ctx context.Context, id string, messages []domain.Message) (*model.Conversation, error) {
+	conversation, err := s.conversationRepository.AppendConversationMessages(
+		ctx, id, messages, time.Now().UTC().Add(s.config.TTL))
+	if err != nil {
+		return nil, err
+	}
+
+	// The conversation may have expired or been deleted while the message was being generated.
+	if conversation == nil {
+		return nil, &ConversationNotFoundError{ID: id}
+	}
+
+	return conversation, nil
+}
+
+func (s *conversationService) GenerateMessage(
+	ctx context.Context, id string, messages []domain.Message, tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema, options domain.GenerationOptions) (*domain.Response, error) {
+	chatHistory, usage, err := s.prepareChatHistory(ctx, id, messages, options)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.llmService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Usage = addUsage(usage, response.Usage)
+
+	if _, err := s.appendMessages(ctx, id, append(slices.Clone(messages), response.Message)); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *conversationService) GenerateMessageStream(
+	ctx context.Context, id string, messages []domain.Message, tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema, options domain.GenerationOptions) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		chatHistory, usage, err := s.prepareChatHistory(ctx, id, messages, options)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		generated := domain.Message{Role: domain.RoleAssistant}
+
+		stream := s.llmService.GenerateResponseStream(ctx, chatHistory, tools, structuredOutputSchema, options)
+		for delta, err := range stream {
+			if err == nil {
+				generated.Text += delta.Text
+				generated.ToolCalls = append(generated.ToolCalls, delta.ToolCalls...)
+
+				if delta.Usage != nil {
+					totalUsage := addUsage(usage, *delta.Usage)
+					delta.Usage = &totalUsage
+
+					// The caller may continue the conversation as soon as the stream ends.
+					if _, err := s.appendMessages(ctx, id, append(slices.Clone(messages), generated)); err != nil {
+						yield(nil, err)
+						return
+					}
+				}
+			}
+
+			if !yield(delta, err) {
+				return
+			}
+		}
+	}
+}
+
+// prepareChatHistory compacts the history of the conversation if it grew too long and returns the chat
+// history to generate the next message from, together with the usage of the summary.
+func (s *conversationService) prepareChatHistory(
+	ctx context.Context, id string, messages []domain.Message,
+	options domain.GenerationOptions) ([]domain.Message, domain.Usage, error) {
+	conversation, err := s.getCallerConversation(ctx, id)
+	if err != nil {
+		return nil, domain.Usage{}, err
+	}
+
+	history, err := s.conversationRepository.GetConversationMessages(ctx, id)
+	if err != nil {
+		return nil, domain.Usage{}, err
+	}
+
+	usage, err := s.compactHistory(ctx, conversation, history, messages, options)
+	if err != nil {
+		return nil, domain.Usage{}, err
+	}
+
+	return append(historyChatHistory(conversation, history), messages...), usage, nil
+}
+
+// compactHistory trims or summarizes the earliest messages of the history once it exceeds the maximum size
+// of the conversation, until it takes half of the maximum size. The leading system messages are kept.
+func (s *conversationService) compactHistory(
+	ctx context.Context, conversation *model.Conversation, history []domain.Message, messages []domain.Message,
+	options domain.GenerationOptions) (domain.Usage, error) {
+	if conversation.HistoryPolicy == model.ConversationHistoryKeepAll {
+		return domain.Usage{}, nil
+	}
+
+	maxTokens := int32(conversation.MaxHistoryTokens)
+
+	tokens := estimateChatHistoryTokens(append(historyChatHistory(conversation, history), messages...))
+	if tokens <= maxTokens {
+		return domain.Usage{}, nil
+	}
+
+	leading := leadingSystemMessages(history)
+	start := min(leading+conversation.CompactedMessages, len(history))
+
+	cut := start
+	for cut < len(history) && tokens > maxTokens/conversationCompactionDivisor {
+		tokens -= estimateChatHistoryTokens(history[cut : cut+1])
+		cut++
+	}
+
+	// The tool calls must stay together with their results, so the history is cut before a user message.
+	for cut < len(history) && history[cut].Role != domain.RoleUser {
+		cut++
+	}
+
+	if cut == start {
+		return domain.Usage{}, nil
+	}
+
+	var summary string
+	var usage domain.Usage
+
+	if conversation.HistoryPolicy == model.ConversationHistorySummarize {
+		var err error
+
+		summary, usage, err = s.summarizeHistory(ctx, conversation.Summary, history[start:cut],
+			maxTokens/conversationSummaryDivisor, options)
+		if err != nil {
+			return domain.Usage{}, err
+		}
+	}
+
+	log.L(ctx).Infof("History of conversation %s exceeds %d tokens, %s %d messages",
+		conversation.ID, maxTokens, conversation.HistoryPolicy, cut-start)
+
+	conversation.CompactedMessages = cut - leading
+	conversation.Summary = summary
+
+	return usage, s.conversationRepository.CompactConversation(ctx, conversation.ID, cut-leading, summary)
+}
+
+// summarizeHistory summarizes the messages together with the summary of the messages before them.
+func (s *conversationService) summarizeHistory(
+	ctx context.Context, summary string, messages []domain.Message, maxTokens int32,
+	options domain.GenerationOptions) (string, domain.Usage, error) {
+	var transcript strings.Builder
+	if summary != "" {
+		transcript.WriteString(conversationSummaryPrefix + summary + "\n\n")
+	}
+
+	for _, msg := range messages {
+		fmt.Fprintf(&transcript, "%s: %s\n\n", msg.Role, messageTokenText(msg))
+	}
+
+	// Words are about 3/4 of tokens in English text.
+	maxWords := maxTokens * 3 / 4
+
+	chatHistory := []domain.Message{
+		{
+			Role: domain.RoleSystem,
+			Text: fmt.Sprintf(`Summarize the conversation between a user and an assistant sent by the user in at most %d
+words. The summary replaces the messages in the history of the conversation, so keep the facts about the user, their
+goals, the decisions made and the open questions. Respond with the summary only.`, maxWords),
+		},
+		{Role: domain.RoleUser, Text: transcript.String()},
+	}
+
+	response, err := s.llmService.GenerateResponse(ctx, chatHistory, nil, nil, domain.GenerationOptions{
+		Model:                 options.Model,
+		MaxOutputTokens:       &maxTokens,
+		ContextOverflowPolicy: domain.ContextOverflowSummarize,
+	})
+	if err != nil {
+		return "", domain.Usage{}, fmt.Errorf("failed to summarize conversation: %w", err)
+	}
+
+	return response.Message.Text, response.Usage, nil
+}
+
+// historyChatHistory is the history of the conversation sent to the model: the leading system messages,
+// the summary of the compacted messages and the rest of the messages.
+func historyChatHistory(conversation *model.Conversation, history []domain.Message) []domain.Message {
+	leading := leadingSystemMessages(history)
+	start := min(leading+conversation.CompactedMessages, len(history))
+
+	chatHistory := slices.Clone(history[:leading])
+	if conversation.Summary != "" {
+		chatHistory = append(chatHistory, domain.Message{
+			Role: domain.RoleSystem,
+			Text: conversationSummaryPrefix + conversation.Summary,
+		})
+	}
+
+	return append(chatHistory, history[start:]...)
+}
+
+func leadingSystemMessages(history []domain.Message) int {
+	for i, msg := range history {
+		if msg.Role != domain.RoleSystem {
+			return i
+		}
+	}
+
+	return len(history)
+}
+
+func (s *conversationService) GetMessages(
+	ctx context.Context, id string) (*model.Conversation, []domain.Message, error) {
+	conversation, err := s.getCallerConversation(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages, err := s.conversationRepository.GetConversationMessages(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conversation, messages, nil
+}
+
+func (s *conversationService) DeleteConversation(ctx context.Context, id string) error {
+	if _, err := s.getCallerConversation(ctx, id); err != nil {
+		return err
+	}
+
+	return s.conversationRepository.DeleteConversation(ctx, id)
+}
+
+// getCallerConversation hides the conversations of other callers, as if they didn't exist.
+func (s *conversationService) getCallerConversation(ctx context.Context, id string) (*model.Conversation, error) {
+	if uuid.Validate(id) != nil {
+		return nil, &ConversationNotFoundError{ID: id}
+	}
+
+	conversation, err := s.conversationRepository.GetConversation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if conversation == nil || conversation.Caller.Name != localcontext.GetCaller(ctx).Name {
+		return nil, &ConversationNotFoundError{ID: id}
+	}
+
+	return conversation, nil
+}
+
+func (s *conversationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(conversationCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.conversationRepository.DeleteExpiredConversations(ctx, time.Now())
+		if err != nil {
+			log.L(ctx).Errorf("Failed to delete expired conversations: %v", err)
+		} else if deleted > 0 {
+			log.L(ctx).Infof("Deleted %d expired conversations", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
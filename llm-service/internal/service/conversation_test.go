@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+const testConversationID = "5b0c6f4e-1d2a-4c8e-9f3b-7a6d5e4c3b2a"
+
+var testConversationConfig = ConversationConfig{TTL: time.Hour, DefaultMaxHistoryTokens: 500}
+
+// longConversationHistory has a system prompt and ten turns of about a hundred tokens each.
+func longConversationHistory() []domain.Message {
+	history := []domain.Message{{Role: domain.RoleSystem, Text: "You are an essay coach."}}
+	for range 5 {
+		history = append(history,
+			domain.Message{Role: domain.RoleUser, Text: strings.Repeat("question ", 44)},
+			domain.Message{Role: domain.RoleAssistant, Text: strings.Repeat("answer ", 57)})
+	}
+
+	return history
+}
+
+func newConversationTestService(
+	t *testing.T, policy model.ConversationHistoryPolicy,
+	history []domain.Message) (ConversationService, *repository.MockConversationRepository, *recordingLLMService) {
+	conversationRepository := repository.NewMockConversationRepository(t)
+	conversationRepository.EXPECT().GetConversation(mock.Anything, testConversationID).Return(&model.Conversation{
+		ID:               testConversationID,
+		Caller:           domain.Caller{Name: "application-service"},
+		HistoryPolicy:    policy,
+		MaxHistoryTokens: 500,
+		MessageCount:     len(history),
+	}, nil)
+	conversationRepository.EXPECT().GetConversationMessages(mock.Anything, testConversationID).
+		Return(history, nil).Maybe()
+
+	provider := &recordingLLMService{LLMService: NewFakeClient(nil)}
+
+	return NewConversationService(provider, conversationRepository, testConversationConfig),
+		conversationRepository, provider
+}
+
+func TestConversationServiceAppendsNewAndGeneratedMessages(t *testing.T) {
+	history := []domain.Message{{Role: domain.RoleSystem, Text: "You are an essay coach."}}
+	conversationService, conversationRepository, provider := newConversationTestService(
+		t, model.ConversationHistoryKeepAll, history)
+
+	newMessages := []domain.Message{{Role: domain.RoleUser, Text: "Is my introduction too long?"}}
+
+	conversationRepository.EXPECT().AppendConversationMessages(mock.Anything, testConversationID, mock.Anything,
+		mock.Anything).RunAndReturn(func(
+		_ context.Context, _ string, messages []domain.Message, _ time.Time) (*model.Conversation, error) {
+		require.Len(t, messages, 2)
+		assert.Equal(t, newMessages[0], messages[0])
+		assert.Equal(t, domain.RoleAssistant, messages[1].Role)
+
+		return &model.Conversation{ID: testConversationID, MessageCount: 3}, nil
+	})
+
+	response, err := conversationService.GenerateMessage(batchTestContext(), testConversationID, newMessages,
+		nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Contains(t, response.Message.Text, "Fake response to prompt")
+	assert.Equal(t, append(history, newMessages...), provider.chatHistories[0])
+}
+
+func TestConversationServiceSummarizesEarliestMessages(t *testing.T) {
+	history := longConversationHistory()
+	conversationService, conversationRepository, provider := newConversationTestService(
+		t, model.ConversationHistorySummarize, history)
+
+	conversationRepository.EXPECT().CompactConversation(mock.Anything, testConversationID, mock.Anything,
+		mock.Anything).RunAndReturn(func(_ context.Context, _ string, compactedMessages int, summary string) error {
+		// The history is cut before a user message, so that the turns stay whole.
+		assert.Equal(t, 0, compactedMessages%2)
+		assert.Positive(t, compactedMessages)
+		assert.Contains(t, summary, "Fake response to prompt")
+
+		return nil
+	})
+	conversationRepository.EXPECT().AppendConversationMessages(mock.Anything, testConversationID, mock.Anything,
+		mock.Anything).Return(&model.Conversation{ID: testConversationID}, nil)
+
+	newMessages := []domain.Message{{Role: domain.RoleUser, Text: "What should I write next?"}}
+
+	_, err := conversationService.GenerateMessage(batchTestContext(), testConversationID, newMessages,
+		nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, provider.chatHistories, 2)
+	assert.Contains(t, provider.chatHistories[0][1].Text, "question")
+
+	prompt := provider.chatHistories[1]
+	assert.Equal(t, history[0], prompt[0])
+	assert.True(t, strings.HasPrefix(prompt[1].Text, conversationSummaryPrefix))
+	assert.Equal(t, newMessages[0], prompt[len(prompt)-1])
+	assert.LessOrEqual(t, estimateChatHistoryTokens(prompt), int32(500))
+}
+
+func TestConversationServiceTrimsEarliestMessages(t *testing.T) {
+	history := longConversationHistory()
+	conversationService, conversationRepository, provider := newConversationTestService(
+		t, model.ConversationHistoryTrim, history)
+
+	conversationRepository.EXPECT().CompactConversation(mock.Anything, testConversationID, mock.Anything, "").
+		Return(nil)
+	conversationRepository.EXPECT().AppendConversationMessages(mock.Anything, testConversationID, mock.Anything,
+		mock.Anything).Return(&model.Conversation{ID: testConversationID}, nil)
+
+	_, err := conversationService.GenerateMessage(batchTestContext(), testConversationID, nil, nil, nil,
+		domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	// Nothing is summarized, the prompt starts with the system prompt followed by the latest turns.
+	require.Len(t, provider.chatHistories, 1)
+
+	prompt := provider.chatHistories[0]
+	assert.Equal(t, history[0], prompt[0])
+	assert.Equal(t, domain.RoleUser, prompt[1].Role)
+	assert.Less(t, len(prompt), len(history))
+	assert.Equal(t, history[len(history)-1], prompt[len(prompt)-1])
+}
+
+func TestConversationServiceAppendsStreamedMessageBeforeLastDelta(t *testing.T) {
+	conversationService, conversationRepository, _ := newConversationTestService(
+		t, model.ConversationHistoryKeepAll, longConversationHistory())
+
+	var appended []domain.Message
+	conversationRepository.EXPECT().AppendConversationMessages(mock.Anything, testConversationID, mock.Anything,
+		mock.Anything).RunAndReturn(func(
+		_ context.Context, _ string, messages []domain.Message, _ time.Time) (*model.Conversation, error) {
+		appended = messages
+		return &model.Conversation{ID: testConversationID}, nil
+	})
+
+	var streamed string
+	for delta, err := range conversationService.GenerateMessageStream(batchTestContext(), testConversationID, nil,
+		nil, nil, domain.GenerationOptions{}) {
+		require.NoError(t, err)
+
+		streamed += delta.Text
+
+		if delta.Usage != nil {
+			assert.Equal(t, []domain.Message{{Role: domain.RoleAssistant, Text: streamed}}, appended)
+		}
+	}
+
+	assert.NotEmpty(t, appended)
+}
+
+func TestConversationServiceHidesConversationsOfOtherCallers(t *testing.T) {
+	conversationService, _, _ := newConversationTestService(t, model.ConversationHistoryKeepAll, nil)
+
+	var notFoundErr *ConversationNotFoundError
+
+	_, _, err := conversationService.GetMessages(callerContext("college-service"), testConversationID)
+	require.True(t, errors.As(err, &notFoundErr))
+}
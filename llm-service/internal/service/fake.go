@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/config"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+const (
+	fakeModel = "fake"
+	// fakeEmbeddingDimensions equals to the size of SHA-256 hash, which the fake embeddings are derived from.
+	fakeEmbeddingDimensions = sha256.Size
+	// fakeInputTokenLimit is large enough for any prompt in tests, which set lower limits in ContextWindowConfig.
+	fakeInputTokenLimit = 1 << 20
+)
+
+// fakeClient is a deterministic LLMService that never leaves the process. It replays canned responses
+// matched on the prompt fingerprint (see PromptFingerprint) and, when no canned response exists,
+// synthesizes a response conforming to the structured output schema.
+type fakeClient struct {
+	responses map[string]domain.Message
+}
+
+type fakeResponse struct {
+	Text      string `json:"text"`
+	ToolCalls []struct {
+		ID         string         `json:"id"`
+		Name       string         `json:"name"`
+		Parameters map[string]any `json:"parameters"`
+	} `json:"toolCalls"`
+}
+
+// NewFakeClient creates a fake LLMService replaying responses keyed by prompt fingerprints.
+func NewFakeClient(responses map[string]domain.Message) LLMService {
+	if responses == nil {
+		responses = make(map[string]domain.Message)
+	}
+
+	return &fakeClient{responses: responses}
+}
+
+// NewFakeClientFromFile creates a fake LLMService with canned responses loaded from a JSON file
+// mapping prompt fingerprints to responses. Empty path means that there are no canned responses.
+func NewFakeClientFromFile(_ context.Context, cfg *config.AppConfig) (LLMService, error) {
+	responses := make(map[string]domain.Message)
+	if cfg.FakeLLMResponsesPath == "" {
+		return NewFakeClient(responses), nil
+	}
+
+	content, err := os.ReadFile(cfg.FakeLLMResponsesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fake LLM responses file: %v", err)
+	}
+
+	var fakeResponses map[string]fakeResponse
+	if err := json.Unmarshal(content, &fakeResponses); err != nil {
+		return nil, fmt.Errorf("failed to parse fake LLM responses file: %v", err)
+	}
+
+	for fingerprint, response := range fakeResponses {
+		message := domain.Message{
+			Role: domain.RoleAssistant,
+			Text: response.Text,
+		}
+
+		for _, toolCall := range response.ToolCalls {
+			message.ToolCalls = append(message.ToolCalls, domain.ToolCall{
+				ID:         toolCall.ID,
+				Name:       toolCall.Name,
+				Parameters: toolCall.Parameters,
+			})
+		}
+
+		responses[fingerprint] = message
+	}
+
+	return NewFakeClient(responses), nil
+}
+
+func (f *fakeClient) GenerateResponse(
+	_ context.Context,
+	chatHistory []domain.Message,
+	_ []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	_ domain.GenerationOptions,
+) (*domain.Response, error) {
+	message, err := f.generateMessage(chatHistory, structuredOutputSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Response{
+		Message: *message,
+		Usage:   fakeUsage(chatHistory, message),
+	}, nil
+}
+
+func (f *fakeClient) generateMessage(
+	chatHistory []domain.Message, structuredOutputSchema *domain.Schema) (*domain.Message, error) {
+	fingerprint := PromptFingerprint(chatHistory)
+
+	if response, ok := f.responses[fingerprint]; ok {
+		return &response, nil
+	}
+
+	logrus.Infof("No canned response for prompt fingerprint %s, synthesizing one", fingerprint)
+
+	if structuredOutputSchema != nil {
+		text, err := json.Marshal(SynthesizeSchemaValue(structuredOutputSchema))
+		if err != nil {
+			return nil, fmt.Errorf("failed to synthesize structured output: %v", err)
+		}
+
+		return &domain.Message{Role: domain.RoleAssistant, Text: string(text)}, nil
+	}
+
+	return &domain.Message{
+		Role: domain.RoleAssistant,
+		Text: fmt.Sprintf("Fake response to prompt %s.", fingerprint),
+	}, nil
+}
+
+func (f *fakeClient) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		response, err := f.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		// Split the response into words to imitate token streaming.
+		words := strings.SplitAfter(response.Message.Text, " ")
+		for i, word := range words {
+			delta := &domain.MessageDelta{Text: word}
+			if i == len(words)-1 {
+				delta.ToolCalls = response.Message.ToolCalls
+			}
+
+			if !yield(delta, nil) {
+				return
+			}
+		}
+
+		yield(&domain.MessageDelta{Usage: &response.Usage}, nil)
+	}
+}
+
+// Embed derives the vectors from the hashes of the texts, so equal texts have equal embeddings.
+func (f *fakeClient) Embed(_ context.Context, texts []string, _ string) (*domain.Embeddings, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		hash := sha256.Sum256([]byte(text))
+
+		vectors[i] = make([]float32, fakeEmbeddingDimensions)
+		for j, b := range hash {
+			vectors[i][j] = float32(b)/127.5 - 1
+		}
+	}
+
+	return &domain.Embeddings{Vectors: vectors, Model: fakeModel}, nil
+}
+
+// CountTokens counts the words of the messages, like fakeUsage.
+func (f *fakeClient) CountTokens(_ context.Context, chatHistory []domain.Message, _ string) (*domain.TokenCount, error) {
+	var promptTokens int
+	for _, msg := range chatHistory {
+		promptTokens += len(strings.Fields(messageTokenText(msg)))
+	}
+
+	return &domain.TokenCount{
+		PromptTokens:    int32(promptTokens),
+		InputTokenLimit: fakeInputTokenLimit,
+		Model:           fakeModel,
+	}, nil
+}
+
+// fakeUsage estimates the token counts as the number of words in the prompt and in the response.
+func fakeUsage(chatHistory []domain.Message, response *domain.Message) domain.Usage {
+	var promptTokens int
+	for _, msg := range chatHistory {
+		promptTokens += len(strings.Fields(msg.Text))
+	}
+
+	return domain.Usage{
+		PromptTokens:     int32(promptTokens),
+		CompletionTokens: int32(len(strings.Fields(response.Text))),
+		Model:            fakeModel,
+	}
+}
+
+// PromptFingerprint returns a stable hash of the chat history which identifies the prompt
+// regardless of the generated tool call IDs, including the IDs referenced by tool results.
+func PromptFingerprint(chatHistory []domain.Message) string {
+	type fingerprintToolCall struct {
+		Name       string         `json:"name"`
+		Parameters map[string]any `json:"parameters"`
+	}
+
+	type fingerprintToolResult struct {
+		Name   string         `json:"name"`
+		Result map[string]any `json:"result"`
+	}
+
+	// Attachments are identified by the hash of their data, so that the fingerprint stays short.
+	type fingerprintAttachment struct {
+		MIMEType   string `json:"mimeType"`
+		DataSHA256 string `json:"dataSha256,omitempty"`
+		URI        string `json:"uri,omitempty"`
+	}
+
+	type fingerprintMessage struct {
+		Role       domain.Role            `json:"role"`
+		Text       string                 `json:"text"`
+		ToolCalls  []fingerprintToolCall  `json:"toolCalls"`
+		ToolResult *fingerprintToolResult `json:"toolResult,omitempty"`
+		// Attachments are omitted when empty, so that the fingerprints of text prompts stay the same.
+		Attachments []fingerprintAttachment `json:"attachments,omitempty"`
+	}
+
+	messages := make([]fingerprintMessage, len(chatHistory))
+	for i, msg := range chatHistory {
+		toolCalls := make([]fingerprintToolCall, len(msg.ToolCalls))
+		for j, toolCall := range msg.ToolCalls {
+			toolCalls[j] = fingerprintToolCall{
+				Name:       toolCall.Name,
+				Parameters: toolCall.Parameters,
+			}
+		}
+
+		messages[i] = fingerprintMessage{
+			Role:      msg.Role,
+			Text:      strings.TrimSpace(msg.Text),
+			ToolCalls: toolCalls,
+		}
+
+		if msg.ToolResult != nil {
+			messages[i].ToolResult = &fingerprintToolResult{
+				Name:   msg.ToolResult.Name,
+				Result: msg.ToolResult.Result,
+			}
+		}
+
+		for _, attachment := range msg.Attachments {
+			fingerprint := fingerprintAttachment{MIMEType: attachment.MIMEType, URI: attachment.URI}
+			if len(attachment.Data) > 0 {
+				dataHash := sha256.Sum256(attachment.Data)
+				fingerprint.DataSHA256 = hex.EncodeToString(dataHash[:])
+			}
+
+			messages[i].Attachments = append(messages[i].Attachments, fingerprint)
+		}
+	}
+
+	// Maps are encoded with sorted keys, so the encoding is deterministic.
+	encoded, _ := json.Marshal(messages)
+	hash := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// SynthesizeSchemaValue builds the smallest deterministic value conforming to the schema.
+func SynthesizeSchemaValue(schema *domain.Schema) any {
+	if len(schema.AnyOf) > 0 {
+		return SynthesizeSchemaValue(&schema.AnyOf[0])
+	}
+
+	switch schema.Type {
+	case domain.TypeObject:
+		object := make(map[string]any)
+		for name, prop := range schema.Properties {
+			object[name] = SynthesizeSchemaValue(&prop)
+		}
+
+		return object
+	case domain.TypeArray:
+		itemsCount := int64(1)
+		if schema.MinItems != nil {
+			itemsCount = *schema.MinItems
+		}
+
+		if schema.MaxItems != nil && *schema.MaxItems < itemsCount {
+			itemsCount = *schema.MaxItems
+		}
+
+		array := make([]any, 0, itemsCount)
+		if schema.Items != nil {
+			for range itemsCount {
+				array = append(array, SynthesizeSchemaValue(schema.Items))
+			}
+		}
+
+		return array
+	case domain.TypeNumber, domain.TypeInteger:
+		switch {
+		case schema.Minimum != nil:
+			return math.Ceil(*schema.Minimum)
+		case schema.Maximum != nil && *schema.Maximum < 0:
+			return math.Floor(*schema.Maximum)
+		default:
+			return 0
+		}
+	case domain.TypeBoolean:
+		return false
+	default:
+		if len(schema.Enum) > 0 {
+			return schema.Enum[0]
+		}
+
+		switch schema.Format {
+		case "date-time":
+			return "1970-01-01T00:00:00Z"
+		case "date":
+			return "1970-01-01"
+		default:
+			return "string"
+		}
+	}
+}
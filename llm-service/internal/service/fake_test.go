@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+func TestFakeClientReplaysCannedResponse(t *testing.T) {
+	chatHistory := []domain.Message{{Role: domain.RoleUser, Text: "Is my hook too cliché?"}}
+	fakeClient := NewFakeClient(map[string]domain.Message{
+		PromptFingerprint(chatHistory): {Role: domain.RoleAssistant, Text: "A little."},
+	})
+
+	ctx := context.Background()
+
+	response, err := fakeClient.GenerateResponse(ctx, chatHistory, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "A little.", response.Message.Text)
+	assert.Equal(t, domain.Usage{PromptTokens: 5, CompletionTokens: 2, Model: "fake"}, response.Usage)
+
+	var streamed string
+	var usage *domain.Usage
+	for delta, err := range fakeClient.GenerateResponseStream(ctx, chatHistory, nil, nil, domain.GenerationOptions{}) {
+		require.NoError(t, err)
+		streamed += delta.Text
+
+		if delta.Usage != nil {
+			usage = delta.Usage
+		}
+	}
+
+	assert.Equal(t, "A little.", streamed)
+	assert.Equal(t, &response.Usage, usage)
+}
+
+func TestPromptFingerprintIgnoresToolCallIDs(t *testing.T) {
+	first := []domain.Message{{
+		Role:      domain.RoleAssistant,
+		ToolCalls: []domain.ToolCall{{ID: "search1", Name: "search", Parameters: map[string]any{"query": "MIT"}}},
+	}}
+	second := []domain.Message{{
+		Role:      domain.RoleAssistant,
+		ToolCalls: []domain.ToolCall{{ID: "search2", Name: "search", Parameters: map[string]any{"query": "MIT"}}},
+	}}
+
+	assert.Equal(t, PromptFingerprint(first), PromptFingerprint(second))
+	assert.NotEqual(t, PromptFingerprint(first), PromptFingerprint([]domain.Message{{Role: domain.RoleUser}}))
+}
+
+func TestFakeClientSynthesizesStructuredOutput(t *testing.T) {
+	two := int64(2)
+	schema := &domain.Schema{
+		Type: domain.TypeObject,
+		Properties: map[string]domain.Schema{
+			"summary": {Type: domain.TypeString},
+			"rating":  {Type: domain.TypeInteger},
+			"evaluations": {
+				Type:     domain.TypeArray,
+				MinItems: &two,
+				MaxItems: &two,
+				Items:    &domain.Schema{Type: domain.TypeBoolean},
+			},
+		},
+	}
+
+	response, err := NewFakeClient(nil).GenerateResponse(
+		context.Background(), []domain.Message{{Role: domain.RoleSystem, Text: "Evaluate"}}, nil, schema, domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	var output struct {
+		Summary     string `json:"summary"`
+		Rating      int    `json:"rating"`
+		Evaluations []bool `json:"evaluations"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(response.Message.Text), &output))
+	assert.Len(t, output.Evaluations, 2)
+}
+
+func TestFakeClientEmbedsDeterministically(t *testing.T) {
+	embeddings, err := NewFakeClient(nil).Embed(context.Background(), []string{"MIT", "Stanford", "MIT"}, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "fake", embeddings.Model)
+	require.Len(t, embeddings.Vectors, 3)
+	assert.Len(t, embeddings.Vectors[0], fakeEmbeddingDimensions)
+	assert.Equal(t, embeddings.Vectors[0], embeddings.Vectors[2])
+	assert.NotEqual(t, embeddings.Vectors[0], embeddings.Vectors[1])
+}
@@ -2,25 +2,52 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/compendium-tech/compendium/llm-service/internal/domain"
 	"google.golang.org/genai"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/config"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+const (
+	defaultGeminiModel          = "gemini-2.0-flash"
+	defaultGeminiEmbeddingModel = "text-embedding-004"
 )
 
 type geminiClient struct {
-	client *genai.Client
-	tools  []*genai.Tool
+	client         *genai.Client
+	model          string
+	embeddingModel string
+	// inputTokenLimits caches the input token limits of the models by model name.
+	inputTokenLimits sync.Map
 }
 
-func NewGeminiClient(ctx context.Context, cfg *genai.ClientConfig) (LLMService, error) {
-	client, err := genai.NewClient(ctx, cfg)
+func NewGeminiClient(ctx context.Context, cfg *config.AppConfig) (LLMService, error) {
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:  cfg.GeminiApiKey,
+		Backend: genai.BackendGeminiAPI,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %v", err)
 	}
 
-	return &geminiClient{client: client}, nil
+	model := cfg.GeminiModel
+	if model == "" {
+		model = defaultGeminiModel
+	}
+
+	embeddingModel := cfg.GeminiEmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = defaultGeminiEmbeddingModel
+	}
+
+	return &geminiClient{client: client, model: model, embeddingModel: embeddingModel}, nil
 }
 
 func (g *geminiClient) GenerateResponse(
@@ -28,66 +55,434 @@ func (g *geminiClient) GenerateResponse(
 	chatHistory []domain.Message,
 	tools []domain.ToolDefinition,
 	structuredOutputSchema *domain.Schema,
-) (*domain.Message, error) {
-	g.useTools(tools)
-
-	contents := make([]*genai.Content, len(chatHistory))
-	for i, msg := range chatHistory {
-		parts := make([]*genai.Part, 1+len(msg.ToolCalls))
-		parts = append(parts, &genai.Part{Text: msg.Text})
-
-		for j, toolCall := range msg.ToolCalls {
-			parts[j] = &genai.Part{
-				FunctionCall: &genai.FunctionCall{
-					Name: toolCall.Name,
-					Args: toolCall.Parameters,
-				},
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	model := g.modelName(options)
+	contents, config := g.prepareRequest(chatHistory, tools, structuredOutputSchema, options)
+
+	result, err := g.client.Models.GenerateContent(ctx, model, contents, config)
+	if err != nil {
+		return nil, newGeminiError("failed to generate content", err)
+	}
+
+	return &domain.Response{
+		Message: domain.Message{
+			Role:      domain.RoleAssistant,
+			Text:      result.Text(),
+			ToolCalls: genAIResponseToToolCalls(result),
+		},
+		Usage: genAIResponseToUsage(result, model),
+	}, nil
+}
+
+func (g *geminiClient) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	model := g.modelName(options)
+	contents, config := g.prepareRequest(chatHistory, tools, structuredOutputSchema, options)
+
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		// Every chunk reports the usage accumulated so far, so only the last one matters.
+		usage := domain.Usage{Model: model}
+
+		for result, err := range g.client.Models.GenerateContentStream(ctx, model, contents, config) {
+			if err != nil {
+				yield(nil, newGeminiError("failed to generate content stream", err))
+				return
+			}
+
+			if result.UsageMetadata != nil {
+				usage = genAIResponseToUsage(result, model)
 			}
+
+			delta := &domain.MessageDelta{
+				Text:      result.Text(),
+				ToolCalls: genAIResponseToToolCalls(result),
+			}
+
+			if !yield(delta, nil) {
+				return
+			}
+		}
+
+		yield(&domain.MessageDelta{Usage: &usage}, nil)
+	}
+}
+
+func (g *geminiClient) Embed(ctx context.Context, texts []string, model string) (*domain.Embeddings, error) {
+	if model == "" {
+		model = g.embeddingModel
+	}
+
+	contents := make([]*genai.Content, len(texts))
+	for i, text := range texts {
+		contents[i] = &genai.Content{Parts: []*genai.Part{{Text: text}}, Role: genai.RoleUser}
+	}
+
+	result, err := g.client.Models.EmbedContent(ctx, model, contents, nil)
+	if err != nil {
+		return nil, newGeminiError("failed to embed content", err)
+	}
+
+	vectors := make([][]float32, len(result.Embeddings))
+	for i, embedding := range result.Embeddings {
+		vectors[i] = embedding.Values
+	}
+
+	return &domain.Embeddings{Vectors: vectors, Model: model}, nil
+}
+
+// CountTokens counts the tokens with the Gemini API, which doesn't support system instructions and tools
+// in the count requests, so all messages are counted as user or model contents.
+func (g *geminiClient) CountTokens(
+	ctx context.Context, chatHistory []domain.Message, model string) (*domain.TokenCount, error) {
+	model = g.modelName(domain.GenerationOptions{Model: model})
+
+	inputTokenLimit, err := g.inputTokenLimit(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([]*genai.Content, 0, len(chatHistory))
+	for _, msg := range chatHistory {
+		var parts []*genai.Part
+		if text := messageTokenText(msg); text != "" {
+			parts = append(parts, &genai.Part{Text: text})
+		}
+
+		for _, attachment := range msg.Attachments {
+			parts = append(parts, attachmentToGenAIPart(attachment))
+		}
+
+		if len(parts) == 0 {
+			continue
+		}
+
+		role := genai.RoleUser
+		if msg.Role == domain.RoleAssistant {
+			role = genai.RoleModel
+		}
+
+		contents = append(contents, &genai.Content{Parts: parts, Role: role})
+	}
+
+	tokenCount := &domain.TokenCount{InputTokenLimit: inputTokenLimit, Model: model}
+	if len(contents) == 0 {
+		return tokenCount, nil
+	}
+
+	result, err := g.client.Models.CountTokens(ctx, model, contents, nil)
+	if err != nil {
+		return nil, newGeminiError("failed to count tokens", err)
+	}
+
+	tokenCount.PromptTokens = result.TotalTokens
+	return tokenCount, nil
+}
+
+func (g *geminiClient) inputTokenLimit(ctx context.Context, model string) (int32, error) {
+	if limit, ok := g.inputTokenLimits.Load(model); ok {
+		return limit.(int32), nil
+	}
+
+	result, err := g.client.Models.Get(ctx, model, nil)
+	if err != nil {
+		return 0, newGeminiError("failed to get model", err)
+	}
+
+	g.inputTokenLimits.Store(model, result.InputTokenLimit)
+	return result.InputTokenLimit, nil
+}
+
+// CreateBatchJob submits the requests inline, as the Gemini API doesn't need a file for them. The job runs
+// a single model, so the batches mixing models are rejected.
+func (g *geminiClient) CreateBatchJob(ctx context.Context, requests []domain.GenerationRequest) (string, error) {
+	if len(requests) == 0 {
+		return "", errors.New("batch job has no requests")
+	}
+
+	model := g.modelName(requests[0].Options)
+
+	inlinedRequests := make([]*genai.InlinedRequest, len(requests))
+	for i, request := range requests {
+		if requestModel := g.modelName(request.Options); requestModel != model {
+			return "", fmt.Errorf("batch request %d uses model %s, while the job uses %s", i, requestModel, model)
+		}
+
+		contents, config := g.prepareRequest(
+			request.ChatHistory, request.Tools, request.StructuredOutputSchema, request.Options)
+		inlinedRequests[i] = &genai.InlinedRequest{Model: model, Contents: contents, Config: config}
+	}
+
+	job, err := g.client.Batches.Create(ctx, model, &genai.BatchJobSource{InlinedRequests: inlinedRequests}, nil)
+	if err != nil {
+		return "", newGeminiError("failed to create batch job", err)
+	}
+
+	return job.Name, nil
+}
+
+func (g *geminiClient) GetBatchJobResults(ctx context.Context, id string, requests int) ([]BatchJobResult, error) {
+	job, err := g.client.Batches.Get(ctx, id, nil)
+	if err != nil {
+		return nil, newGeminiError("failed to get batch job", err)
+	}
+
+	var jobErr error
+	switch job.State {
+	case genai.JobStateSucceeded:
+	case genai.JobStateFailed, genai.JobStateCancelled, genai.JobStateExpired:
+		jobErr = fmt.Errorf("batch job %s ended in state %s", id, job.State)
+		if job.Error != nil {
+			jobErr = fmt.Errorf("%w: %s", jobErr, job.Error.Message)
 		}
+	default:
+		return nil, nil
+	}
 
-		contents[i] = &genai.Content{
-			Parts: parts,
-			Role:  string(msg.Role),
+	var inlinedResponses []*genai.InlinedResponse
+	if job.Dest != nil {
+		inlinedResponses = job.Dest.InlinedResponses
+	}
+
+	model := strings.TrimPrefix(job.Model, "models/")
+
+	results := make([]BatchJobResult, requests)
+	for i := range results {
+		switch {
+		case jobErr != nil:
+			results[i].Err = jobErr
+		case i < len(inlinedResponses) && inlinedResponses[i].Error != nil:
+			results[i].Err = fmt.Errorf("batch job %s failed to generate response: %s", id, inlinedResponses[i].Error.Message)
+		case i >= len(inlinedResponses) || inlinedResponses[i].Response == nil:
+			results[i].Err = fmt.Errorf("batch job %s has no response to request %d", id, i)
+		default:
+			result := inlinedResponses[i].Response
+			results[i].Response = &domain.Response{
+				Message: domain.Message{
+					Role:      domain.RoleAssistant,
+					Text:      result.Text(),
+					ToolCalls: genAIResponseToToolCalls(result),
+				},
+				Usage: genAIResponseToUsage(result, model),
+			}
 		}
 	}
 
+	return results, nil
+}
+
+func (g *geminiClient) CancelBatchJob(ctx context.Context, id string) error {
+	if err := g.client.Batches.Cancel(ctx, id, nil); err != nil {
+		return newGeminiError("failed to cancel batch job", err)
+	}
+
+	return nil
+}
+
+// CheckHealth gets the default model, which costs nothing.
+func (g *geminiClient) CheckHealth(ctx context.Context) error {
+	if _, err := g.client.Models.Get(ctx, g.model, nil); err != nil {
+		return newGeminiError("failed to get model", err)
+	}
+
+	return nil
+}
+
+func (g *geminiClient) modelName(options domain.GenerationOptions) string {
+	if options.Model != "" {
+		return options.Model
+	}
+
+	return g.model
+}
+
+// newGeminiError wraps the error returned by the Gemini API into ProviderError, keeping the HTTP status.
+func newGeminiError(message string, err error) error {
+	providerErr := &ProviderError{Provider: ProviderGemini, Err: fmt.Errorf("%s: %w", message, err)}
+
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		providerErr.StatusCode = apiErr.Code
+	}
+
+	return providerErr
+}
+
+func (g *geminiClient) prepareRequest(
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) ([]*genai.Content, *genai.GenerateContentConfig) {
+	contents, systemInstruction := domainMessagesToGenAIContents(chatHistory)
+
+	// Gemini ignores the response schema unless JSON output is requested.
 	var schema *genai.Schema
+	var responseMIMEType string
 	if structuredOutputSchema != nil {
 		schema = domainSchemaToGenAISchema(structuredOutputSchema)
+		responseMIMEType = "application/json"
+	}
+
+	temperature := options.Temperature
+	if temperature == nil {
+		temperature = genai.Ptr[float32](0)
+	}
+
+	var maxOutputTokens int32
+	if options.MaxOutputTokens != nil {
+		maxOutputTokens = *options.MaxOutputTokens
 	}
 
 	config := &genai.GenerateContentConfig{
-		ResponseSchema: schema,
-		Tools:          g.tools,
-		Temperature:    genai.Ptr[float32](0),
+		SystemInstruction: systemInstruction,
+		ResponseSchema:    schema,
+		ResponseMIMEType:  responseMIMEType,
+		Tools:             domainToolsToGenAITools(tools),
+		Temperature:       temperature,
+		TopP:              options.TopP,
+		MaxOutputTokens:   maxOutputTokens,
+		StopSequences:     options.StopSequences,
+		Seed:              options.Seed,
 	}
 
-	result, err := g.client.Models.GenerateContent(ctx, "gemini-2.0-flash", contents, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate content: %v", err)
+	return contents, config
+}
+
+// domainMessagesToGenAIContents converts the chat history into Gemini contents. Gemini only knows
+// user and model roles, so system messages become the system instruction and tool results are sent
+// by the user, merging consecutive results into a single content.
+func domainMessagesToGenAIContents(chatHistory []domain.Message) ([]*genai.Content, *genai.Content) {
+	var contents []*genai.Content
+	var systemInstruction *genai.Content
+
+	for _, msg := range chatHistory {
+		switch msg.Role {
+		case domain.RoleSystem:
+			if systemInstruction == nil {
+				systemInstruction = &genai.Content{}
+			}
+
+			systemInstruction.Parts = append(systemInstruction.Parts, &genai.Part{Text: msg.Text})
+		case domain.RoleTool:
+			if msg.ToolResult == nil {
+				continue
+			}
+
+			part := &genai.Part{
+				FunctionResponse: &genai.FunctionResponse{
+					Name:     msg.ToolResult.Name,
+					Response: msg.ToolResult.Result,
+				},
+			}
+
+			if len(contents) > 0 && isGenAIFunctionResponseContent(contents[len(contents)-1]) {
+				last := contents[len(contents)-1]
+				last.Parts = append(last.Parts, part)
+			} else {
+				contents = append(contents, &genai.Content{Parts: []*genai.Part{part}, Role: genai.RoleUser})
+			}
+		default:
+			var parts []*genai.Part
+			if msg.Text != "" {
+				parts = append(parts, &genai.Part{Text: msg.Text})
+			}
+
+			for _, attachment := range msg.Attachments {
+				parts = append(parts, attachmentToGenAIPart(attachment))
+			}
+
+			for _, toolCall := range msg.ToolCalls {
+				parts = append(parts, &genai.Part{
+					FunctionCall: &genai.FunctionCall{
+						Name: toolCall.Name,
+						Args: toolCall.Parameters,
+					},
+				})
+			}
+
+			role := genai.RoleUser
+			if msg.Role == domain.RoleAssistant {
+				role = genai.RoleModel
+			}
+
+			contents = append(contents, &genai.Content{Parts: parts, Role: role})
+		}
 	}
 
-	message := domain.Message{
-		Role: domain.RoleAssistant,
+	// Gemini requires at least one content, so a prompt consisting only of system messages
+	// is sent as a user message.
+	if len(contents) == 0 && systemInstruction != nil {
+		return []*genai.Content{{Parts: systemInstruction.Parts, Role: genai.RoleUser}}, nil
 	}
 
-	text := result.Text()
-	message.Text = text
+	return contents, systemInstruction
+}
+
+// attachmentToGenAIPart sends the attachment as inline data, or as file data referencing the URI.
+func attachmentToGenAIPart(attachment domain.Attachment) *genai.Part {
+	if len(attachment.Data) > 0 {
+		return &genai.Part{InlineData: &genai.Blob{MIMEType: attachment.MIMEType, Data: attachment.Data}}
+	}
+
+	return &genai.Part{FileData: &genai.FileData{MIMEType: attachment.MIMEType, FileURI: attachment.URI}}
+}
+
+func isGenAIFunctionResponseContent(content *genai.Content) bool {
+	return len(content.Parts) > 0 && content.Parts[0].FunctionResponse != nil
+}
+
+func genAIResponseToToolCalls(result *genai.GenerateContentResponse) []domain.ToolCall {
+	var toolCalls []domain.ToolCall
 
 	for _, candidate := range result.Candidates {
+		if candidate.Content == nil {
+			continue
+		}
+
 		for _, part := range candidate.Content.Parts {
 			if part.FunctionCall != nil {
 				toolCall := domain.ToolCall{
-					ID:         fmt.Sprintf("%v%d", part.FunctionCall.Name, time.Now().UnixNano()),
+					ID:         genAIFunctionCallID(part.FunctionCall),
 					Name:       part.FunctionCall.Name,
 					Parameters: part.FunctionCall.Args,
 				}
-				message.ToolCalls = append(message.ToolCalls, toolCall)
+				toolCalls = append(toolCalls, toolCall)
 			}
 		}
 	}
 
-	return &message, nil
+	return toolCalls
+}
+
+// genAIFunctionCallID returns the ID assigned by Gemini, which isn't always present, or generates one.
+func genAIFunctionCallID(functionCall *genai.FunctionCall) string {
+	if functionCall.ID != "" {
+		return functionCall.ID
+	}
+
+	return fmt.Sprintf("%v%d", functionCall.Name, time.Now().UnixNano())
+}
+
+// genAIResponseToUsage extracts the usage from the response, falling back to the requested
+// model name when the response doesn't report the model version.
+func genAIResponseToUsage(result *genai.GenerateContentResponse, model string) domain.Usage {
+	usage := domain.Usage{Model: model}
+	if result.ModelVersion != "" {
+		usage.Model = result.ModelVersion
+	}
+
+	if result.UsageMetadata != nil {
+		usage.PromptTokens = result.UsageMetadata.PromptTokenCount
+		usage.CompletionTokens = result.UsageMetadata.CandidatesTokenCount
+	}
+
+	return usage
 }
 
 func domainSchemaToGenAISchema(domainSchema *domain.Schema) *genai.Schema {
@@ -106,14 +501,31 @@ func domainSchemaToGenAISchema(domainSchema *domain.Schema) *genai.Schema {
 		items = domainSchemaToGenAISchema(domainSchema.Items)
 	}
 
+	var anyOf []*genai.Schema
+	for _, schema := range domainSchema.AnyOf {
+		anyOf = append(anyOf, domainSchemaToGenAISchema(&schema))
+	}
+
+	var nullable *bool
+	if domainSchema.Nullable {
+		nullable = genai.Ptr(true)
+	}
+
 	return &genai.Schema{
-		Type:        ty,
-		Description: domainSchema.Description,
-		Properties:  properties,
-		Items:       items,
-		MaxItems:    domainSchema.MaxItems,
-		MinItems:    domainSchema.MinItems,
-		Required:    domainSchema.Required,
+		Type:             ty,
+		Description:      domainSchema.Description,
+		Properties:       properties,
+		Items:            items,
+		MaxItems:         domainSchema.MaxItems,
+		MinItems:         domainSchema.MinItems,
+		Required:         domainSchema.Required,
+		Enum:             domainSchema.Enum,
+		Nullable:         nullable,
+		Minimum:          domainSchema.Minimum,
+		Maximum:          domainSchema.Maximum,
+		Format:           domainSchema.Format,
+		PropertyOrdering: domainSchema.PropertyOrdering,
+		AnyOf:            anyOf,
 	}
 }
 
@@ -131,12 +543,15 @@ func toGenAIType(t domain.Type) genai.Type {
 		return genai.TypeArray
 	case domain.TypeObject:
 		return genai.TypeObject
+	case "":
+		// Schemas described by anyOf don't have a type.
+		return genai.TypeUnspecified
 	default:
 		return genai.TypeString
 	}
 }
 
-func (g *geminiClient) useTools(tools []domain.ToolDefinition) {
+func domainToolsToGenAITools(tools []domain.ToolDefinition) []*genai.Tool {
 	genAITools := make([]*genai.Tool, len(tools))
 	for i, toolDef := range tools {
 		genAITools[i] = &genai.Tool{
@@ -150,5 +565,5 @@ func (g *geminiClient) useTools(tools []domain.ToolDefinition) {
 		}
 	}
 
-	g.tools = genAITools
+	return genAITools
 }
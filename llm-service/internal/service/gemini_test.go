@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genai"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+func TestDomainMessagesToGenAIContentsMapsToolCallingTurns(t *testing.T) {
+	contents, systemInstruction := domainMessagesToGenAIContents([]domain.Message{
+		{Role: domain.RoleSystem, Text: "You are an admissions counselor."},
+		{Role: domain.RoleUser, Text: "Which colleges did I add?"},
+		{Role: domain.RoleAssistant, ToolCalls: []domain.ToolCall{
+			{ID: "1", Name: "list_colleges", Parameters: map[string]any{}},
+			{ID: "2", Name: "get_deadlines", Parameters: map[string]any{"year": 2026.0}},
+		}},
+		{Role: domain.RoleTool, ToolResult: &domain.ToolResult{
+			ToolCallID: "1", Name: "list_colleges", Result: map[string]any{"colleges": []any{"MIT"}}}},
+		{Role: domain.RoleTool, ToolResult: &domain.ToolResult{
+			ToolCallID: "2", Name: "get_deadlines", Result: map[string]any{"MIT": "Jan 1"}}},
+	})
+
+	require.NotNil(t, systemInstruction)
+	assert.Equal(t, "You are an admissions counselor.", systemInstruction.Parts[0].Text)
+
+	require.Len(t, contents, 3)
+
+	assert.Equal(t, genai.RoleUser, contents[0].Role)
+	assert.Equal(t, []*genai.Part{{Text: "Which colleges did I add?"}}, contents[0].Parts)
+
+	assert.Equal(t, genai.RoleModel, contents[1].Role)
+	require.Len(t, contents[1].Parts, 2)
+	assert.Equal(t, "list_colleges", contents[1].Parts[0].FunctionCall.Name)
+	assert.Equal(t, "get_deadlines", contents[1].Parts[1].FunctionCall.Name)
+
+	assert.Equal(t, genai.RoleUser, contents[2].Role)
+	require.Len(t, contents[2].Parts, 2)
+	assert.Equal(t, "list_colleges", contents[2].Parts[0].FunctionResponse.Name)
+	assert.Equal(t, map[string]any{"MIT": "Jan 1"}, contents[2].Parts[1].FunctionResponse.Response)
+}
+
+func TestDomainMessagesToGenAIContentsSendsLoneSystemPromptAsUser(t *testing.T) {
+	contents, systemInstruction := domainMessagesToGenAIContents([]domain.Message{
+		{Role: domain.RoleSystem, Text: "Evaluate the application."},
+	})
+
+	assert.Nil(t, systemInstruction)
+	require.Len(t, contents, 1)
+	assert.Equal(t, genai.RoleUser, contents[0].Role)
+	assert.Equal(t, "Evaluate the application.", contents[0].Parts[0].Text)
+}
+
+func TestDomainMessagesToGenAIContentsMapsAttachments(t *testing.T) {
+	contents, _ := domainMessagesToGenAIContents([]domain.Message{
+		{Role: domain.RoleUser, Text: "Review my transcript and essay draft.", Attachments: []domain.Attachment{
+			{MIMEType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}},
+			{MIMEType: "application/pdf", URI: "gs://compendium/essay.pdf"},
+		}},
+	})
+
+	require.Len(t, contents, 1)
+	require.Len(t, contents[0].Parts, 3)
+	assert.Equal(t, "Review my transcript and essay draft.", contents[0].Parts[0].Text)
+	assert.Equal(t, &genai.Blob{MIMEType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}}, contents[0].Parts[1].InlineData)
+	assert.Equal(t, &genai.FileData{MIMEType: "application/pdf", FileURI: "gs://compendium/essay.pdf"},
+		contents[0].Parts[2].FileData)
+}
+
+func TestGeminiCreateBatchJobRejectsMixedModels(t *testing.T) {
+	client := &geminiClient{model: "gemini-2.5-flash"}
+
+	_, err := client.CreateBatchJob(context.Background(), []domain.GenerationRequest{
+		{ChatHistory: []domain.Message{{Role: domain.RoleUser, Text: "Hi"}}},
+		{ChatHistory: []domain.Message{{Role: domain.RoleUser, Text: "Hi"}},
+			Options: domain.GenerationOptions{Model: "gemini-2.5-pro"}},
+	})
+	assert.ErrorContains(t, err, "batch request 1 uses model gemini-2.5-pro")
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+)
+
+// healthCheckTimeout limits every check of a provider, so that a provider which hangs is considered unreachable.
+const healthCheckTimeout = 10 * time.Second
+
+var providerUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "llm_provider_up",
+	Help: "Whether the model provider passed its last health check.",
+}, []string{"provider"})
+
+// HealthChecker is implemented by the providers which can check that they are reachable without generating
+// anything.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// ProviderHealthMonitor periodically checks whether the model providers can be reached.
+type ProviderHealthMonitor interface {
+	// Run checks the providers every interval until the context is cancelled. Report is called with the result
+	// of the first check and then every time it changes: whether any of the providers is reachable.
+	Run(ctx context.Context, report func(available bool))
+}
+
+type providerHealthMonitor struct {
+	checkers map[string]HealthChecker
+	// unchecked is whether any provider can't check its health, in which case it is assumed to be reachable.
+	unchecked bool
+	interval  time.Duration
+}
+
+// NewProviderHealthMonitor monitors the providers by their names. Nil providers are skipped.
+func NewProviderHealthMonitor(providers map[string]LLMService, interval time.Duration) ProviderHealthMonitor {
+	monitor := &providerHealthMonitor{checkers: make(map[string]HealthChecker), interval: interval}
+
+	for name, provider := range providers {
+		if provider == nil {
+			continue
+		}
+
+		if checker, ok := provider.(HealthChecker); ok {
+			monitor.checkers[name] = checker
+		} else {
+			monitor.unchecked = true
+		}
+	}
+
+	return monitor
+}
+
+func (m *providerHealthMonitor) Run(ctx context.Context, report func(available bool)) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	available := m.check(ctx)
+	report(available)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if current := m.check(ctx); current != available {
+			if current {
+				log.L(ctx).Infof("Model providers are reachable again")
+			} else {
+				log.L(ctx).Errorf("None of the model providers is reachable")
+			}
+
+			available = current
+			report(available)
+		}
+	}
+}
+
+// check checks all providers, so that the gauge of every provider stays up to date, and reports whether any
+// of them is reachable.
+func (m *providerHealthMonitor) check(ctx context.Context) bool {
+	available := m.unchecked
+
+	for name, checker := range m.checkers {
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := checker.CheckHealth(checkCtx)
+		cancel()
+
+		if err != nil {
+			log.L(ctx).Warnf("Health check of %s provider failed: %v", name, err)
+			providerUp.WithLabelValues(name).Set(0)
+
+			continue
+		}
+
+		providerUp.WithLabelValues(name).Set(1)
+		available = true
+	}
+
+	return available
+}
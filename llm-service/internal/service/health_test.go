@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// healthCheckingLLMService is reachable until it is told otherwise.
+type healthCheckingLLMService struct {
+	LLMService
+	unreachable atomic.Bool
+}
+
+func (s *healthCheckingLLMService) CheckHealth(context.Context) error {
+	if s.unreachable.Load() {
+		return errors.New("connection refused")
+	}
+
+	return nil
+}
+
+func TestProviderHealthMonitorReportsChanges(t *testing.T) {
+	provider := &healthCheckingLLMService{LLMService: NewFakeClient(nil)}
+	monitor := NewProviderHealthMonitor(map[string]LLMService{ProviderGemini: provider}, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reports := make(chan bool)
+	go monitor.Run(ctx, func(available bool) { reports <- available })
+
+	require.True(t, <-reports)
+
+	provider.unreachable.Store(true)
+	require.False(t, <-reports)
+
+	provider.unreachable.Store(false)
+	require.True(t, <-reports)
+}
+
+func TestProviderHealthMonitorReportsAvailableIfAnyProviderIsReachable(t *testing.T) {
+	provider := &healthCheckingLLMService{LLMService: NewFakeClient(nil)}
+	provider.unreachable.Store(true)
+
+	fallback := &healthCheckingLLMService{LLMService: NewFakeClient(nil)}
+
+	monitor := NewProviderHealthMonitor(map[string]LLMService{
+		ProviderGemini: provider,
+		ProviderOpenAI: fallback,
+	}, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var reports []bool
+	monitor.Run(ctx, func(available bool) { reports = append(reports, available) })
+
+	assert.Equal(t, []bool{true}, reports)
+}
+
+func TestProviderHealthMonitorAssumesProvidersWithoutChecksAreReachable(t *testing.T) {
+	monitor := NewProviderHealthMonitor(map[string]LLMService{ProviderFake: NewFakeClient(nil), "": nil}, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var reports []bool
+	monitor.Run(ctx, func(available bool) { reports = append(reports, available) })
+
+	assert.Equal(t, []bool{true}, reports)
+}
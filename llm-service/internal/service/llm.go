@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"iter"
 
 	"github.com/compendium-tech/compendium/llm-service/internal/domain"
 )
@@ -9,5 +10,12 @@ import (
 type LLMService interface {
 	GenerateResponse(
 		ctx context.Context, chatHistory []domain.Message,
-		tools []domain.ToolDefinition, structuredOutputSchema *domain.Schema) (*domain.Message, error)
+		tools []domain.ToolDefinition, structuredOutputSchema *domain.Schema,
+		options domain.GenerationOptions) (*domain.Response, error)
+	GenerateResponseStream(
+		ctx context.Context, chatHistory []domain.Message,
+		tools []domain.ToolDefinition, structuredOutputSchema *domain.Schema,
+		options domain.GenerationOptions) iter.Seq2[*domain.MessageDelta, error]
+	// Embed computes the embeddings of the texts. Empty model means the default embedding model.
+	Embed(ctx context.Context, texts []string, model string) (*domain.Embeddings, error)
 }
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"path"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// backgroundMethod labels the provider calls which aren't made on behalf of an RPC, such as batch items.
+const backgroundMethod = "background"
+
+var (
+	providerRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llm_provider_requests_total",
+		Help: "Calls to the model providers by RPC, provider and outcome: ok, HTTP status of the provider, " +
+			"unreachable, canceled or error.",
+	}, []string{"method", "provider", "outcome"})
+	providerTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llm_provider_tokens_total",
+		Help: "Tokens spent by the model providers by RPC, provider, model and kind: prompt or completion.",
+	}, []string{"method", "provider", "model", "kind"})
+)
+
+// metricsLLMService counts the calls to the wrapped provider and the tokens they spent, except for embeddings,
+// whose usage the providers don't report. It wraps the providers inside the resilient LLMService, so every retry
+// and fallback is counted separately.
+type metricsLLMService struct {
+	LLMService
+	provider string
+}
+
+func NewMetricsLLMService(provider string, llmService LLMService) LLMService {
+	return &metricsLLMService{LLMService: llmService, provider: provider}
+}
+
+func (s *metricsLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	response, err := s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+	s.recordRequest(ctx, err)
+
+	if err == nil {
+		s.recordTokens(ctx, response.Usage)
+	}
+
+	return response, err
+}
+
+func (s *metricsLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		var streamErr error
+		defer func() { s.recordRequest(ctx, streamErr) }()
+
+		for delta, err := range s.LLMService.GenerateResponseStream(ctx, chatHistory, tools, structuredOutputSchema, options) {
+			if err != nil {
+				streamErr = err
+			} else if delta.Usage != nil {
+				s.recordTokens(ctx, *delta.Usage)
+			}
+
+			if !yield(delta, err) {
+				return
+			}
+		}
+	}
+}
+
+func (s *metricsLLMService) Embed(ctx context.Context, texts []string, model string) (*domain.Embeddings, error) {
+	embeddings, err := s.LLMService.Embed(ctx, texts, model)
+	s.recordRequest(ctx, err)
+
+	return embeddings, err
+}
+
+func (s *metricsLLMService) recordRequest(ctx context.Context, err error) {
+	providerRequests.WithLabelValues(rpcMethod(ctx), s.provider, requestOutcome(err)).Inc()
+}
+
+func (s *metricsLLMService) recordTokens(ctx context.Context, usage domain.Usage) {
+	method := rpcMethod(ctx)
+
+	providerTokens.WithLabelValues(method, s.provider, usage.Model, "prompt").Add(float64(usage.PromptTokens))
+	providerTokens.WithLabelValues(method, s.provider, usage.Model, "completion").Add(float64(usage.CompletionTokens))
+}
+
+// rpcMethod returns the name of the RPC the context belongs to, without the service name.
+func rpcMethod(ctx context.Context) string {
+	if method, ok := grpc.Method(ctx); ok {
+		return path.Base(method)
+	}
+
+	return backgroundMethod
+}
+
+func requestOutcome(err error) string {
+	var providerErr *ProviderError
+
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &providerErr) && providerErr.StatusCode == 0:
+		return "unreachable"
+	case errors.As(err, &providerErr):
+		return strconv.Itoa(providerErr.StatusCode)
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+func TestMetricsLLMServiceCountsRequestsAndTokens(t *testing.T) {
+	const provider = "metrics-test"
+
+	llmService := NewMetricsLLMService(provider, newFailingLLMService(providerError(http.StatusTooManyRequests)))
+
+	_, err := llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+	require.Error(t, err)
+
+	response, err := llmService.GenerateResponse(
+		context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(providerRequests.WithLabelValues(backgroundMethod, provider, "429")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(providerRequests.WithLabelValues(backgroundMethod, provider, "ok")))
+	assert.Equal(t, float64(response.Usage.PromptTokens), testutil.ToFloat64(
+		providerTokens.WithLabelValues(backgroundMethod, provider, response.Usage.Model, "prompt")))
+	assert.Equal(t, float64(response.Usage.CompletionTokens), testutil.ToFloat64(
+		providerTokens.WithLabelValues(backgroundMethod, provider, response.Usage.Model, "completion")))
+}
+
+func TestMetricsLLMServiceCountsStreamedTokens(t *testing.T) {
+	const provider = "metrics-stream-test"
+
+	llmService := NewMetricsLLMService(provider, NewFakeClient(nil))
+
+	var usage *domain.Usage
+	for delta, err := range llmService.GenerateResponseStream(
+		context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{}) {
+		require.NoError(t, err)
+
+		if delta.Usage != nil {
+			usage = delta.Usage
+		}
+	}
+
+	require.NotNil(t, usage)
+	assert.Equal(t, 1.0, testutil.ToFloat64(providerRequests.WithLabelValues(backgroundMethod, provider, "ok")))
+	assert.Equal(t, float64(usage.CompletionTokens), testutil.ToFloat64(
+		providerTokens.WithLabelValues(backgroundMethod, provider, usage.Model, "completion")))
+}
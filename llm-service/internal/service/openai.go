@@ -0,0 +1,935 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/config"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// openAICompatibleClient talks to any server implementing the OpenAI chat completions API,
+// e.g. vLLM, Ollama or llama.cpp server.
+type openAICompatibleClient struct {
+	httpClient     *http.Client
+	baseURL        string
+	apiKey         string
+	model          string
+	embeddingModel string
+}
+
+func NewOpenAICompatibleClient(_ context.Context, cfg *config.AppConfig) (LLMService, error) {
+	if cfg.OpenAIBaseURL == "" {
+		return nil, fmt.Errorf("OpenAI-compatible provider requires a base URL")
+	}
+
+	if cfg.OpenAIModel == "" {
+		return nil, fmt.Errorf("OpenAI-compatible provider requires a model name")
+	}
+
+	return &openAICompatibleClient{
+		httpClient:     http.DefaultClient,
+		baseURL:        strings.TrimSuffix(cfg.OpenAIBaseURL, "/"),
+		apiKey:         cfg.OpenAIApiKey,
+		model:          cfg.OpenAIModel,
+		embeddingModel: cfg.OpenAIEmbeddingModel,
+	}, nil
+}
+
+type openAIChatCompletionRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	Tools          []openAITool          `json:"tools,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+	Temperature    float32               `json:"temperature"`
+	TopP           *float32              `json:"top_p,omitempty"`
+	MaxTokens      *int32                `json:"max_tokens,omitempty"`
+	Stop           []string              `json:"stop,omitempty"`
+	Seed           *int32                `json:"seed,omitempty"`
+	Stream         bool                  `json:"stream,omitempty"`
+	StreamOptions  *openAIStreamOptions  `json:"stream_options,omitempty"`
+}
+
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    openAIContent    `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+// openAIContent is the content of a message, sent as a string unless the message has attachments,
+// which require an array of content parts. Responses always have string or null content.
+type openAIContent struct {
+	Text  string
+	Parts []openAIContentPart
+}
+
+func (c openAIContent) MarshalJSON() ([]byte, error) {
+	if c.Parts != nil {
+		return json.Marshal(c.Parts)
+	}
+
+	return json.Marshal(c.Text)
+}
+
+func (c *openAIContent) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(data, &c.Text)
+}
+
+type openAIContentPart struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	ImageURL *openAIImageURL `json:"image_url,omitempty"`
+	File     *openAIFile     `json:"file,omitempty"`
+}
+
+type openAIImageURL struct {
+	URL string `json:"url"`
+}
+
+// openAIFile is a document sent inline as a data URL or referenced by the ID of the uploaded file.
+type openAIFile struct {
+	Filename string `json:"filename,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	FileID   string `json:"file_id,omitempty"`
+}
+
+type openAIToolCall struct {
+	// Index is only set in the streamed deltas, where it tells which tool call a fragment belongs to.
+	Index    *int               `json:"index,omitempty"`
+	ID       string             `json:"id,omitempty"`
+	Type     string             `json:"type,omitempty"`
+	Function openAIFunctionCall `json:"function"`
+}
+
+type openAIFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+type openAITool struct {
+	Type     string             `json:"type"`
+	Function openAIFunctionDecl `json:"function"`
+}
+
+type openAIFunctionDecl struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string           `json:"type"`
+	JSONSchema openAIJSONSchema `json:"json_schema"`
+}
+
+type openAIJSONSchema struct {
+	Name   string         `json:"name"`
+	Schema map[string]any `json:"schema"`
+}
+
+type openAIChatCompletionResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		Delta        openAIMessage `json:"delta"`
+		FinishReason *string       `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *openAIUsage `json:"usage"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int32 `json:"prompt_tokens"`
+	CompletionTokens int32 `json:"completion_tokens"`
+}
+
+func (o *openAICompatibleClient) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	request, err := o.buildRequest(chatHistory, tools, structuredOutputSchema, options, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.sendChatCompletionRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var completion openAIChatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, fmt.Errorf("failed to decode chat completion response: %v", err)
+	}
+
+	return completion.toResponse(request.Model)
+}
+
+func (o *openAICompatibleClient) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		request, err := o.buildRequest(chatHistory, tools, structuredOutputSchema, options, true)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		resp, err := o.sendChatCompletionRequest(ctx, request)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		defer resp.Body.Close()
+
+		// Tool call arguments are streamed in fragments, so they are accumulated by index
+		// and sent once the model finishes the message, or once the stream ends if the provider
+		// doesn't report the finish reason.
+		var pendingToolCalls []openAIToolCall
+
+		// Usage is reported in the last chunk, which has no choices.
+		usage := domain.Usage{Model: request.Model}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+
+			data = strings.TrimSpace(data)
+			if data == "[DONE]" {
+				break
+			}
+
+			var chunk openAIChatCompletionResponse
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				yield(nil, fmt.Errorf("failed to decode chat completion chunk: %v", err))
+				return
+			}
+
+			if chunk.Usage != nil {
+				usage = chunk.toUsage(request.Model)
+			}
+
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+
+			choice := chunk.Choices[0]
+			for _, toolCall := range choice.Delta.ToolCalls {
+				index := 0
+				if toolCall.Index != nil {
+					index = *toolCall.Index
+				}
+
+				if index < 0 {
+					yield(nil, fmt.Errorf("chat completion chunk contains unexpected tool call index %d", index))
+					return
+				}
+
+				for len(pendingToolCalls) <= index {
+					pendingToolCalls = append(pendingToolCalls, openAIToolCall{Type: "function"})
+				}
+
+				pending := &pendingToolCalls[index]
+				if toolCall.ID != "" {
+					pending.ID = toolCall.ID
+				}
+
+				pending.Function.Name += toolCall.Function.Name
+				pending.Function.Arguments += toolCall.Function.Arguments
+			}
+
+			delta := &domain.MessageDelta{Text: choice.Delta.Content.Text}
+
+			if choice.FinishReason != nil && len(pendingToolCalls) > 0 {
+				delta.ToolCalls, err = openAIToolCallsToToolCalls(pendingToolCalls)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+
+				pendingToolCalls = nil
+			}
+
+			if delta.Text == "" && len(delta.ToolCalls) == 0 {
+				continue
+			}
+
+			if !yield(delta, nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(nil, &ProviderError{Provider: ProviderOpenAI, Err: fmt.Errorf("failed to read chat completion stream: %w", err)})
+			return
+		}
+
+		if len(pendingToolCalls) > 0 {
+			toolCalls, err := openAIToolCallsToToolCalls(pendingToolCalls)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(&domain.MessageDelta{ToolCalls: toolCalls}, nil) {
+				return
+			}
+		}
+
+		yield(&domain.MessageDelta{Usage: &usage}, nil)
+	}
+}
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Model string `json:"model"`
+	Data  []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (o *openAICompatibleClient) Embed(ctx context.Context, texts []string, model string) (*domain.Embeddings, error) {
+	if model == "" {
+		model = o.embeddingModel
+	}
+
+	if model == "" {
+		return nil, fmt.Errorf("OpenAI-compatible provider requires an embedding model name")
+	}
+
+	resp, err := o.sendRequest(ctx, "/embeddings", openAIEmbeddingRequest{Model: model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var embeddingResponse openAIEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embeddingResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode embedding response: %v", err)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, data := range embeddingResponse.Data {
+		if data.Index < 0 || data.Index >= len(vectors) {
+			return nil, fmt.Errorf("embedding response contains unexpected index %d", data.Index)
+		}
+
+		vectors[data.Index] = data.Embedding
+	}
+
+	if embeddingResponse.Model != "" {
+		model = embeddingResponse.Model
+	}
+
+	return &domain.Embeddings{Vectors: vectors, Model: model}, nil
+}
+
+// openAIInputTokenLimits are the context windows of the known models by model name prefix. More specific
+// prefixes go first.
+var openAIInputTokenLimits = []struct {
+	prefix string
+	limit  int32
+}{
+	{"gpt-4.1", 1_047_576},
+	{"gpt-4o", 128_000},
+	{"gpt-4-turbo", 128_000},
+	{"gpt-3.5-turbo", 16_385},
+	{"o1", 200_000},
+	{"o3", 200_000},
+	{"o4", 200_000},
+}
+
+// defaultOpenAIInputTokenLimit is the context window assumed for the models served by other OpenAI-compatible
+// servers. It is small, so that the prompts fit into the models with the shortest windows.
+const defaultOpenAIInputTokenLimit = 8192
+
+// CountTokens estimates the tokens, as the OpenAI API doesn't expose the tokenizers of the models.
+func (o *openAICompatibleClient) CountTokens(
+	_ context.Context, chatHistory []domain.Message, model string) (*domain.TokenCount, error) {
+	if model == "" {
+		model = o.model
+	}
+
+	inputTokenLimit := int32(defaultOpenAIInputTokenLimit)
+	for _, known := range openAIInputTokenLimits {
+		if strings.HasPrefix(model, known.prefix) {
+			inputTokenLimit = known.limit
+			break
+		}
+	}
+
+	return &domain.TokenCount{
+		PromptTokens:    estimateChatHistoryTokens(chatHistory),
+		InputTokenLimit: inputTokenLimit,
+		Model:           model,
+	}, nil
+}
+
+type openAIBatchRequestLine struct {
+	CustomID string                      `json:"custom_id"`
+	Method   string                      `json:"method"`
+	URL      string                      `json:"url"`
+	Body     openAIChatCompletionRequest `json:"body"`
+}
+
+type openAIBatchResponseLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int                          `json:"status_code"`
+		Body       openAIChatCompletionResponse `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type openAICreateBatchRequest struct {
+	InputFileID      string `json:"input_file_id"`
+	Endpoint         string `json:"endpoint"`
+	CompletionWindow string `json:"completion_window"`
+}
+
+// openAIBatch is a batch job, whose results are written to the output file, and the errors of the requests
+// which failed to the error file.
+type openAIBatch struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	OutputFileID string `json:"output_file_id"`
+	ErrorFileID  string `json:"error_file_id"`
+}
+
+// CreateBatchJob uploads the requests as a JSONL file and creates a batch job processing it. Only the OpenAI API
+// supports batches, so creating the job fails on other OpenAI-compatible servers.
+func (o *openAICompatibleClient) CreateBatchJob(ctx context.Context, requests []domain.GenerationRequest) (string, error) {
+	var input bytes.Buffer
+
+	encoder := json.NewEncoder(&input)
+	for i, request := range requests {
+		body, err := o.buildRequest(request.ChatHistory, request.Tools, request.StructuredOutputSchema, request.Options, false)
+		if err != nil {
+			return "", err
+		}
+
+		line := openAIBatchRequestLine{CustomID: strconv.Itoa(i), Method: http.MethodPost, URL: "/v1/chat/completions", Body: body}
+		if err := encoder.Encode(line); err != nil {
+			return "", fmt.Errorf("failed to encode batch request %d: %v", i, err)
+		}
+	}
+
+	fileID, err := o.uploadBatchFile(ctx, input.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := o.sendRequest(ctx, "/batches", openAICreateBatchRequest{
+		InputFileID:      fileID,
+		Endpoint:         "/v1/chat/completions",
+		CompletionWindow: "24h",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var batch openAIBatch
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return "", fmt.Errorf("failed to decode batch: %v", err)
+	}
+
+	return batch.ID, nil
+}
+
+func (o *openAICompatibleClient) uploadBatchFile(ctx context.Context, content []byte) (string, error) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("purpose", "batch"); err != nil {
+		return "", fmt.Errorf("failed to write batch file: %v", err)
+	}
+
+	part, err := writer.CreateFormFile("file", "batch.jsonl")
+	if err != nil {
+		return "", fmt.Errorf("failed to write batch file: %v", err)
+	}
+
+	if _, err := part.Write(content); err != nil {
+		return "", fmt.Errorf("failed to write batch file: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to write batch file: %v", err)
+	}
+
+	resp, err := o.send(ctx, http.MethodPost, "/files", writer.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var file struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return "", fmt.Errorf("failed to decode uploaded file: %v", err)
+	}
+
+	return file.ID, nil
+}
+
+func (o *openAICompatibleClient) GetBatchJobResults(ctx context.Context, id string, requests int) ([]BatchJobResult, error) {
+	resp, err := o.send(ctx, http.MethodGet, "/batches/"+id, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var batch openAIBatch
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, fmt.Errorf("failed to decode batch: %v", err)
+	}
+
+	results := make([]BatchJobResult, requests)
+	for i := range results {
+		results[i].Err = fmt.Errorf("batch job %s ended in status %s without response to request %d", id, batch.Status, i)
+	}
+
+	// Expired and cancelled batches have the results of the requests completed in time.
+	switch batch.Status {
+	case "completed", "expired", "cancelled":
+	case "failed":
+		return results, nil
+	default:
+		return nil, nil
+	}
+
+	for _, fileID := range []string{batch.OutputFileID, batch.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+
+		if err := o.readBatchResults(ctx, fileID, results); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// readBatchResults stores the results from the output or the error file of the batch by the indexes
+// of the requests.
+func (o *openAICompatibleClient) readBatchResults(ctx context.Context, fileID string, results []BatchJobResult) error {
+	resp, err := o.send(ctx, http.MethodGet, "/files/"+fileID+"/content", "", nil)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var line openAIBatchResponseLine
+		if err := decoder.Decode(&line); err != nil {
+			return fmt.Errorf("failed to decode batch results: %v", err)
+		}
+
+		index, err := strconv.Atoi(line.CustomID)
+		if err != nil || index < 0 || index >= len(results) {
+			return fmt.Errorf("batch results contain unexpected request %q", line.CustomID)
+		}
+
+		switch {
+		case line.Error != nil:
+			results[index].Err = fmt.Errorf("%s: %s", line.Error.Code, line.Error.Message)
+		case line.Response == nil:
+			continue
+		case line.Response.StatusCode != http.StatusOK:
+			results[index].Err = fmt.Errorf("request failed with status %d", line.Response.StatusCode)
+		default:
+			results[index].Response, results[index].Err = line.Response.Body.toResponse("")
+		}
+	}
+
+	return nil
+}
+
+func (o *openAICompatibleClient) CancelBatchJob(ctx context.Context, id string) error {
+	resp, err := o.send(ctx, http.MethodPost, "/batches/"+id+"/cancel", "", nil)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// CheckHealth lists the models, which costs nothing and is supported by the OpenAI-compatible servers.
+func (o *openAICompatibleClient) CheckHealth(ctx context.Context) error {
+	resp, err := o.send(ctx, http.MethodGet, "/models", "", nil)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (o *openAICompatibleClient) buildRequest(
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+	stream bool,
+) (openAIChatCompletionRequest, error) {
+	messages := make([]openAIMessage, len(chatHistory))
+	for i, msg := range chatHistory {
+		toolCalls := make([]openAIToolCall, len(msg.ToolCalls))
+		for j, toolCall := range msg.ToolCalls {
+			arguments, _ := json.Marshal(toolCall.Parameters)
+
+			toolCalls[j] = openAIToolCall{
+				ID:   toolCall.ID,
+				Type: "function",
+				Function: openAIFunctionCall{
+					Name:      toolCall.Name,
+					Arguments: string(arguments),
+				},
+			}
+		}
+
+		content, err := messageToOpenAIContent(msg)
+		if err != nil {
+			return openAIChatCompletionRequest{}, err
+		}
+
+		messages[i] = openAIMessage{
+			Role:      string(msg.Role),
+			Content:   content,
+			ToolCalls: toolCalls,
+		}
+
+		// Tool results are sent as JSON in the content of the tool message.
+		if msg.ToolResult != nil {
+			result, _ := json.Marshal(msg.ToolResult.Result)
+
+			messages[i].Content = openAIContent{Text: string(result)}
+			messages[i].ToolCallID = msg.ToolResult.ToolCallID
+		}
+	}
+
+	openAITools := make([]openAITool, len(tools))
+	for i, tool := range tools {
+		openAITools[i] = openAITool{
+			Type: "function",
+			Function: openAIFunctionDecl{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  domainSchemaToJSONSchema(tool.ParametersSchema),
+			},
+		}
+	}
+
+	var responseFormat *openAIResponseFormat
+	if structuredOutputSchema != nil {
+		responseFormat = &openAIResponseFormat{
+			Type: "json_schema",
+			JSONSchema: openAIJSONSchema{
+				Name:   "response",
+				Schema: domainSchemaToJSONSchema(structuredOutputSchema),
+			},
+		}
+	}
+
+	model := o.model
+	if options.Model != "" {
+		model = options.Model
+	}
+
+	var temperature float32
+	if options.Temperature != nil {
+		temperature = *options.Temperature
+	}
+
+	var streamOptions *openAIStreamOptions
+	if stream {
+		streamOptions = &openAIStreamOptions{IncludeUsage: true}
+	}
+
+	return openAIChatCompletionRequest{
+		Model:          model,
+		Messages:       messages,
+		Tools:          openAITools,
+		ResponseFormat: responseFormat,
+		Temperature:    temperature,
+		TopP:           options.TopP,
+		MaxTokens:      options.MaxOutputTokens,
+		Stop:           options.StopSequences,
+		Seed:           options.Seed,
+		Stream:         stream,
+		StreamOptions:  streamOptions,
+	}, nil
+}
+
+// messageToOpenAIContent sends images as image URLs and PDF documents as files, either inline as data URLs
+// or by the URL of the image and the ID of the uploaded file.
+func messageToOpenAIContent(msg domain.Message) (openAIContent, error) {
+	if len(msg.Attachments) == 0 {
+		return openAIContent{Text: msg.Text}, nil
+	}
+
+	var parts []openAIContentPart
+	if msg.Text != "" {
+		parts = append(parts, openAIContentPart{Type: "text", Text: msg.Text})
+	}
+
+	for i, attachment := range msg.Attachments {
+		dataURL := ""
+		if len(attachment.Data) > 0 {
+			dataURL = fmt.Sprintf("data:%s;base64,%s", attachment.MIMEType, base64.StdEncoding.EncodeToString(attachment.Data))
+		}
+
+		switch {
+		case strings.HasPrefix(attachment.MIMEType, "image/"):
+			url := attachment.URI
+			if dataURL != "" {
+				url = dataURL
+			}
+
+			parts = append(parts, openAIContentPart{Type: "image_url", ImageURL: &openAIImageURL{URL: url}})
+		case attachment.MIMEType == "application/pdf":
+			file := &openAIFile{FileID: attachment.URI}
+			if dataURL != "" {
+				file = &openAIFile{Filename: fmt.Sprintf("attachment-%d.pdf", i+1), FileData: dataURL}
+			}
+
+			parts = append(parts, openAIContentPart{Type: "file", File: file})
+		default:
+			return openAIContent{}, &UnsupportedAttachmentError{Provider: ProviderOpenAI, MIMEType: attachment.MIMEType}
+		}
+	}
+
+	return openAIContent{Parts: parts}, nil
+}
+
+func (o *openAICompatibleClient) sendChatCompletionRequest(
+	ctx context.Context, request openAIChatCompletionRequest) (*http.Response, error) {
+	return o.sendRequest(ctx, "/chat/completions", request)
+}
+
+// sendRequest posts the JSON encoded request to the endpoint, returning the response only if it succeeded.
+func (o *openAICompatibleClient) sendRequest(ctx context.Context, endpoint string, request any) (*http.Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s request: %v", endpoint, err)
+	}
+
+	return o.send(ctx, http.MethodPost, endpoint, "application/json", bytes.NewReader(body))
+}
+
+// send sends the body of the content type to the endpoint, returning the response only if it succeeded.
+func (o *openAICompatibleClient) send(
+	ctx context.Context, method string, endpoint string, contentType string, body io.Reader) (*http.Response, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, method, o.baseURL+endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %v", endpoint, err)
+	}
+
+	if contentType != "" {
+		httpRequest.Header.Set("Content-Type", contentType)
+	}
+
+	if o.apiKey != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, &ProviderError{Provider: ProviderOpenAI, Err: fmt.Errorf("failed to send %s request: %w", endpoint, err)}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, &ProviderError{
+			Provider:   ProviderOpenAI,
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("%s request failed with status %d: %s", endpoint, resp.StatusCode, respBody),
+		}
+	}
+
+	return resp, nil
+}
+
+func (r openAIChatCompletionResponse) toResponse(model string) (*domain.Response, error) {
+	if len(r.Choices) == 0 {
+		return nil, fmt.Errorf("chat completion response contains no choices")
+	}
+
+	toolCalls, err := openAIToolCallsToToolCalls(r.Choices[0].Message.ToolCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Response{
+		Message: domain.Message{
+			Role:      domain.RoleAssistant,
+			Text:      r.Choices[0].Message.Content.Text,
+			ToolCalls: toolCalls,
+		},
+		Usage: r.toUsage(model),
+	}, nil
+}
+
+// toUsage converts the reported usage, falling back to the requested model name
+// when the server doesn't report the model.
+func (r openAIChatCompletionResponse) toUsage(model string) domain.Usage {
+	usage := domain.Usage{Model: model}
+	if r.Model != "" {
+		usage.Model = r.Model
+	}
+
+	if r.Usage != nil {
+		usage.PromptTokens = r.Usage.PromptTokens
+		usage.CompletionTokens = r.Usage.CompletionTokens
+	}
+
+	return usage
+}
+
+func openAIToolCallsToToolCalls(openAIToolCalls []openAIToolCall) ([]domain.ToolCall, error) {
+	var toolCalls []domain.ToolCall
+
+	for _, toolCall := range openAIToolCalls {
+		parameters := make(map[string]any)
+		if toolCall.Function.Arguments != "" {
+			if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &parameters); err != nil {
+				return nil, fmt.Errorf("failed to decode arguments of tool call %s: %v", toolCall.Function.Name, err)
+			}
+		}
+
+		toolCalls = append(toolCalls, domain.ToolCall{
+			ID:         toolCall.ID,
+			Name:       toolCall.Function.Name,
+			Parameters: parameters,
+		})
+	}
+
+	return toolCalls, nil
+}
+
+// domainSchemaToJSONSchema converts domain.Schema into a JSON Schema document.
+func domainSchemaToJSONSchema(domainSchema *domain.Schema) map[string]any {
+	if domainSchema == nil {
+		return nil
+	}
+
+	jsonSchema := make(map[string]any)
+
+	// JSON Schema has no nullable keyword, null is one of the types instead.
+	if domainSchema.Type != "" {
+		ty := strings.ToLower(string(domainSchema.Type))
+		if domainSchema.Nullable {
+			jsonSchema["type"] = []string{ty, "null"}
+		} else {
+			jsonSchema["type"] = ty
+		}
+	}
+
+	if domainSchema.Description != "" {
+		jsonSchema["description"] = domainSchema.Description
+	}
+
+	if len(domainSchema.Properties) > 0 {
+		properties := make(map[string]any)
+		for name, prop := range domainSchema.Properties {
+			properties[name] = domainSchemaToJSONSchema(&prop)
+		}
+
+		jsonSchema["properties"] = properties
+	}
+
+	if domainSchema.Items != nil {
+		jsonSchema["items"] = domainSchemaToJSONSchema(domainSchema.Items)
+	}
+
+	if domainSchema.MaxItems != nil {
+		jsonSchema["maxItems"] = *domainSchema.MaxItems
+	}
+
+	if domainSchema.MinItems != nil {
+		jsonSchema["minItems"] = *domainSchema.MinItems
+	}
+
+	if len(domainSchema.Required) > 0 {
+		jsonSchema["required"] = domainSchema.Required
+	}
+
+	if len(domainSchema.Enum) > 0 {
+		jsonSchema["enum"] = domainSchema.Enum
+	}
+
+	if domainSchema.Minimum != nil {
+		jsonSchema["minimum"] = *domainSchema.Minimum
+	}
+
+	if domainSchema.Maximum != nil {
+		jsonSchema["maximum"] = *domainSchema.Maximum
+	}
+
+	if domainSchema.Format != "" {
+		jsonSchema["format"] = domainSchema.Format
+	}
+
+	if len(domainSchema.AnyOf) > 0 {
+		anyOf := make([]any, len(domainSchema.AnyOf))
+		for i, schema := range domainSchema.AnyOf {
+			anyOf[i] = domainSchemaToJSONSchema(&schema)
+		}
+
+		jsonSchema["anyOf"] = anyOf
+	}
+
+	return jsonSchema
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+func TestMessageToOpenAIContentSendsTextOnlyMessageAsString(t *testing.T) {
+	content, err := messageToOpenAIContent(domain.Message{Role: domain.RoleUser, Text: "Hello"})
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(content)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"Hello"`, string(encoded))
+}
+
+func TestMessageToOpenAIContentMapsAttachments(t *testing.T) {
+	content, err := messageToOpenAIContent(domain.Message{Role: domain.RoleUser, Text: "Review my documents.",
+		Attachments: []domain.Attachment{
+			{MIMEType: "image/png", Data: []byte("png")},
+			{MIMEType: "image/jpeg", URI: "https://example.com/transcript.jpg"},
+			{MIMEType: "application/pdf", Data: []byte("pdf")},
+			{MIMEType: "application/pdf", URI: "file-abc123"},
+		}})
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(content)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"type": "text", "text": "Review my documents."},
+		{"type": "image_url", "image_url": {"url": "data:image/png;base64,cG5n"}},
+		{"type": "image_url", "image_url": {"url": "https://example.com/transcript.jpg"}},
+		{"type": "file", "file": {"filename": "attachment-3.pdf", "file_data": "data:application/pdf;base64,cGRm"}},
+		{"type": "file", "file": {"file_id": "file-abc123"}}
+	]`, string(encoded))
+}
+
+func TestMessageToOpenAIContentRejectsUnsupportedAttachment(t *testing.T) {
+	_, err := messageToOpenAIContent(domain.Message{Role: domain.RoleUser,
+		Attachments: []domain.Attachment{{MIMEType: "audio/mpeg", Data: []byte("mp3")}}})
+
+	var unsupportedErr *UnsupportedAttachmentError
+	require.True(t, errors.As(err, &unsupportedErr))
+	assert.Equal(t, "audio/mpeg", unsupportedErr.MIMEType)
+}
+
+func TestOpenAIContentUnmarshalsStringAndNull(t *testing.T) {
+	var msg openAIMessage
+	require.NoError(t, json.Unmarshal([]byte(`{"role": "assistant", "content": "Hi"}`), &msg))
+	assert.Equal(t, "Hi", msg.Content.Text)
+
+	msg = openAIMessage{}
+	require.NoError(t, json.Unmarshal([]byte(`{"role": "assistant", "content": null}`), &msg))
+	assert.Empty(t, msg.Content.Text)
+}
+
+func TestOpenAIBuildRequestOmitsToolCallIndexes(t *testing.T) {
+	client := &openAICompatibleClient{model: "gpt-4o"}
+
+	request, err := client.buildRequest([]domain.Message{{
+		Role:      domain.RoleAssistant,
+		ToolCalls: []domain.ToolCall{{ID: "call_1", Name: "search"}, {ID: "call_2", Name: "search"}},
+	}}, nil, nil, domain.GenerationOptions{}, false)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"id":"call_2"`)
+	assert.NotContains(t, string(body), `"index"`)
+}
+
+func TestOpenAIGenerateResponseStreamSendsToolCallsWithoutFinishReason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `data: {"choices": [{"delta": {"tool_calls": [{"index": 0, "id": "call_1",`+
+			`"function": {"name": "search", "arguments": "{\"query\":"}}]}}]}`)
+		fmt.Fprintln(w, `data: {"choices": [{"delta": {"tool_calls": [{"index": 0,`+
+			`"function": {"arguments": "\"MIT\"}"}}]}}]}`)
+		fmt.Fprintln(w, `data: [DONE]`)
+	}))
+	defer server.Close()
+
+	client := &openAICompatibleClient{httpClient: server.Client(), baseURL: server.URL, model: "gpt-4o"}
+
+	var toolCalls []domain.ToolCall
+	for delta, err := range client.GenerateResponseStream(
+		context.Background(), []domain.Message{{Role: domain.RoleUser, Text: "Hi"}}, nil, nil,
+		domain.GenerationOptions{}) {
+		require.NoError(t, err)
+		toolCalls = append(toolCalls, delta.ToolCalls...)
+	}
+
+	assert.Equal(t, []domain.ToolCall{
+		{ID: "call_1", Name: "search", Parameters: map[string]any{"query": "MIT"}},
+	}, toolCalls)
+}
+
+func TestOpenAIGetBatchJobResultsReadsOutputAndErrorFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/batches/batch_1":
+			fmt.Fprint(w, `{"id": "batch_1", "status": "completed", "output_file_id": "file_out", "error_file_id": "file_err"}`)
+		case "/files/file_out/content":
+			fmt.Fprintln(w, `{"custom_id": "1", "response": {"status_code": 200, "body": {"model": "gpt-4o",`+
+				`"choices": [{"message": {"role": "assistant", "content": "Hi"}}], "usage": {"prompt_tokens": 3}}}}`)
+			fmt.Fprintln(w, `{"custom_id": "0", "response": {"status_code": 400, "body": {}}}`)
+		case "/files/file_err/content":
+			fmt.Fprintln(w, `{"custom_id": "2", "error": {"code": "batch_expired", "message": "expired"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &openAICompatibleClient{httpClient: server.Client(), baseURL: server.URL, model: "gpt-4o"}
+
+	results, err := client.GetBatchJobResults(context.Background(), "batch_1", 4)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Error(t, results[0].Err)
+	require.NoError(t, results[1].Err)
+	assert.Equal(t, "Hi", results[1].Response.Message.Text)
+	assert.Equal(t, int32(3), results[1].Response.Usage.PromptTokens)
+	assert.ErrorContains(t, results[2].Err, "batch_expired")
+	assert.Error(t, results[3].Err)
+}
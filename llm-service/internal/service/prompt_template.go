@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+// PromptTemplateNotFoundError is returned when the requested version of the template doesn't exist.
+type PromptTemplateNotFoundError struct {
+	Name    string
+	Version int32
+}
+
+func (e *PromptTemplateNotFoundError) Error() string {
+	if e.Version == 0 {
+		return fmt.Sprintf("prompt template %s doesn't exist", e.Name)
+	}
+
+	return fmt.Sprintf("prompt template %s doesn't have version %d", e.Name, e.Version)
+}
+
+// InvalidPromptTemplateError is returned when the template can't be parsed or rendered with the given variables.
+type InvalidPromptTemplateError struct {
+	Err error
+}
+
+func (e *InvalidPromptTemplateError) Error() string {
+	return fmt.Sprintf("invalid prompt template: %v", e.Err)
+}
+
+func (e *InvalidPromptTemplateError) Unwrap() error {
+	return e.Err
+}
+
+// PromptTemplateService keeps the versioned prompt templates, so that prompts can be changed and rolled back
+// without redeploying the callers. Templates use the syntax of Go text/template.
+type PromptTemplateService interface {
+	// RenderPromptTemplate renders the version of the template, or its latest version if version is zero.
+	RenderPromptTemplate(
+		ctx context.Context, name string, version int32, variables map[string]any) (*domain.RenderedPrompt, error)
+	// CreatePromptTemplate validates the body and stores it as the next version of the template.
+	CreatePromptTemplate(ctx context.Context, name string, body string) (int32, error)
+}
+
+type promptTemplateService struct {
+	promptTemplateRepository repository.PromptTemplateRepository
+
+	// Versions are immutable, so the parsed templates are cached forever.
+	mu     sync.Mutex
+	parsed map[string]*template.Template
+}
+
+func NewPromptTemplateService(promptTemplateRepository repository.PromptTemplateRepository) PromptTemplateService {
+	return &promptTemplateService{
+		promptTemplateRepository: promptTemplateRepository,
+		parsed:                   make(map[string]*template.Template),
+	}
+}
+
+var promptTemplateFuncs = template.FuncMap{
+	// inc turns zero-based indexes of range into list numbers.
+	"inc": func(i int) int { return i + 1 },
+	"join": func(values []any, separator string) string {
+		strs := make([]string, len(values))
+		for i, value := range values {
+			strs[i] = fmt.Sprint(value)
+		}
+
+		return strings.Join(strs, separator)
+	},
+}
+
+func (s *promptTemplateService) RenderPromptTemplate(
+	ctx context.Context, name string, version int32, variables map[string]any) (*domain.RenderedPrompt, error) {
+	promptTemplate, err := s.promptTemplateRepository.GetPromptTemplate(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if promptTemplate == nil {
+		return nil, &PromptTemplateNotFoundError{Name: name, Version: version}
+	}
+
+	key := fmt.Sprintf("%s@%d", promptTemplate.Name, promptTemplate.Version)
+
+	s.mu.Lock()
+	parsed, ok := s.parsed[key]
+	s.mu.Unlock()
+
+	if !ok {
+		parsed, err = parsePromptTemplate(key, promptTemplate.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		s.parsed[key] = parsed
+		s.mu.Unlock()
+	}
+
+	var text strings.Builder
+	if err := parsed.Execute(&text, variables); err != nil {
+		return nil, &InvalidPromptTemplateError{Err: err}
+	}
+
+	return &domain.RenderedPrompt{
+		Text:    text.String(),
+		Name:    promptTemplate.Name,
+		Version: promptTemplate.Version,
+	}, nil
+}
+
+func (s *promptTemplateService) CreatePromptTemplate(ctx context.Context, name string, body string) (int32, error) {
+	if _, err := parsePromptTemplate(name, body); err != nil {
+		return 0, err
+	}
+
+	promptTemplate, err := s.promptTemplateRepository.CreatePromptTemplate(ctx, name, body)
+	if err != nil {
+		return 0, err
+	}
+
+	return promptTemplate.Version, nil
+}
+
+// parsePromptTemplate makes missing variables fail rendering instead of producing "<no value>" in the prompt.
+func parsePromptTemplate(name string, body string) (*template.Template, error) {
+	parsed, err := template.New(name).Funcs(promptTemplateFuncs).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, &InvalidPromptTemplateError{Err: err}
+	}
+
+	return parsed, nil
+}
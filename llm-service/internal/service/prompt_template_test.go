@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+const testPromptTemplateBody = `Evaluate the application of {{.name}}.
+{{range $i, $activity := .activities}}{{inc $i}}. {{$activity.name}} (grades {{join $activity.grades ", "}})
+{{end}}`
+
+func TestPromptTemplateServiceRendersTemplate(t *testing.T) {
+	promptTemplateRepository := repository.NewMockPromptTemplateRepository(t)
+	promptTemplateRepository.EXPECT().GetPromptTemplate(mock.Anything, "evaluation", int32(0)).
+		Return(&model.PromptTemplate{Name: "evaluation", Version: 3, Body: testPromptTemplateBody}, nil).Twice()
+
+	promptTemplateService := NewPromptTemplateService(promptTemplateRepository)
+
+	variables := map[string]any{
+		"name": "Alice",
+		"activities": []any{
+			map[string]any{"name": "Chess club", "grades": []any{9, 10}},
+			map[string]any{"name": "Debate", "grades": []any{11}},
+		},
+	}
+
+	for range 2 {
+		rendered, err := promptTemplateService.RenderPromptTemplate(context.Background(), "evaluation", 0, variables)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Evaluate the application of Alice.\n1. Chess club (grades 9, 10)\n2. Debate (grades 11)\n",
+			rendered.Text)
+		assert.Equal(t, int32(3), rendered.Version)
+	}
+}
+
+func TestPromptTemplateServiceRejectsMissingVariables(t *testing.T) {
+	promptTemplateRepository := repository.NewMockPromptTemplateRepository(t)
+	promptTemplateRepository.EXPECT().GetPromptTemplate(mock.Anything, "evaluation", int32(1)).
+		Return(&model.PromptTemplate{Name: "evaluation", Version: 1, Body: testPromptTemplateBody}, nil)
+
+	_, err := NewPromptTemplateService(promptTemplateRepository).
+		RenderPromptTemplate(context.Background(), "evaluation", 1, map[string]any{"activities": []any{}})
+
+	var invalidPromptTemplateErr *InvalidPromptTemplateError
+	require.True(t, errors.As(err, &invalidPromptTemplateErr))
+}
+
+func TestPromptTemplateServiceReportsMissingTemplate(t *testing.T) {
+	promptTemplateRepository := repository.NewMockPromptTemplateRepository(t)
+	promptTemplateRepository.EXPECT().GetPromptTemplate(mock.Anything, "evaluation", int32(2)).Return(nil, nil)
+
+	_, err := NewPromptTemplateService(promptTemplateRepository).
+		RenderPromptTemplate(context.Background(), "evaluation", 2, nil)
+
+	var notFoundErr *PromptTemplateNotFoundError
+	require.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, int32(2), notFoundErr.Version)
+}
+
+func TestPromptTemplateServiceDoesNotCreateInvalidTemplate(t *testing.T) {
+	// The mock fails the test if the template is stored.
+	promptTemplateService := NewPromptTemplateService(repository.NewMockPromptTemplateRepository(t))
+
+	_, err := promptTemplateService.CreatePromptTemplate(context.Background(), "evaluation", "{{range .activities}}")
+
+	var invalidPromptTemplateErr *InvalidPromptTemplateError
+	require.True(t, errors.As(err, &invalidPromptTemplateErr))
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/config"
+)
+
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderFake   = "fake"
+)
+
+// ProviderFactory creates an LLMService backed by a specific model provider.
+type ProviderFactory func(ctx context.Context, cfg *config.AppConfig) (LLMService, error)
+
+var providerFactories = map[string]ProviderFactory{
+	ProviderGemini: NewGeminiClient,
+	ProviderOpenAI: NewOpenAICompatibleClient,
+	ProviderFake:   NewFakeClientFromFile,
+}
+
+// RegisterProvider makes a provider available under the given name, overriding the existing one if present.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providerFactories[name] = factory
+}
+
+// NewProvider creates an LLMService using the provider registered under the given name.
+func NewProvider(ctx context.Context, name string, cfg *config.AppConfig) (LLMService, error) {
+	factory, ok := providerFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown LLM provider: %s", name)
+	}
+
+	return factory(ctx, cfg)
+}
+
+// ProviderError is returned when the request to the model provider failed. StatusCode is the HTTP status
+// returned by the provider or zero if the request didn't reach it.
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Err        error
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s provider error: %v", e.Provider, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether the request may succeed if repeated: the provider is rate limiting, overloaded
+// or wasn't reached at all.
+func (e *ProviderError) Retryable() bool {
+	return e.StatusCode == 0 ||
+		e.StatusCode == http.StatusRequestTimeout ||
+		e.StatusCode == http.StatusTooManyRequests ||
+		e.StatusCode >= http.StatusInternalServerError
+}
+
+// UnsupportedAttachmentError is returned when the provider can't send attachments of the MIME type to its models.
+type UnsupportedAttachmentError struct {
+	Provider string
+	MIMEType string
+}
+
+func (e *UnsupportedAttachmentError) Error() string {
+	return fmt.Sprintf("%s provider doesn't support attachments of type %s", e.Provider, e.MIMEType)
+}
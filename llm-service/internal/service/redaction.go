@@ -0,0 +1,497 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// maxPlaceholderLength bounds the text held back from the stream while it may be the beginning of a placeholder.
+const maxPlaceholderLength = 24
+
+type piiKind string
+
+const (
+	piiEmail       piiKind = "EMAIL"
+	piiPhoneNumber piiKind = "PHONE"
+	piiAddress     piiKind = "ADDRESS"
+	piiName        piiKind = "NAME"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	// phoneNumberPattern matches international numbers starting with the country code and North American
+	// numbers, but not scores or years separated by dashes.
+	phoneNumberPattern = regexp.MustCompile(
+		`\+\d{1,3}(?:[\s.-]?\(?\d{1,4}\)?){2,5}\b|(?:\(\d{3}\)\s?|\b\d{3}[\s.-]?)\d{3}[\s.-]?\d{4}\b`)
+	// addressPattern matches street addresses with capitalized street names, optionally followed
+	// by the apartment, the city, the state and the ZIP code, and post office boxes.
+	addressPattern = regexp.MustCompile(
+		`\b\d{1,6}(?:\s+[A-Z][A-Za-z'.-]*){1,4}\s+(?i:street|st|avenue|ave|road|rd|boulevard|blvd|lane|ln|` +
+			`drive|dr|court|ct|way|place|pl|terrace|circle|parkway|pkwy|highway|hwy)\b\.?` +
+			`(?:,?\s+(?i:apt|apartment|suite|unit)\.?\s*#?\w+)?` +
+			`(?:,\s*[A-Z][A-Za-z]+(?:\s+[A-Z][A-Za-z]+)*,\s*[A-Z]{2}(?:\s+\d{5}(?:-\d{4})?)?)?` +
+			`|\b(?i:p\.?\s?o\.?\s+box)\s+\d+`)
+)
+
+// safetyPatterns match the phrases disclosing self-harm or abuse. They are deliberately broad, as a false
+// alert costs a counselor a glance, while a missed one may cost much more.
+var safetyPatterns = []struct {
+	category domain.SafetyCategory
+	pattern  *regexp.Regexp
+}{
+	{
+		category: domain.SafetyCategorySelfHarm,
+		pattern: regexp.MustCompile(`(?i)\b(?:kill(?:ing|ed)? myself|suicid(?:e|al)|end(?:ing)? my (?:own )?life|` +
+			`wan(?:t|ted|na) to die|self[- ]harm(?:ing)?|cut(?:ting)? myself|hurt(?:ing)? myself|overdos(?:e|ed|ing))\b`),
+	},
+	{
+		category: domain.SafetyCategoryAbuse,
+		pattern: regexp.MustCompile(`(?i)\b(?:abus(?:e|ed|ive)|molest(?:ed|ation)?|rape[ds]?|` +
+			`sexual(?:ly)? assault(?:ed)?|domestic violence|(?:hit|beat|hurt|touched|choked) me)\b`),
+	},
+}
+
+// RedactionPolicy tells which PII is replaced with placeholders in the prompts of a caller before they
+// leave llm-service, and whether the prompts are moderated.
+type RedactionPolicy struct {
+	Emails       bool `json:"emails"`
+	PhoneNumbers bool `json:"phoneNumbers"`
+	// Addresses are street addresses and post office boxes.
+	Addresses bool `json:"addresses"`
+	// Names are redacted wherever they appear as whole words, regardless of case.
+	Names []string `json:"names"`
+	// Moderation flags the disclosures of self-harm and abuse, so that counselors can be alerted.
+	Moderation bool `json:"moderation"`
+}
+
+func (p RedactionPolicy) redacts() bool {
+	return p.Emails || p.PhoneNumbers || p.Addresses || len(p.Names) > 0
+}
+
+// RedactionConfig holds the policies of the callers. The callers without their own policy follow Default.
+type RedactionConfig struct {
+	Default RedactionPolicy            `json:"default"`
+	Callers map[string]RedactionPolicy `json:"callers"`
+}
+
+func (c RedactionConfig) Policy(caller string) RedactionPolicy {
+	if policy, ok := c.Callers[caller]; ok {
+		return policy
+	}
+
+	return c.Default
+}
+
+// DefaultRedactionConfig redacts all PII which can be detected without lists of names and moderates
+// the prompts of all callers.
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{
+		Default: RedactionPolicy{Emails: true, PhoneNumbers: true, Addresses: true, Moderation: true},
+	}
+}
+
+// LoadRedactionConfig loads the policies from a JSON file, which replaces the default config entirely.
+// Empty path means the default config.
+func LoadRedactionConfig(path string) (RedactionConfig, error) {
+	if path == "" {
+		return DefaultRedactionConfig(), nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return RedactionConfig{}, fmt.Errorf("failed to read redaction config file: %v", err)
+	}
+
+	var config RedactionConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return RedactionConfig{}, fmt.Errorf("failed to parse redaction config file: %v", err)
+	}
+
+	return config, nil
+}
+
+type piiDetector struct {
+	kind    piiKind
+	pattern *regexp.Regexp
+}
+
+// compiledRedactionPolicy is a RedactionPolicy with the patterns compiled once, as the lists of names
+// may be long.
+type compiledRedactionPolicy struct {
+	detectors  []piiDetector
+	moderation bool
+}
+
+func compileRedactionPolicy(policy RedactionPolicy) compiledRedactionPolicy {
+	var detectors []piiDetector
+
+	// Emails go first, as they may contain names and numbers.
+	if policy.Emails {
+		detectors = append(detectors, piiDetector{kind: piiEmail, pattern: emailPattern})
+	}
+
+	if policy.Addresses {
+		detectors = append(detectors, piiDetector{kind: piiAddress, pattern: addressPattern})
+	}
+
+	if policy.PhoneNumbers {
+		detectors = append(detectors, piiDetector{kind: piiPhoneNumber, pattern: phoneNumberPattern})
+	}
+
+	if pattern := namesPattern(policy.Names); pattern != nil {
+		detectors = append(detectors, piiDetector{kind: piiName, pattern: pattern})
+	}
+
+	return compiledRedactionPolicy{detectors: detectors, moderation: policy.Moderation}
+}
+
+// namesPattern matches any of the names as whole words, or returns nil if there are no names.
+func namesPattern(names []string) *regexp.Regexp {
+	var alternatives []string
+	for _, name := range names {
+		words := strings.Fields(name)
+		if len(words) == 0 {
+			continue
+		}
+
+		for i, word := range words {
+			words[i] = regexp.QuoteMeta(word)
+		}
+
+		alternatives = append(alternatives, strings.Join(words, `\s+`))
+	}
+
+	if len(alternatives) == 0 {
+		return nil
+	}
+
+	// The longer names go first, so that a full name is redacted as a whole rather than word by word.
+	slices.SortStableFunc(alternatives, func(a, b string) int { return len(b) - len(a) })
+
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(alternatives, "|") + `)\b`)
+}
+
+// redactingLLMService replaces the PII in the chat history with numbered placeholders, such as [EMAIL_1],
+// before it is sent to the wrapped LLMService, and restores the values in the response, so that the PII
+// never reaches the providers. The attachments are sent as is. The chat history is also moderated,
+// and the disclosures of self-harm and abuse are reported as the safety flags of the response.
+type redactingLLMService struct {
+	LLMService
+	defaultPolicy compiledRedactionPolicy
+	policies      map[string]compiledRedactionPolicy
+}
+
+func NewRedactingLLMService(llmService LLMService, config RedactionConfig) LLMService {
+	policies := make(map[string]compiledRedactionPolicy, len(config.Callers))
+	for caller, policy := range config.Callers {
+		policies[caller] = compileRedactionPolicy(policy)
+	}
+
+	return &redactingLLMService{
+		LLMService:    llmService,
+		defaultPolicy: compileRedactionPolicy(config.Default),
+		policies:      policies,
+	}
+}
+
+func (s *redactingLLMService) policy(ctx context.Context) compiledRedactionPolicy {
+	if policy, ok := s.policies[localcontext.GetCaller(ctx).Name]; ok {
+		return policy
+	}
+
+	return s.defaultPolicy
+}
+
+func (s *redactingLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	policy := s.policy(ctx)
+	redaction := newRedaction(policy.detectors)
+
+	response, err := s.LLMService.GenerateResponse(
+		ctx, redaction.redactChatHistory(chatHistory), tools, structuredOutputSchema, options)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Message.Text = redaction.replacer(structuredOutputSchema != nil).Replace(response.Message.Text)
+	response.Message.ToolCalls = redaction.restoreToolCalls(response.Message.ToolCalls)
+
+	if policy.moderation {
+		response.SafetyFlags = moderateChatHistory(ctx, chatHistory)
+	}
+
+	return response, nil
+}
+
+func (s *redactingLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		policy := s.policy(ctx)
+		redaction := newRedaction(policy.detectors)
+		stream := s.LLMService.GenerateResponseStream(
+			ctx, redaction.redactChatHistory(chatHistory), tools, structuredOutputSchema, options)
+
+		restorer := streamRestorer{replacer: redaction.replacer(structuredOutputSchema != nil)}
+
+		for delta, err := range stream {
+			if err == nil {
+				delta.Text = restorer.restore(delta.Text)
+				delta.ToolCalls = redaction.restoreToolCalls(delta.ToolCalls)
+
+				if delta.Usage != nil {
+					delta.Text += restorer.flush()
+
+					if policy.moderation {
+						delta.SafetyFlags = moderateChatHistory(ctx, chatHistory)
+					}
+				}
+			}
+
+			if !yield(delta, err) {
+				return
+			}
+		}
+
+		if text := restorer.flush(); text != "" {
+			yield(&domain.MessageDelta{Text: text}, nil)
+		}
+	}
+}
+
+// Embed redacts the texts, but doesn't moderate them, as they are not written to be read by a model.
+func (s *redactingLLMService) Embed(ctx context.Context, texts []string, model string) (*domain.Embeddings, error) {
+	redaction := newRedaction(s.policy(ctx).detectors)
+
+	redacted := make([]string, len(texts))
+	for i, text := range texts {
+		redacted[i] = redaction.redactText(text)
+	}
+
+	return s.LLMService.Embed(ctx, redacted, model)
+}
+
+// redaction replaces the PII in a single request with placeholders numbered by the kind of PII, the same
+// value always with the same placeholder, and remembers the values to restore them in the response.
+type redaction struct {
+	detectors    []piiDetector
+	placeholders map[string]string
+	values       map[string]string
+	counts       map[piiKind]int
+}
+
+func newRedaction(detectors []piiDetector) *redaction {
+	return &redaction{
+		detectors:    detectors,
+		placeholders: make(map[string]string),
+		values:       make(map[string]string),
+		counts:       make(map[piiKind]int),
+	}
+}
+
+func (r *redaction) redactText(text string) string {
+	for _, detector := range r.detectors {
+		text = detector.pattern.ReplaceAllStringFunc(text, func(value string) string {
+			return r.placeholder(detector.kind, value)
+		})
+	}
+
+	return text
+}
+
+func (r *redaction) placeholder(kind piiKind, value string) string {
+	if placeholder, ok := r.placeholders[value]; ok {
+		return placeholder
+	}
+
+	r.counts[kind]++
+	placeholder := fmt.Sprintf("[%s_%d]", kind, r.counts[kind])
+
+	r.placeholders[value] = placeholder
+	r.values[placeholder] = value
+
+	return placeholder
+}
+
+// redactChatHistory redacts the texts, the tool calls and the tool results of the messages, leaving
+// the chat history itself untouched.
+func (r *redaction) redactChatHistory(chatHistory []domain.Message) []domain.Message {
+	if len(r.detectors) == 0 {
+		return chatHistory
+	}
+
+	redacted := make([]domain.Message, len(chatHistory))
+	for i, msg := range chatHistory {
+		msg.Text = r.redactText(msg.Text)
+
+		if len(msg.ToolCalls) > 0 {
+			toolCalls := make([]domain.ToolCall, len(msg.ToolCalls))
+			for j, toolCall := range msg.ToolCalls {
+				toolCall.Parameters = mapStrings(toolCall.Parameters, r.redactText)
+				toolCalls[j] = toolCall
+			}
+
+			msg.ToolCalls = toolCalls
+		}
+
+		if msg.ToolResult != nil {
+			toolResult := *msg.ToolResult
+			toolResult.Result = mapStrings(toolResult.Result, r.redactText)
+			msg.ToolResult = &toolResult
+		}
+
+		redacted[i] = msg
+	}
+
+	return redacted
+}
+
+// replacer restores the redacted values. The values are escaped when they are restored in structured
+// output, which is JSON.
+func (r *redaction) replacer(escapeJSON bool) *strings.Replacer {
+	oldnew := make([]string, 0, 2*len(r.values))
+	for placeholder, value := range r.values {
+		if escapeJSON {
+			encoded, _ := json.Marshal(value)
+			value = string(encoded[1 : len(encoded)-1])
+		}
+
+		oldnew = append(oldnew, placeholder, value)
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
+
+func (r *redaction) restoreToolCalls(toolCalls []domain.ToolCall) []domain.ToolCall {
+	if len(r.values) == 0 {
+		return toolCalls
+	}
+
+	replacer := r.replacer(false)
+	for i := range toolCalls {
+		toolCalls[i].Parameters = mapStrings(toolCalls[i].Parameters, replacer.Replace)
+	}
+
+	return toolCalls
+}
+
+// streamRestorer restores the redacted values in the text received in deltas. A placeholder may be split
+// between the deltas, so the text which may be the beginning of a placeholder is held back until
+// the next delta.
+type streamRestorer struct {
+	replacer *strings.Replacer
+	pending  string
+}
+
+func (r *streamRestorer) restore(text string) string {
+	text = r.pending + text
+	r.pending = ""
+
+	if start := strings.LastIndexByte(text, '['); start >= 0 && len(text)-start < maxPlaceholderLength &&
+		!strings.Contains(text[start:], "]") {
+		text, r.pending = text[:start], text[start:]
+	}
+
+	return r.replacer.Replace(text)
+}
+
+func (r *streamRestorer) flush() string {
+	text := r.replacer.Replace(r.pending)
+	r.pending = ""
+
+	return text
+}
+
+// mapStrings returns a copy of the map with the function applied to all strings in it, including
+// the strings nested in maps and slices.
+func mapStrings(values map[string]any, f func(string) string) map[string]any {
+	if values == nil {
+		return nil
+	}
+
+	mapped := make(map[string]any, len(values))
+	for key, value := range values {
+		mapped[key] = mapStringsInValue(value, f)
+	}
+
+	return mapped
+}
+
+func mapStringsInValue(value any, f func(string) string) any {
+	switch value := value.(type) {
+	case string:
+		return f(value)
+	case map[string]any:
+		return mapStrings(value, f)
+	case []any:
+		mapped := make([]any, len(value))
+		for i, item := range value {
+			mapped[i] = mapStringsInValue(item, f)
+		}
+
+		return mapped
+	default:
+		return value
+	}
+}
+
+// moderateChatHistory flags the disclosures of self-harm and abuse in the messages written by the users,
+// or by the callers on their behalf, such as the prompts with embedded essays. Every category is flagged
+// at most once per message.
+func moderateChatHistory(ctx context.Context, chatHistory []domain.Message) []domain.SafetyFlag {
+	var flags []domain.SafetyFlag
+
+	for i, msg := range chatHistory {
+		if msg.Role != domain.RoleUser && msg.Role != domain.RoleSystem {
+			continue
+		}
+
+		for _, safetyPattern := range safetyPatterns {
+			if phrase := safetyPattern.pattern.FindString(msg.Text); phrase != "" {
+				flags = append(flags, domain.SafetyFlag{
+					Category:     safetyPattern.category,
+					MessageIndex: i,
+					Phrase:       strings.ToLower(phrase),
+				})
+			}
+		}
+	}
+
+	if len(flags) > 0 {
+		caller := localcontext.GetCaller(ctx)
+		log.L(ctx).Warnf("Request of user %s from %s flagged for %s", caller.UserID, caller.Name, safetyCategories(flags))
+	}
+
+	return flags
+}
+
+func safetyCategories(flags []domain.SafetyFlag) string {
+	var categories []string
+	for _, flag := range flags {
+		if !slices.Contains(categories, string(flag.Category)) {
+			categories = append(categories, string(flag.Category))
+		}
+	}
+
+	return strings.Join(categories, ", ")
+}
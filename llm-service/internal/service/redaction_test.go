@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// echoLLMService responds with the text of the last message, streamed in chunks of three bytes,
+// and records the chat histories it receives.
+type echoLLMService struct {
+	LLMService
+	chatHistories [][]domain.Message
+	texts         []string
+}
+
+func (s *echoLLMService) GenerateResponse(
+	_ context.Context, chatHistory []domain.Message, _ []domain.ToolDefinition, _ *domain.Schema, _ domain.GenerationOptions,
+) (*domain.Response, error) {
+	s.chatHistories = append(s.chatHistories, chatHistory)
+
+	return &domain.Response{Message: domain.Message{
+		Role: domain.RoleAssistant,
+		Text: chatHistory[len(chatHistory)-1].Text,
+		ToolCalls: []domain.ToolCall{{Name: "send_email", Parameters: map[string]any{
+			"to": []any{"[EMAIL_1]"},
+		}}},
+	}}, nil
+}
+
+func (s *echoLLMService) GenerateResponseStream(
+	_ context.Context, chatHistory []domain.Message, _ []domain.ToolDefinition, _ *domain.Schema, _ domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		text := chatHistory[len(chatHistory)-1].Text
+		for len(text) > 3 {
+			if !yield(&domain.MessageDelta{Text: text[:3]}, nil) {
+				return
+			}
+
+			text = text[3:]
+		}
+
+		yield(&domain.MessageDelta{Text: text, Usage: &domain.Usage{}}, nil)
+	}
+}
+
+func (s *echoLLMService) Embed(_ context.Context, texts []string, _ string) (*domain.Embeddings, error) {
+	s.texts = texts
+	return &domain.Embeddings{}, nil
+}
+
+var testRedactionConfig = RedactionConfig{
+	Default: RedactionPolicy{Emails: true, PhoneNumbers: true, Addresses: true,
+		Names: []string{"Jane", "Jane Doe"}, Moderation: true},
+	Callers: map[string]RedactionPolicy{"college-service": {}},
+}
+
+func callerContext(name string) context.Context {
+	ctx := context.Background()
+	localcontext.SetCaller(&ctx, domain.Caller{Name: name})
+
+	return ctx
+}
+
+func TestRedactingLLMServiceRedactsPIIAndRestoresIt(t *testing.T) {
+	provider := &echoLLMService{}
+	llmService := NewRedactingLLMService(provider, testRedactionConfig)
+
+	text := "Jane Doe (jane.doe@example.com, +1 (555) 123-4567) lives at 42 Maple Street, Springfield, IL 62704. " +
+		"Write to jane.doe@example.com, Jane."
+
+	response, err := llmService.GenerateResponse(callerContext("application-service"),
+		[]domain.Message{{Role: domain.RoleUser, Text: text}}, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[NAME_1] ([EMAIL_1], [PHONE_1]) lives at [ADDRESS_1]. Write to [EMAIL_1], [NAME_2].",
+		provider.chatHistories[0][0].Text)
+	assert.Equal(t, text, response.Message.Text)
+	assert.Equal(t, []any{"jane.doe@example.com"}, response.Message.ToolCalls[0].Parameters["to"])
+	assert.Empty(t, response.SafetyFlags)
+}
+
+func TestRedactingLLMServiceEscapesRestoredValuesInStructuredOutput(t *testing.T) {
+	config := RedactionConfig{Default: RedactionPolicy{Names: []string{`Jane "JD" Doe`}}}
+	llmService := NewRedactingLLMService(&echoLLMService{}, config)
+
+	response, err := llmService.GenerateResponse(context.Background(),
+		[]domain.Message{{Role: domain.RoleUser, Text: `{"name": "Jane \"JD\" Doe"}`}}, nil,
+		&domain.Schema{Type: domain.TypeObject}, domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	var output map[string]string
+	require.NoError(t, json.Unmarshal([]byte(response.Message.Text), &output))
+	assert.Equal(t, `Jane "JD" Doe`, output["name"])
+}
+
+func TestRedactingLLMServiceRestoresPlaceholdersSplitBetweenDeltas(t *testing.T) {
+	provider := &echoLLMService{}
+	llmService := NewRedactingLLMService(provider, testRedactionConfig)
+
+	text := "Dear Jane, please call 555-123-4567 [today]. I want to die."
+
+	var streamed string
+	var flags []domain.SafetyFlag
+
+	for delta, err := range llmService.GenerateResponseStream(callerContext("application-service"),
+		[]domain.Message{{Role: domain.RoleUser, Text: text}}, nil, nil, domain.GenerationOptions{}) {
+		require.NoError(t, err)
+
+		streamed += delta.Text
+		if delta.Usage != nil {
+			flags = delta.SafetyFlags
+		}
+	}
+
+	assert.Equal(t, text, streamed)
+	assert.Equal(t, []domain.SafetyFlag{
+		{Category: domain.SafetyCategorySelfHarm, MessageIndex: 0, Phrase: "want to die"},
+	}, flags)
+}
+
+func TestRedactingLLMServiceFollowsPolicyOfCaller(t *testing.T) {
+	provider := &echoLLMService{}
+	llmService := NewRedactingLLMService(provider, testRedactionConfig)
+
+	chatHistory := []domain.Message{
+		{Role: domain.RoleSystem, Text: "Evaluate the essay."},
+		{Role: domain.RoleUser, Text: "My father abused me. Contact jane@example.com."},
+	}
+
+	response, err := llmService.GenerateResponse(callerContext("college-service"), chatHistory, nil, nil,
+		domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, chatHistory, provider.chatHistories[0])
+	assert.Empty(t, response.SafetyFlags)
+
+	response, err = llmService.GenerateResponse(callerContext("application-service"), chatHistory, nil, nil,
+		domain.GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "My father abused me. Contact [EMAIL_1].", provider.chatHistories[1][1].Text)
+	assert.Equal(t, []domain.SafetyFlag{
+		{Category: domain.SafetyCategoryAbuse, MessageIndex: 1, Phrase: "abused"},
+	}, response.SafetyFlags)
+}
+
+func TestRedactingLLMServiceRedactsEmbeddedTexts(t *testing.T) {
+	provider := &echoLLMService{}
+	llmService := NewRedactingLLMService(provider, testRedactionConfig)
+
+	_, err := llmService.Embed(context.Background(), []string{"Jane wrote from jane@example.com"}, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"[NAME_1] wrote from [EMAIL_1]"}, provider.texts)
+}
+
+func TestPIIPatternsIgnoreScoresAndDates(t *testing.T) {
+	for _, text := range []string{
+		"SAT 1450-1500, ACT 33",
+		"Class of 2023-2024, GPA 3.95",
+		"I ran 5 miles down the road",
+		"Scored 1450 1500 1600 in three attempts",
+	} {
+		assert.Equal(t, text, newRedaction(compileRedactionPolicy(DefaultRedactionConfig().Default).detectors).redactText(text))
+	}
+}
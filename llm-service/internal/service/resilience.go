@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// ResilienceConfig configures how calls to the model provider are retried and when the provider is
+// considered unavailable.
+type ResilienceConfig struct {
+	// MaxRetries is how many times a call failing with a retryable error is repeated.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry, doubled before every next one up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// CallTimeout limits every attempt, including streaming of the whole response. Zero means no limit.
+	CallTimeout time.Duration
+	// FailureThreshold is how many consecutive calls have to fail for the circuit breaker to open.
+	FailureThreshold int
+	// OpenDuration is how long the circuit breaker stays open before it lets a trial call through.
+	OpenDuration time.Duration
+}
+
+// resilientLLMService retries the calls to the wrapped provider failing with retryable errors and stops calling
+// a model of the provider which keeps failing, using the fallback provider instead if one is configured.
+type resilientLLMService struct {
+	provider   string
+	llmService LLMService
+	fallback   LLMService
+	config     ResilienceConfig
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+// NewResilientLLMService wraps the provider with the given name. Fallback may be nil, in which case calls fail
+// with ProviderError while the circuit breaker is open.
+func NewResilientLLMService(provider string, llmService LLMService, fallback LLMService, config ResilienceConfig) LLMService {
+	return &resilientLLMService{
+		provider:   provider,
+		llmService: llmService,
+		fallback:   fallback,
+		config:     config,
+		breakers:   make(map[string]*circuitBreaker),
+	}
+}
+
+func (s *resilientLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	breaker := s.breaker(options.Model)
+	if !breaker.allow() {
+		if s.fallback != nil {
+			log.L(ctx).Warnf("Circuit breaker of %s is open, falling back to the secondary provider", s.breakerKey(options.Model))
+			return s.fallback.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, fallbackOptions(options))
+		}
+
+		return nil, s.circuitOpenError(options.Model)
+	}
+
+	var response *domain.Response
+	err := s.retry(ctx, breaker, func(ctx context.Context) error {
+		var err error
+		response, err = s.llmService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+		return err
+	})
+
+	return response, err
+}
+
+// GenerateResponseStream retries the stream only until the first delta is received, as the deltas
+// which were already sent to the caller can't be taken back.
+func (s *resilientLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		breaker := s.breaker(options.Model)
+		if !breaker.allow() {
+			if s.fallback != nil {
+				log.L(ctx).Warnf("Circuit breaker of %s is open, falling back to the secondary provider", s.breakerKey(options.Model))
+
+				for delta, err := range s.fallback.GenerateResponseStream(
+					ctx, chatHistory, tools, structuredOutputSchema, fallbackOptions(options)) {
+					if !yield(delta, err) {
+						return
+					}
+				}
+
+				return
+			}
+
+			yield(nil, s.circuitOpenError(options.Model))
+			return
+		}
+
+		var stopped bool
+		err := s.retry(ctx, breaker, func(ctx context.Context) error {
+			var received bool
+
+			for delta, err := range s.llmService.GenerateResponseStream(ctx, chatHistory, tools, structuredOutputSchema, options) {
+				if err != nil {
+					if received {
+						// Stops retrying, while the failure still counts for the circuit breaker.
+						return &streamInterruptedError{err: err}
+					}
+
+					return err
+				}
+
+				received = true
+				if !yield(delta, nil) {
+					stopped = true
+					return nil
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
+
+func (s *resilientLLMService) Embed(ctx context.Context, texts []string, model string) (*domain.Embeddings, error) {
+	breaker := s.breaker(model)
+	if !breaker.allow() {
+		if s.fallback != nil {
+			log.L(ctx).Warnf("Circuit breaker of %s is open, falling back to the secondary provider", s.breakerKey(model))
+			// The fallback provider uses its default embedding model, see fallbackOptions.
+			return s.fallback.Embed(ctx, texts, "")
+		}
+
+		return nil, s.circuitOpenError(model)
+	}
+
+	var embeddings *domain.Embeddings
+	err := s.retry(ctx, breaker, func(ctx context.Context) error {
+		var err error
+		embeddings, err = s.llmService.Embed(ctx, texts, model)
+		return err
+	})
+
+	return embeddings, err
+}
+
+// retry calls the function until it succeeds, fails with a non-retryable error or the retries are exhausted.
+// Every attempt is limited by the call timeout.
+func (s *resilientLLMService) retry(ctx context.Context, breaker *circuitBreaker, call func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := s.callWithTimeout(ctx, call)
+		if err == nil {
+			breaker.recordSuccess()
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			// The caller gave up on the call, which tells nothing about the availability of the provider.
+			breaker.recordCanceled()
+			return err
+		}
+
+		retryable := s.isRetryable(ctx, err)
+		if retryable {
+			breaker.recordFailure()
+		} else {
+			// The provider is available, even though it rejected the request.
+			breaker.recordSuccess()
+		}
+
+		var interruptedErr *streamInterruptedError
+		if !retryable || errors.As(err, &interruptedErr) || attempt >= s.config.MaxRetries || !breaker.allow() {
+			return err
+		}
+
+		backoff := s.backoff(attempt)
+		log.L(ctx).Warnf("Call to %s failed, retrying in %s (attempt %d of %d): %v",
+			s.provider, backoff, attempt+1, s.config.MaxRetries, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (s *resilientLLMService) callWithTimeout(ctx context.Context, call func(ctx context.Context) error) error {
+	if s.config.CallTimeout <= 0 {
+		return call(ctx)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, s.config.CallTimeout)
+	defer cancel()
+
+	err := call(callCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return &ProviderError{
+			Provider:   s.provider,
+			StatusCode: http.StatusGatewayTimeout,
+			// The cause isn't wrapped, so that the timeout isn't mistaken for the deadline of the caller.
+			Err: fmt.Errorf("call didn't complete in %s: %v", s.config.CallTimeout, err),
+		}
+	}
+
+	return err
+}
+
+// isRetryable reports whether the failed call may succeed if repeated. Calls are never repeated
+// after the caller gave up on them.
+func (s *resilientLLMService) isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var providerErr *ProviderError
+	return errors.As(err, &providerErr) && providerErr.Retryable()
+}
+
+// backoff returns the exponentially growing delay before the retry with jitter, so that
+// the callers failed at the same time don't retry at the same time.
+func (s *resilientLLMService) backoff(attempt int) time.Duration {
+	backoff := s.config.InitialBackoff << attempt
+	if backoff > s.config.MaxBackoff || backoff <= 0 {
+		backoff = s.config.MaxBackoff
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// fallbackOptions returns the options of the call to the fallback provider. The requested model belongs to
+// the primary provider, so the fallback one uses its default model instead.
+func fallbackOptions(options domain.GenerationOptions) domain.GenerationOptions {
+	options.Model = ""
+	return options
+}
+
+func (s *resilientLLMService) breaker(model string) *circuitBreaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.breakerKey(model)
+	breaker, ok := s.breakers[key]
+	if !ok {
+		breaker = &circuitBreaker{failureThreshold: s.config.FailureThreshold, openDuration: s.config.OpenDuration}
+		s.breakers[key] = breaker
+	}
+
+	return breaker
+}
+
+// breakerKey identifies the model of the provider. Empty model means the default model of the provider.
+func (s *resilientLLMService) breakerKey(model string) string {
+	if model == "" {
+		model = "default"
+	}
+
+	return s.provider + "/" + model
+}
+
+func (s *resilientLLMService) circuitOpenError(model string) error {
+	return &ProviderError{
+		Provider:   s.provider,
+		StatusCode: http.StatusServiceUnavailable,
+		Err:        fmt.Errorf("circuit breaker of %s is open", s.breakerKey(model)),
+	}
+}
+
+// streamInterruptedError marks the stream which failed after some deltas were sent to the caller.
+type streamInterruptedError struct {
+	err error
+}
+
+func (e *streamInterruptedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *streamInterruptedError) Unwrap() error {
+	return e.err
+}
+
+// circuitBreaker opens after failureThreshold consecutive failures and rejects calls for openDuration.
+// After that it lets a single trial call through, which closes the breaker if it succeeds.
+type circuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	openedAt            time.Time
+	trialInFlight       bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failureThreshold <= 0 || b.consecutiveFailures < b.failureThreshold {
+		return true
+	}
+
+	if time.Since(b.openedAt) < b.openDuration || b.trialInFlight {
+		return false
+	}
+
+	b.trialInFlight = true
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures = 0
+	b.trialInFlight = false
+}
+
+// recordCanceled lets another trial call through if the canceled call was the trial one, without changing
+// the state of the breaker.
+func (b *circuitBreaker) recordCanceled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialInFlight = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures++
+	b.trialInFlight = false
+
+	if b.consecutiveFailures >= b.failureThreshold {
+		b.openedAt = time.Now()
+	}
+}
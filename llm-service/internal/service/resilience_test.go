@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// failingLLMService fails the first calls with the given errors and delegates the rest to the fake client.
+type failingLLMService struct {
+	LLMService
+	errs        []error
+	calls       int
+	lastOptions domain.GenerationOptions
+}
+
+func newFailingLLMService(errs ...error) *failingLLMService {
+	return &failingLLMService{LLMService: NewFakeClient(nil), errs: errs}
+}
+
+func (s *failingLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	s.calls++
+	s.lastOptions = options
+	if s.calls <= len(s.errs) {
+		return nil, s.errs[s.calls-1]
+	}
+
+	return s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+}
+
+func providerError(statusCode int) error {
+	return &ProviderError{Provider: ProviderFake, StatusCode: statusCode, Err: errors.New(http.StatusText(statusCode))}
+}
+
+var testResilienceConfig = ResilienceConfig{
+	MaxRetries:       2,
+	InitialBackoff:   time.Millisecond,
+	MaxBackoff:       time.Millisecond,
+	FailureThreshold: 3,
+	OpenDuration:     time.Hour,
+}
+
+var testChatHistory = []domain.Message{{Role: domain.RoleUser, Text: "Hi"}}
+
+func TestResilientLLMServiceRetriesRetryableErrors(t *testing.T) {
+	provider := newFailingLLMService(providerError(http.StatusTooManyRequests), providerError(http.StatusBadGateway))
+	llmService := NewResilientLLMService(ProviderFake, provider, nil, testResilienceConfig)
+
+	_, err := llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, provider.calls)
+}
+
+func TestResilientLLMServiceDoesNotRetryRejectedRequests(t *testing.T) {
+	provider := newFailingLLMService(providerError(http.StatusBadRequest))
+	llmService := NewResilientLLMService(ProviderFake, provider, nil, testResilienceConfig)
+
+	_, err := llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+
+	var providerErr *ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.False(t, providerErr.Retryable())
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestResilientLLMServiceOpensCircuitBreaker(t *testing.T) {
+	unavailable := providerError(http.StatusServiceUnavailable)
+	provider := newFailingLLMService(unavailable, unavailable, unavailable)
+	llmService := NewResilientLLMService(ProviderFake, provider, nil, testResilienceConfig)
+
+	// The retries are exhausted and the breaker opens after the third failure.
+	_, err := llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+	require.ErrorIs(t, err, unavailable)
+	assert.Equal(t, 3, provider.calls)
+
+	_, err = llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+	var providerErr *ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, http.StatusServiceUnavailable, providerErr.StatusCode)
+	assert.Equal(t, 3, provider.calls)
+
+	// Breakers are kept per model.
+	_, err = llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{Model: "other"})
+	require.NoError(t, err)
+}
+
+func TestResilientLLMServiceFallsBackWhenCircuitBreakerIsOpen(t *testing.T) {
+	unavailable := providerError(http.StatusServiceUnavailable)
+	provider := newFailingLLMService(unavailable, unavailable, unavailable)
+	fallback := newFailingLLMService()
+	llmService := NewResilientLLMService(ProviderFake, provider, fallback, testResilienceConfig)
+	options := domain.GenerationOptions{Model: "primary-model"}
+
+	_, err := llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, options)
+	require.ErrorIs(t, err, unavailable)
+	assert.Zero(t, fallback.calls)
+
+	_, err = llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, options)
+	require.NoError(t, err)
+	assert.Equal(t, 3, provider.calls)
+	assert.Equal(t, 1, fallback.calls)
+
+	// The fallback provider doesn't know the models of the primary one.
+	assert.Empty(t, fallback.lastOptions.Model)
+}
+
+func TestResilientLLMServiceIgnoresCanceledTrialCalls(t *testing.T) {
+	config := testResilienceConfig
+	config.MaxRetries = 0
+	config.FailureThreshold = 1
+	config.OpenDuration = 0
+
+	provider := newFailingLLMService(providerError(http.StatusServiceUnavailable), context.Canceled)
+	llmService := NewResilientLLMService(ProviderFake, provider, nil, config)
+	breaker := llmService.(*resilientLLMService).breaker("")
+
+	_, err := llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+	require.Error(t, err)
+
+	// The trial call is canceled by the caller, so the breaker stays open.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = llmService.GenerateResponse(ctx, testChatHistory, nil, nil, domain.GenerationOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, breaker.consecutiveFailures)
+
+	// The next trial call is let through and closes the breaker.
+	_, err = llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, breaker.consecutiveFailures)
+}
+
+func TestResilientLLMServiceLimitsCallDuration(t *testing.T) {
+	config := testResilienceConfig
+	config.MaxRetries = 0
+	config.CallTimeout = time.Millisecond
+
+	llmService := NewResilientLLMService(ProviderFake, &slowLLMService{}, nil, config)
+
+	_, err := llmService.GenerateResponse(context.Background(), testChatHistory, nil, nil, domain.GenerationOptions{})
+
+	var providerErr *ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, http.StatusGatewayTimeout, providerErr.StatusCode)
+}
+
+// slowLLMService never responds before the context is done.
+type slowLLMService struct {
+	LLMService
+}
+
+func (s *slowLLMService) GenerateResponse(
+	ctx context.Context, _ []domain.Message, _ []domain.ToolDefinition, _ *domain.Schema, _ domain.GenerationOptions,
+) (*domain.Response, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// TokenCounter is implemented by the providers, which know how their models split prompts into tokens
+// and how many tokens the models accept.
+type TokenCounter interface {
+	// CountTokens counts the tokens of the chat history for the model, or for the default model if it is empty.
+	CountTokens(ctx context.Context, chatHistory []domain.Message, model string) (*domain.TokenCount, error)
+}
+
+const (
+	// charsPerToken is the average length of a token of English text in the tokenizers of popular models.
+	charsPerToken = 4
+	// messageTokenOverhead accounts for the role and the separators of every message.
+	messageTokenOverhead = 4
+	// attachmentTokens is a rough estimate of an attachment, as the models spend a few hundred tokens
+	// on an image or on a page of a document.
+	attachmentTokens = 1024
+)
+
+// estimateTokens estimates the number of tokens of the text for the providers which can't count them.
+func estimateTokens(text string) int32 {
+	return int32((utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken)
+}
+
+func estimateChatHistoryTokens(chatHistory []domain.Message) int32 {
+	var tokens int32
+	for _, msg := range chatHistory {
+		tokens += messageTokenOverhead + estimateTokens(messageTokenText(msg))
+		tokens += int32(len(msg.Attachments)) * attachmentTokens
+	}
+
+	return tokens
+}
+
+// messageTokenText returns everything of the message that is sent to the model as text, including
+// the encoded tool calls and results, but not the attachments.
+func messageTokenText(msg domain.Message) string {
+	var text strings.Builder
+	text.WriteString(msg.Text)
+
+	for _, toolCall := range msg.ToolCalls {
+		encoded, _ := json.Marshal(toolCall)
+		text.Write(encoded)
+	}
+
+	if msg.ToolResult != nil {
+		encoded, _ := json.Marshal(msg.ToolResult)
+		text.Write(encoded)
+	}
+
+	return text.String()
+}
+
+// tokenEstimator estimates the tokens of parts of the prompt. The estimates are scaled so that they add up
+// to the count of the whole prompt reported by the provider.
+type tokenEstimator struct {
+	scale float64
+}
+
+func newTokenEstimator(chatHistory []domain.Message, promptTokens int32) tokenEstimator {
+	estimated := estimateChatHistoryTokens(chatHistory)
+	if estimated == 0 || promptTokens == 0 {
+		return tokenEstimator{scale: 1}
+	}
+
+	return tokenEstimator{scale: float64(promptTokens) / float64(estimated)}
+}
+
+func (e tokenEstimator) tokens(text string) int32 {
+	return int32(math.Ceil(float64(estimateTokens(text)) * e.scale))
+}
+
+// runes returns how many characters of text make up the given number of tokens.
+func (e tokenEstimator) runes(tokens int32) int {
+	return int(float64(tokens) / e.scale * charsPerToken)
+}
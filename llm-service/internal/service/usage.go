@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+type UsageService interface {
+	GetUsage(ctx context.Context, filter model.UsageFilter) ([]model.UsageAggregate, error)
+}
+
+type usageService struct {
+	usageRepository repository.UsageRepository
+}
+
+func NewUsageService(usageRepository repository.UsageRepository) UsageService {
+	return &usageService{usageRepository: usageRepository}
+}
+
+func (s *usageService) GetUsage(ctx context.Context, filter model.UsageFilter) ([]model.UsageAggregate, error) {
+	return s.usageRepository.GetUsageAggregates(ctx, filter)
+}
+
+// usageRecordingLLMService measures the latency of every call to the wrapped LLMService and
+// records the usage in the ledger on behalf of the caller from the context.
+type usageRecordingLLMService struct {
+	LLMService
+	usageRepository repository.UsageRepository
+}
+
+func NewUsageRecordingLLMService(llmService LLMService, usageRepository repository.UsageRepository) LLMService {
+	return &usageRecordingLLMService{
+		LLMService:      llmService,
+		usageRepository: usageRepository,
+	}
+}
+
+func (s *usageRecordingLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	startedAt := time.Now()
+
+	response, err := s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Usage.Latency = time.Since(startedAt)
+	s.recordUsage(ctx, response.Usage)
+
+	return response, nil
+}
+
+func (s *usageRecordingLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		startedAt := time.Now()
+
+		for delta, err := range s.LLMService.GenerateResponseStream(ctx, chatHistory, tools, structuredOutputSchema, options) {
+			if err == nil && delta.Usage != nil {
+				delta.Usage.Latency = time.Since(startedAt)
+				s.recordUsage(ctx, *delta.Usage)
+			}
+
+			if !yield(delta, err) {
+				return
+			}
+		}
+	}
+}
+
+// recordUsage doesn't fail the call if the ledger is unavailable, as the response is already generated.
+func (s *usageRecordingLLMService) recordUsage(ctx context.Context, usage domain.Usage) {
+	caller := localcontext.GetCaller(ctx)
+
+	err := s.usageRepository.CreateUsageRecord(ctx, model.UsageRecord{
+		Caller:           caller.Name,
+		UserID:           caller.UserID,
+		Model:            usage.Model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Latency:          usage.Latency,
+		CreatedAt:        time.Now().UTC(),
+	})
+	if err != nil {
+		log.L(ctx).Errorf("Failed to record LLM usage of caller %s and user %s: %v", caller.Name, caller.UserID, err)
+	}
+}
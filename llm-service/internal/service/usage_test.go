@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	localcontext "github.com/compendium-tech/compendium/llm-service/internal/context"
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+	"github.com/compendium-tech/compendium/llm-service/internal/model"
+	"github.com/compendium-tech/compendium/llm-service/internal/repository"
+)
+
+func TestUsageRecordingLLMServiceRecordsCallerUsage(t *testing.T) {
+	ctx := context.Background()
+	localcontext.SetCaller(&ctx, domain.Caller{Name: "application-service", UserID: "user"})
+
+	usageRepository := repository.NewMockUsageRepository(t)
+	usageRepository.EXPECT().CreateUsageRecord(mock.Anything, mock.MatchedBy(func(record model.UsageRecord) bool {
+		return record.Caller == "application-service" && record.UserID == "user" &&
+			record.Model == "fake" && record.PromptTokens == 2 && record.CompletionTokens > 0
+	})).Return(nil).Twice()
+
+	llmService := NewUsageRecordingLLMService(NewFakeClient(nil), usageRepository)
+	chatHistory := []domain.Message{{Role: domain.RoleUser, Text: "Hello there"}}
+
+	response, err := llmService.GenerateResponse(ctx, chatHistory, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "fake", response.Usage.Model)
+
+	var usage *domain.Usage
+	for delta, err := range llmService.GenerateResponseStream(ctx, chatHistory, nil, nil, domain.GenerationOptions{}) {
+		require.NoError(t, err)
+
+		if delta.Usage != nil {
+			usage = delta.Usage
+		}
+	}
+
+	require.NotNil(t, usage)
+	assert.Equal(t, response.Usage.CompletionTokens, usage.CompletionTokens)
+}
+
+func TestUsageRecordingLLMServiceIgnoresLedgerFailures(t *testing.T) {
+	usageRepository := repository.NewMockUsageRepository(t)
+	usageRepository.EXPECT().CreateUsageRecord(mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	llmService := NewUsageRecordingLLMService(NewFakeClient(nil), usageRepository)
+
+	_, err := llmService.GenerateResponse(
+		context.Background(), []domain.Message{{Role: domain.RoleUser, Text: "Hi"}}, nil, nil, domain.GenerationOptions{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/compendium-tech/compendium/common/pkg/log"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+// InvalidStructuredOutputError is returned when the model keeps generating structured output
+// which doesn't conform to the requested schema.
+type InvalidStructuredOutputError struct {
+	Violations []domain.SchemaViolation
+}
+
+func (e *InvalidStructuredOutputError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		descriptions[i] = fmt.Sprintf("%s: %s", violation.Path, violation.Description)
+	}
+
+	return fmt.Sprintf("structured output doesn't conform to the schema: %s", strings.Join(descriptions, "; "))
+}
+
+// validatingLLMService validates the structured output of the wrapped LLMService against the requested
+// schema. Invalid responses are sent back to the model with the list of violations, so that it can repair
+// them, at most repairAttempts times.
+type validatingLLMService struct {
+	LLMService
+	repairAttempts int
+}
+
+func NewValidatingLLMService(llmService LLMService, repairAttempts int) LLMService {
+	return &validatingLLMService{
+		LLMService:     llmService,
+		repairAttempts: repairAttempts,
+	}
+}
+
+func (s *validatingLLMService) GenerateResponse(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) (*domain.Response, error) {
+	if structuredOutputSchema == nil {
+		return s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+	}
+
+	var usage domain.Usage
+
+	for attempt := 0; ; attempt++ {
+		response, err := s.LLMService.GenerateResponse(ctx, chatHistory, tools, structuredOutputSchema, options)
+		if err != nil {
+			return nil, err
+		}
+
+		// All attempts are paid for, so the usage of the response includes them.
+		usage = addUsage(usage, response.Usage)
+		response.Usage = usage
+
+		// The model doesn't produce structured output when it calls tools.
+		if len(response.Message.ToolCalls) > 0 {
+			return response, nil
+		}
+
+		violations := ValidateStructuredOutput(structuredOutputSchema, response.Message.Text)
+		if len(violations) == 0 {
+			return response, nil
+		}
+
+		if attempt >= s.repairAttempts {
+			return nil, &InvalidStructuredOutputError{Violations: violations}
+		}
+
+		log.L(ctx).Warnf("Structured output doesn't conform to the schema, asking the model to repair it (attempt %d of %d)",
+			attempt+1, s.repairAttempts)
+
+		chatHistory = append(chatHistory[:len(chatHistory):len(chatHistory)],
+			response.Message, repairPrompt(violations))
+	}
+}
+
+// GenerateResponseStream can't repair the output, which was already sent to the caller, so it only
+// reports invalid structured output as an error at the end of the stream.
+func (s *validatingLLMService) GenerateResponseStream(
+	ctx context.Context,
+	chatHistory []domain.Message,
+	tools []domain.ToolDefinition,
+	structuredOutputSchema *domain.Schema,
+	options domain.GenerationOptions,
+) iter.Seq2[*domain.MessageDelta, error] {
+	stream := s.LLMService.GenerateResponseStream(ctx, chatHistory, tools, structuredOutputSchema, options)
+	if structuredOutputSchema == nil {
+		return stream
+	}
+
+	return func(yield func(*domain.MessageDelta, error) bool) {
+		var text strings.Builder
+		var hasToolCalls bool
+
+		for delta, err := range stream {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			text.WriteString(delta.Text)
+			hasToolCalls = hasToolCalls || len(delta.ToolCalls) > 0
+
+			// The usage is sent last, so the output is validated before it.
+			if delta.Usage != nil && !hasToolCalls {
+				violations := ValidateStructuredOutput(structuredOutputSchema, text.String())
+				if len(violations) > 0 {
+					yield(nil, &InvalidStructuredOutputError{Violations: violations})
+					return
+				}
+			}
+
+			if !yield(delta, nil) {
+				return
+			}
+		}
+	}
+}
+
+func repairPrompt(violations []domain.SchemaViolation) domain.Message {
+	var prompt strings.Builder
+	prompt.WriteString("Your previous response doesn't conform to the required JSON schema:\n")
+
+	for _, violation := range violations {
+		fmt.Fprintf(&prompt, "- %s: %s\n", violation.Path, violation.Description)
+	}
+
+	prompt.WriteString("Respond again with the complete JSON that fixes these problems and conforms to the schema.")
+
+	return domain.Message{Role: domain.RoleUser, Text: prompt.String()}
+}
+
+func addUsage(a, b domain.Usage) domain.Usage {
+	return domain.Usage{
+		PromptTokens:     a.PromptTokens + b.PromptTokens,
+		CompletionTokens: a.CompletionTokens + b.CompletionTokens,
+		Model:            b.Model,
+		Latency:          a.Latency + b.Latency,
+		CacheStatus:      b.CacheStatus,
+	}
+}
+
+// ValidateStructuredOutput checks that the text is JSON conforming to the schema and returns all violations found.
+func ValidateStructuredOutput(schema *domain.Schema, text string) []domain.SchemaViolation {
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return []domain.SchemaViolation{{Path: "$", Description: fmt.Sprintf("response is not valid JSON: %v", err)}}
+	}
+
+	return validateSchemaValue(schema, value, "$")
+}
+
+// stringFormatLayouts are the layouts of the string formats which are validated. Other formats are accepted as is.
+var stringFormatLayouts = map[string]string{
+	"date-time": time.RFC3339,
+	"date":      time.DateOnly,
+}
+
+func validateSchemaValue(schema *domain.Schema, value any, path string) []domain.SchemaViolation {
+	violation := func(format string, args ...any) []domain.SchemaViolation {
+		return []domain.SchemaViolation{{Path: path, Description: fmt.Sprintf(format, args...)}}
+	}
+
+	if value == nil {
+		if schema.Nullable {
+			return nil
+		}
+
+		return violation("expected a non-null value")
+	}
+
+	if len(schema.AnyOf) > 0 {
+		for _, option := range schema.AnyOf {
+			if len(validateSchemaValue(&option, value, path)) == 0 {
+				return nil
+			}
+		}
+
+		return violation("expected a value conforming to one of %d schemas", len(schema.AnyOf))
+	}
+
+	switch schema.Type {
+	case domain.TypeObject:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return violation("expected an object")
+		}
+
+		var violations []domain.SchemaViolation
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				violations = append(violations, domain.SchemaViolation{
+					Path:        path,
+					Description: fmt.Sprintf("required property %q is missing", name),
+				})
+			}
+		}
+
+		// Properties are visited in a stable order, so that repair prompts are the same for the same output.
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			if propValue, ok := object[name]; ok {
+				prop := schema.Properties[name]
+				violations = append(violations, validateSchemaValue(&prop, propValue, path+"."+name)...)
+			}
+		}
+
+		return violations
+	case domain.TypeArray:
+		array, ok := value.([]any)
+		if !ok {
+			return violation("expected an array")
+		}
+
+		var violations []domain.SchemaViolation
+		if schema.MinItems != nil && int64(len(array)) < *schema.MinItems {
+			violations = append(violations, violation("expected at least %d items, got %d", *schema.MinItems, len(array))...)
+		}
+
+		if schema.MaxItems != nil && int64(len(array)) > *schema.MaxItems {
+			violations = append(violations, violation("expected at most %d items, got %d", *schema.MaxItems, len(array))...)
+		}
+
+		if schema.Items != nil {
+			for i, item := range array {
+				violations = append(violations, validateSchemaValue(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+
+		return violations
+	case domain.TypeString:
+		str, ok := value.(string)
+		if !ok {
+			return violation("expected a string")
+		}
+
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, str) {
+			return violation("expected one of %s, got %q", strings.Join(schema.Enum, ", "), str)
+		}
+
+		if layout, ok := stringFormatLayouts[schema.Format]; ok {
+			if _, err := time.Parse(layout, str); err != nil {
+				return violation("expected a string in %s format, got %q", schema.Format, str)
+			}
+		}
+	case domain.TypeNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return violation("expected a number")
+		}
+
+		return validateNumberBounds(schema, number, path)
+	case domain.TypeInteger:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return violation("expected an integer")
+		}
+
+		return validateNumberBounds(schema, number, path)
+	case domain.TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return violation("expected a boolean")
+		}
+	}
+
+	return nil
+}
+
+func validateNumberBounds(schema *domain.Schema, number float64, path string) []domain.SchemaViolation {
+	if schema.Minimum != nil && number < *schema.Minimum {
+		return []domain.SchemaViolation{{
+			Path: path, Description: fmt.Sprintf("expected a value of at least %v, got %v", *schema.Minimum, number),
+		}}
+	}
+
+	if schema.Maximum != nil && number > *schema.Maximum {
+		return []domain.SchemaViolation{{
+			Path: path, Description: fmt.Sprintf("expected a value of at most %v, got %v", *schema.Maximum, number),
+		}}
+	}
+
+	return nil
+}
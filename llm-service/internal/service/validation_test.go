@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/compendium-tech/compendium/llm-service/internal/domain"
+)
+
+func evaluationSchema() *domain.Schema {
+	minItems := int64(1)
+
+	return &domain.Schema{
+		Type: domain.TypeObject,
+		Properties: map[string]domain.Schema{
+			"score": {Type: domain.TypeInteger},
+			"suggestions": {
+				Type:     domain.TypeArray,
+				Items:    &domain.Schema{Type: domain.TypeString},
+				MinItems: &minItems,
+			},
+		},
+		Required: []string{"score", "suggestions"},
+	}
+}
+
+func TestValidateStructuredOutput(t *testing.T) {
+	schema := evaluationSchema()
+
+	assert.Empty(t, ValidateStructuredOutput(schema, `{"score": 7, "suggestions": ["Be concise"]}`))
+
+	assert.Equal(t, []domain.SchemaViolation{
+		{Path: "$", Description: `required property "suggestions" is missing`},
+		{Path: "$.score", Description: "expected an integer"},
+	}, ValidateStructuredOutput(schema, `{"score": 7.5}`))
+
+	assert.Equal(t, []domain.SchemaViolation{
+		{Path: "$.suggestions", Description: "expected at least 1 items, got 0"},
+	}, ValidateStructuredOutput(schema, `{"score": 7, "suggestions": []}`))
+
+	assert.Equal(t, []domain.SchemaViolation{
+		{Path: "$.suggestions[1]", Description: "expected a string"},
+	}, ValidateStructuredOutput(schema, `{"score": 7, "suggestions": ["Be concise", 1]}`))
+
+	violations := ValidateStructuredOutput(schema, `Sure! Here is the JSON`)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "$", violations[0].Path)
+}
+
+func TestValidateStructuredOutputConstraints(t *testing.T) {
+	minimum, maximum := float64(1), float64(10)
+	schema := &domain.Schema{
+		Type: domain.TypeObject,
+		Properties: map[string]domain.Schema{
+			"rating":  {Type: domain.TypeInteger, Minimum: &minimum, Maximum: &maximum},
+			"verdict": {Type: domain.TypeString, Enum: []string{"reach", "target", "safety"}},
+			"deadline": {
+				AnyOf: []domain.Schema{{Type: domain.TypeString, Format: "date"}, {Type: domain.TypeInteger}},
+			},
+			"comment": {Type: domain.TypeString, Nullable: true},
+		},
+	}
+
+	assert.Empty(t, ValidateStructuredOutput(schema,
+		`{"rating": 10, "verdict": "reach", "deadline": "2025-01-01", "comment": null}`))
+	assert.Empty(t, ValidateStructuredOutput(schema, `{"deadline": 20250101}`))
+
+	assert.Equal(t, []domain.SchemaViolation{
+		{Path: "$.deadline", Description: "expected a value conforming to one of 2 schemas"},
+		{Path: "$.rating", Description: "expected a value of at most 10, got 11"},
+		{Path: "$.verdict", Description: `expected one of reach, target, safety, got "likely"`},
+	}, ValidateStructuredOutput(schema, `{"rating": 11, "verdict": "likely", "deadline": "January"}`))
+
+	assert.Equal(t, []domain.SchemaViolation{
+		{Path: "$.verdict", Description: "expected a non-null value"},
+	}, ValidateStructuredOutput(schema, `{"verdict": null}`))
+
+	// Synthesized values conform to the constraints.
+	synthesized, err := json.Marshal(SynthesizeSchemaValue(schema))
+	require.NoError(t, err)
+	assert.Empty(t, ValidateStructuredOutput(schema, string(synthesized)))
+}
+
+func TestValidatingLLMServiceRepairsInvalidOutput(t *testing.T) {
+	schema := evaluationSchema()
+	prompt := []domain.Message{{Role: domain.RoleUser, Text: "Evaluate my essay"}}
+	invalid := domain.Message{Role: domain.RoleAssistant, Text: `{"score": 7}`}
+	valid := domain.Message{Role: domain.RoleAssistant, Text: `{"score": 7, "suggestions": ["Be concise"]}`}
+	repair := repairPrompt(ValidateStructuredOutput(schema, invalid.Text))
+
+	llmService := NewValidatingLLMService(NewFakeClient(map[string]domain.Message{
+		PromptFingerprint(prompt):                          invalid,
+		PromptFingerprint(append(prompt, invalid, repair)): valid,
+	}), 1)
+
+	response, err := llmService.GenerateResponse(context.Background(), prompt, nil, schema, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, valid, response.Message)
+
+	// Both attempts are accounted for.
+	first, err := NewFakeClient(nil).GenerateResponse(context.Background(), prompt, nil, nil, domain.GenerationOptions{})
+	require.NoError(t, err)
+	assert.Greater(t, response.Usage.PromptTokens, first.Usage.PromptTokens)
+}
+
+func TestValidatingLLMServiceFailsWhenRepairsAreExhausted(t *testing.T) {
+	schema := evaluationSchema()
+	prompt := []domain.Message{{Role: domain.RoleUser, Text: "Evaluate my essay"}}
+	invalid := domain.Message{Role: domain.RoleAssistant, Text: `{"score": 7}`}
+	repair := repairPrompt(ValidateStructuredOutput(schema, invalid.Text))
+
+	llmService := NewValidatingLLMService(NewFakeClient(map[string]domain.Message{
+		PromptFingerprint(prompt):                          invalid,
+		PromptFingerprint(append(prompt, invalid, repair)): invalid,
+	}), 1)
+
+	_, err := llmService.GenerateResponse(context.Background(), prompt, nil, schema, domain.GenerationOptions{})
+
+	var invalidStructuredOutputErr *InvalidStructuredOutputError
+	require.True(t, errors.As(err, &invalidStructuredOutputErr))
+	assert.Equal(t, []domain.SchemaViolation{
+		{Path: "$", Description: `required property "suggestions" is missing`},
+	}, invalidStructuredOutputErr.Violations)
+
+	var streamErr error
+	for _, err := range llmService.GenerateResponseStream(
+		context.Background(), prompt, nil, schema, domain.GenerationOptions{}) {
+		streamErr = err
+	}
+
+	assert.True(t, errors.As(streamErr, &invalidStructuredOutputErr))
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS prompt_templates (
+  name TEXT NOT NULL,
+  version INTEGER NOT NULL,
+  body TEXT NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (name, version)
+);
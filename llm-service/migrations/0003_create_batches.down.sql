@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS llm_batch_items;
+DROP TABLE IF EXISTS llm_batches;
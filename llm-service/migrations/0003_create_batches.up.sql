@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS llm_batches (
+  id UUID PRIMARY KEY,
+  caller TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  tier TEXT NOT NULL,
+  provider_job_id TEXT NOT NULL DEFAULT '',
+  provider_job_polled_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS llm_batches_provider_job_idx ON llm_batches (provider_job_polled_at)
+  WHERE provider_job_id <> '';
+
+CREATE TABLE IF NOT EXISTS llm_batch_items (
+  batch_id UUID NOT NULL REFERENCES llm_batches (id) ON DELETE CASCADE,
+  item_index INTEGER NOT NULL,
+  custom_id TEXT NOT NULL,
+  status TEXT NOT NULL,
+  request JSONB NOT NULL,
+  response JSONB,
+  error TEXT NOT NULL DEFAULT '',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  claimed_at TIMESTAMP WITH TIME ZONE,
+  completed_at TIMESTAMP WITH TIME ZONE,
+
+  PRIMARY KEY (batch_id, item_index)
+);
+
+-- Pending items and the items whose lease may expire are claimed by the workers.
+CREATE INDEX IF NOT EXISTS llm_batch_items_unfinished_idx ON llm_batch_items (status, claimed_at)
+  WHERE status IN ('pending', 'running');
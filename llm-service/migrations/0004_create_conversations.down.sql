@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS llm_conversation_messages;
+DROP TABLE IF EXISTS llm_conversations;
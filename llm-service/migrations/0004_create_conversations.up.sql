@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS llm_conversations (
+  id UUID PRIMARY KEY,
+  caller TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  tier TEXT NOT NULL,
+  history_policy TEXT NOT NULL,
+  max_history_tokens INTEGER NOT NULL,
+  message_count INTEGER NOT NULL DEFAULT 0,
+  compacted_messages INTEGER NOT NULL DEFAULT 0,
+  summary TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS llm_conversations_expires_at_idx ON llm_conversations (expires_at);
+
+CREATE TABLE IF NOT EXISTS llm_conversation_messages (
+  conversation_id UUID NOT NULL REFERENCES llm_conversations (id) ON DELETE CASCADE,
+  message_index INTEGER NOT NULL,
+  message JSONB NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (conversation_id, message_index)
+);
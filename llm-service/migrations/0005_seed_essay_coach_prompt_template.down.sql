@@ -0,0 +1 @@
+DELETE FROM prompt_templates WHERE name = 'essay_coach' AND version = 1;
@@ -0,0 +1 @@
+DELETE FROM prompt_templates WHERE name = 'application_evaluation' AND version = 2;
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/compendium-tech/compendium/subscription-service/internal/model"
 	pb "github.com/compendium-tech/compendium/subscription-service/internal/proto/v1"
@@ -48,13 +49,13 @@ func (s SubscriptionServiceServer) GetSubscriptionTier(ctx context.Context, req
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID format: %v", err)
 	}
 
-	tier := s.subscriptionService.GetSubscriptionTierByMemberUserID(ctx, userID)
-	if tier == nil {
+	subscription := s.subscriptionService.GetSubscriptionByMemberUserID(ctx, userID)
+	if subscription == nil {
 		return &pb.GetSubscriptionTierResponse{Tier: pb.SubscriptionTier_NONE}, nil
 	}
 
 	var pbTier pb.SubscriptionTier
-	switch *tier {
+	switch subscription.Tier {
 	case model.TierStudent:
 		pbTier = pb.SubscriptionTier_STUDENT
 	case model.TierTeam:
@@ -64,6 +65,8 @@ func (s SubscriptionServiceServer) GetSubscriptionTier(ctx context.Context, req
 	}
 
 	return &pb.GetSubscriptionTierResponse{
-		Tier: pbTier,
+		Tier:        pbTier,
+		PeriodStart: timestamppb.New(subscription.Since),
+		PeriodEnd:   timestamppb.New(subscription.Till),
 	}, nil
 }
@@ -19,7 +19,8 @@ import (
 )
 
 type SubscriptionService interface {
-	GetSubscriptionTierByMemberUserID(ctx context.Context, userID uuid.UUID) *model.Tier
+	// GetSubscriptionByMemberUserID returns the subscription the user is a member of, or nil.
+	GetSubscriptionByMemberUserID(ctx context.Context, userID uuid.UUID) *model.Subscription
 	GetSubscription(ctx context.Context) domain.SubscriptionResponse
 	GetSubscriptionInvitationCode(ctx context.Context) domain.InvitationCodeResponse
 	UpdateSubscriptionInvitationCode(ctx context.Context) domain.InvitationCodeResponse
@@ -49,18 +50,14 @@ func NewSubscriptionService(
 	}
 }
 
-func (s *subscriptionService) GetSubscriptionTierByMemberUserID(ctx context.Context, userID uuid.UUID) *model.Tier {
+func (s *subscriptionService) GetSubscriptionByMemberUserID(ctx context.Context, userID uuid.UUID) *model.Subscription {
 	logger := log.L(ctx).WithField("userId", userID)
-	logger.Info("Getting subscription tier")
+	logger.Info("Getting subscription of member")
 
 	subscription := s.subscriptionRepository.FindSubscriptionByMemberUserID(ctx, userID)
 	logger.Info("Subscription details fetched successfully")
 
-	if subscription == nil {
-		return nil
-	}
-
-	return &subscription.Tier
+	return subscription
 }
 
 func (s *subscriptionService) GetSubscription(ctx context.Context) domain.SubscriptionResponse {